@@ -2,18 +2,30 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
+	"unicode/utf8"
 	"voxflow/internal/audio"
 	"voxflow/internal/config"
 	"voxflow/internal/gemini"
 	"voxflow/internal/history"
 	"voxflow/internal/hotkey"
 	"voxflow/internal/injection"
+	"voxflow/internal/loginitem"
+	"voxflow/internal/notify"
+	"voxflow/internal/openai"
+	"voxflow/internal/server"
+	"voxflow/internal/sound"
+	"voxflow/internal/textproc"
 	"voxflow/internal/whisper"
 
+	"github.com/wailsapp/wails/v2/pkg/menu"
 	"github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
@@ -26,16 +38,56 @@ type App struct {
 	audioRecorder           *audio.Recorder
 	whisperService          *whisper.Service
 	geminiClient            *gemini.Client
+	openaiClient            *openai.Client
+	refinementMenuItem      *menu.MenuItem // "AI Refinement" checkbox, kept in sync by SetRefinementEnabled
 	historyService          *history.Service
 	injectionService        *injection.Service
 	modelReady              bool
+	windowMu                sync.Mutex         // Mutex for isMiniMode and ignoreMovesUntil
 	isMiniMode              bool               // Tracks if app is in mini indicator mode
+	ignoreMovesUntil        time.Time          // Window moves before this are our own, not the user's
 	userExplicitlyMaximized bool               // Tracks if user manually opened full app (don't auto-minimize)
 	downloadCancel          context.CancelFunc // Cancel function for active download
+	activeDownload          string             // Model owning downloadCancel
+	downloading             map[string]bool    // Models with a download in flight (guarded by downloadMu)
 	downloadMu              sync.Mutex         // Mutex for download operations
-	positionWatchCancel     context.CancelFunc // Cancel function for position polling
+	benchmarkCancel         context.CancelFunc // Cancel function for active model benchmark
+	benchmarkMu             sync.Mutex         // Mutex for benchmarkCancel
+	positionSaveTimer       *time.Timer        // Pending debounced save of the window position
+	positionSaveMu          sync.Mutex         // Mutex for positionSaveTimer
+	localServer             *server.Server     // Optional localhost status/events server
+	localServerMu           sync.Mutex         // Mutex for localServer
+	privacyMode             atomic.Bool        // Session-only: skip all persistence while set
+	confirmCh               chan bool          // Pending injection confirmation, if any
+	confirmMu               sync.Mutex         // Mutex for confirmCh
+	processingCancel        context.CancelFunc // Cancel function for the in-flight processing pipeline
+	processingMu            sync.Mutex         // Mutex for processingCancel and processingGen
+	processingGen           uint64             // Incremented per run, so a late run can't clear its successor's cancel
+	processingWG            sync.WaitGroup     // Tracks in-flight processRecording runs
+	lastRecording           string             // WAV of the last recording that hasn't processed successfully ("" if none)
+	lastRecordingDuration   time.Duration      // Length of lastRecording
+	lastRecordingMu         sync.Mutex         // Mutex for lastRecording
+	historyKeyPrompted      atomic.Bool        // Asked this launch whether to replace a missing history key
+	latency                 *latencyTracker    // Rolling average of pipeline timings
+	quickNote               atomic.Bool        // Current recording is a quick note: history only, no clipboard/paste
+	targetApp               string             // App that was frontmost when the current recording started
+	targetAppMu             sync.Mutex         // Mutex for targetApp
+	accessibilityChecked    atomic.Bool        // The startup Accessibility check has run
+	testAudioPath           string             // Dev only: WAV fed into the pipeline instead of the mic
+	recentErrors            errorLog           // Recent error toasts, for diagnostics
+	batchCancel             context.CancelFunc // Cancel function for the running batch operation
+	batchMu                 sync.Mutex         // Mutex for batchCancel
 }
 
+// confirmInjectionTimeout is how long to wait for the user to approve an injection
+const confirmInjectionTimeout = 30 * time.Second
+
+// pillPinInterval is how often the mini indicator is re-pinned while processing
+const pillPinInterval = 500 * time.Millisecond
+
+// quitFinalizeTimeout bounds how long quitting waits for an in-flight transcription
+const quitFinalizeTimeout = 15 * time.Second
+
 // NewApp creates a new App application struct
 func NewApp() *App {
 	cfg := config.GetInstance()
@@ -46,6 +98,9 @@ func NewApp() *App {
 		audioRecorder:  audio.NewRecorder(),
 		whisperService: whisper.NewService(),
 		geminiClient:   gemini.NewClient(cfg.GetGeminiAPIKey()),
+		openaiClient:   openai.NewClient(cfg.GetOpenAIEndpoint()),
+		downloading:    make(map[string]bool),
+		latency:        newLatencyTracker(),
 	}
 	return app
 }
@@ -57,26 +112,93 @@ func (a *App) startup(ctx context.Context) {
 	// Make the floating indicator visible on all spaces and over fullscreen apps
 	MakeWindowFloatEverywhere()
 
-	// If starting in mini mode, ensure position is restored and watcher is started
-	if a.isMiniMode {
+	// Apply the saved appearance
+	SetAppAppearance(a.config.GetAppearance())
+
+	// Keep the saved window position current as the window moves
+	go a.trackWindowPosition()
+
+	a.startTray()
+
+	// The login item is the source of truth: the user may have removed it
+	// in System Settings, so don't recreate it, just record what it is
+	if enabled := loginitem.IsEnabled(); enabled != a.config.GetLaunchAtLogin() {
+		a.config.SetLaunchAtLogin(enabled)
+		if err := a.config.Save(); err != nil {
+			fmt.Printf("Warning: Failed to save launch at login: %v\n", err)
+		}
+	}
+
+	// If starting in mini mode, ensure position is restored
+	if a.miniMode() {
+		SetWindowAlpha(a.config.GetPillOpacity())
+
 		// Restore saved position if available
 		x, y := a.config.GetMiniModePosition()
 		if x != 0 || y != 0 {
-			runtime.WindowSetPosition(a.ctx, x, y)
+			a.ignoreOwnMoves()
+			a.restoreMiniModePosition()
 			// Ensure size is correct too, just in case
-			runtime.WindowSetMinSize(a.ctx, 200, 60)
-			runtime.WindowSetMaxSize(a.ctx, 200, 60)
-			runtime.WindowSetSize(a.ctx, 200, 60)
+			runtime.WindowSetMinSize(a.ctx, 240, 60)
+			runtime.WindowSetMaxSize(a.ctx, 240, 60)
+			runtime.WindowSetSize(a.ctx, 240, 60)
 		}
-
-		// Start watching position
-		a.startPositionWatch()
 	}
 
 	// Initialize audio
 	if err := a.audioRecorder.Initialize(); err != nil {
 		fmt.Printf("Warning: Failed to initialize audio: %v\n", err)
 	}
+	a.audioRecorder.OnDeviceChange = func(previous, current string) {
+		fmt.Printf("[App] Input device changed: %s -> %s\n", previous, current)
+		a.emitToast("Microphone switched to "+current, "info")
+	}
+	a.audioRecorder.OnLevel = func(rms, peak float32) {
+		runtime.EventsEmit(a.ctx, "audio-level", map[string]float32{
+			"rms":  rms,
+			"peak": peak,
+		})
+	}
+	a.audioRecorder.SetInputDevice(a.config.GetInputDevice())
+	a.audioRecorder.SetGain(float32(a.config.GetInputGain()))
+	a.applySilenceStop()
+	a.audioRecorder.OnSilence = func() {
+		if a.state != hotkey.StateRecording {
+			return
+		}
+		fmt.Println("[App] Silence detected, stopping recording")
+		a.StopRecording()
+	}
+	a.audioRecorder.SetMaxDuration(time.Duration(a.config.GetMaxRecordingSecs()) * time.Second)
+	a.audioRecorder.OnMaxDuration = func() {
+		if a.state != hotkey.StateRecording {
+			return
+		}
+		limit := a.audioRecorder.MaxDuration()
+		fmt.Printf("[App] Recording hit max duration (%v), stopping\n", limit)
+		runtime.EventsEmit(a.ctx, "recording-max-duration", limit.Seconds())
+		a.emitToast(fmt.Sprintf("Recording stopped after the %v limit", limit), "warning")
+		a.StopRecording()
+	}
+	a.audioRecorder.OnDeviceLost = func(err error) {
+		if a.state != hotkey.StateRecording {
+			return
+		}
+		fmt.Printf("[App] Microphone lost during recording: %v\n", err)
+		a.resetToIdle()
+		runtime.EventsEmit(a.ctx, "error", "Microphone disconnected: "+err.Error())
+		a.emitToast("Microphone disconnected, recording stopped ("+err.Error()+")", "error")
+	}
+	a.audioRecorder.OnDeviceMissing = func(name string) {
+		fmt.Printf("[App] Selected input device missing: %s\n", name)
+		runtime.EventsEmit(a.ctx, "error", "Microphone \""+name+"\" is not connected, using the system default")
+		a.emitToast("Microphone \""+name+"\" not found — using the system default", "error")
+	}
+	if ms := a.config.GetPreRollMs(); ms > 0 {
+		if err := a.audioRecorder.EnablePreRoll(time.Duration(ms) * time.Millisecond); err != nil {
+			fmt.Printf("Warning: Failed to enable pre-roll: %v\n", err)
+		}
+	}
 
 	// Initialize history service
 	histService, err := history.NewService()
@@ -84,6 +206,14 @@ func (a *App) startup(ctx context.Context) {
 		fmt.Printf("Warning: Failed to initialize history: %v\n", err)
 	} else {
 		a.historyService = histService
+		if a.config.GetEncryptHistory() {
+			if err := a.enableHistoryEncryption(); err != nil {
+				fmt.Printf("Warning: Failed to enable history encryption: %v\n", err)
+				a.emitToast("History encryption unavailable, new transcripts won't be saved until it is: "+err.Error(), "warning")
+			}
+		}
+		go a.purgeTrashLoop()
+		go a.pruneHistory()
 	}
 
 	// Initialize injection service
@@ -91,19 +221,98 @@ func (a *App) startup(ctx context.Context) {
 	if err != nil {
 		fmt.Printf("Warning: Failed to initialize injection: %v\n", err)
 	} else {
+		if err := injService.SetLineEnding(a.config.GetLineEnding()); err != nil {
+			fmt.Printf("Warning: %v, using default\n", err)
+		}
+		injService.SetPasteRetries(a.config.GetPasteRetries())
+		injService.SetDelays(pasteDelays(a.config.GetPasteDelays()))
+		if err := injService.SetMode(a.config.GetInjectionMode()); err != nil {
+			fmt.Printf("Warning: %v, using paste\n", err)
+		}
 		a.injectionService = injService
 	}
 
+	// Use the configured models directory, unless overridden for this launch
+	modelsDir := a.config.GetModelsDir()
+	if dir := os.Getenv(whisper.ModelsDirEnv); dir != "" {
+		modelsDir = dir
+	}
+	if err := whisper.SetModelsDir(modelsDir); err != nil {
+		fmt.Printf("Warning: Can't use models directory: %v, using ~/.voxflow/models\n", err)
+	}
+
 	// Clean up any partial model downloads from previous interrupted sessions
 	if err := whisper.CleanupPartialDownloads(); err != nil {
 		fmt.Printf("Warning: Failed to cleanup partial downloads: %v\n", err)
 	}
 
+	// Configure chunking of long recordings
+	a.whisperService.SetChunking(a.config.GetChunking())
+
+	a.geminiClient.SetToneTagging(a.config.GetToneTagging())
+	a.openaiClient.SetToneTagging(a.config.GetToneTagging())
+	a.syncCustomModes()
+	a.geminiClient.SetModel(a.config.GetGeminiModel())
+	geminiAttempts, geminiRetrySeconds := a.config.GetGeminiRetry()
+	a.geminiClient.SetRetry(geminiAttempts, time.Duration(geminiRetrySeconds)*time.Second)
+	a.geminiClient.SetTimeout(time.Duration(a.config.GetGeminiTimeoutSeconds()) * time.Second)
+	if err := a.geminiClient.SetProxy(a.config.GetProxy()); err != nil {
+		fmt.Printf("Warning: %v, refinement will fail until it is fixed\n", err)
+		a.emitToast("Proxy setting is invalid: "+err.Error(), "error")
+	}
+
+	// Configure whisper decoding
+	if err := a.whisperService.SetDecoding(a.config.GetWhisperDecoding()); err != nil {
+		fmt.Printf("Warning: Invalid whisper decoding settings: %v, using defaults\n", err)
+		a.whisperService.SetDecoding(whisper.DefaultBeamSize, 0)
+	}
+
+	if err := a.whisperService.SetLanguage(a.config.GetLanguage()); err != nil {
+		fmt.Printf("Warning: %v, auto-detecting\n", err)
+		a.whisperService.SetLanguage(whisper.AutoLanguage)
+	}
+
+	a.whisperService.SetTranslate(a.config.GetTranslate())
+	a.whisperService.SetThreads(a.config.GetWhisperThreads())
+	a.whisperService.SetPrompt(a.config.GetVocabularyPrompt())
+
+	// Retry model downloads that stop receiving data
+	a.whisperService.SetStallTimeout(time.Duration(a.config.GetDownloadStallSeconds()) * time.Second)
+	a.whisperService.OnDownloadStall = func(modelSize string, attempt int) {
+		runtime.EventsEmit(a.ctx, "model-download-stalled", map[string]interface{}{
+			"model":   modelSize,
+			"attempt": attempt,
+		})
+	}
+
+	// Forward whisper's progress; in the pipeline transcription spans 10-60%
+	a.whisperService.OnTranscribeProgress = func(percent int) {
+		a.emitEvent("transcription-progress", map[string]interface{}{
+			"percent": percent,
+		})
+		if a.state == hotkey.StateProcessing {
+			a.emitProgress("transcribing", 0.1+0.5*float64(percent)/100)
+		}
+	}
+
 	// Check if model is downloaded
 	go a.checkModelStatus()
 
 	// Initialize hotkey manager with callback
 	a.hotkeyManager = hotkey.NewManager(a.onHotkeyPressed)
+	a.hotkeyManager.SetEmergencyHotkey(a.config.GetEmergencyStopHotkey(), a.EmergencyStop)
+	a.hotkeyManager.SetQuickNoteHotkey(a.config.GetQuickNoteHotkey())
+	a.hotkeyManager.SetCycleModeHotkey(a.config.GetCycleModeHotkey(), a.cycleModeFromHotkey)
+	a.hotkeyManager.SetDoubleTapWindow(time.Duration(a.config.GetDoubleTapWindowMs()) * time.Millisecond)
+	a.hotkeyManager.SetMinHoldDuration(time.Duration(a.config.GetPTTMinHoldMs()) * time.Millisecond)
+	a.hotkeyManager.OnRegisterFailed = func(name, hotkeyStr string, err error) {
+		runtime.EventsEmit(a.ctx, "hotkey-register-failed", map[string]string{
+			"name":   name,
+			"hotkey": hotkeyStr,
+			"error":  err.Error(),
+		})
+		a.emitToast(fmt.Sprintf("Hotkey %s (%s) couldn't be registered — it's likely in use by macOS or another app. Pick a different shortcut in Settings.", hotkeyStr, name), "warning")
+	}
 
 	// Register and Start listening for hotkeys
 	hfHotkey := a.config.GetHandsFreeHotkey()
@@ -113,6 +322,79 @@ func (a *App) startup(ctx context.Context) {
 	if err := a.hotkeyManager.Start(hfHotkey, pttHotkey); err != nil {
 		fmt.Printf("Failed to start hotkey listener: %v\n", err)
 	}
+
+	// Start the local status/events server if enabled
+	if enabled, port := a.config.GetLocalServer(); enabled {
+		a.startLocalServer(port)
+	}
+
+	// Re-broadcast state once the frontend has registered its listeners, in case
+	// any state-changed events were emitted before the webview was ready
+	runtime.EventsOn(a.ctx, "frontend-ready", func(optionalData ...interface{}) {
+		a.broadcastState()
+		a.checkAccessibility()
+		go a.confirmHistoryKeyReset()
+	})
+}
+
+// beforeClose is called before the app quits. If a recording or transcription
+// is in progress it applies the quit policy: "ask" confirms with the user,
+// "finish" finalizes the current transcription, "discard" quits immediately.
+// Returning true cancels the quit.
+func (a *App) beforeClose(ctx context.Context) bool {
+	if a.state == hotkey.StateIdle {
+		return false
+	}
+
+	policy := a.config.GetQuitWhileBusy()
+	if policy == "discard" {
+		return false
+	}
+
+	if policy == "ask" {
+		activity := "Recording"
+		if a.state == hotkey.StateProcessing {
+			activity = "Processing"
+		}
+		choice, err := runtime.MessageDialog(ctx, runtime.MessageDialogOptions{
+			Type:          runtime.QuestionDialog,
+			Title:         "Quit voxflow?",
+			Message:       activity + " in progress. Quit anyway? The current dictation will be finished first.",
+			Buttons:       []string{"Quit", "Cancel"},
+			DefaultButton: "Cancel",
+			CancelButton:  "Cancel",
+		})
+		if err != nil {
+			fmt.Printf("[Quit] Confirmation dialog failed: %v\n", err)
+		}
+		if choice != "Quit" {
+			return true
+		}
+	}
+
+	a.finalizeBeforeQuit()
+	return false
+}
+
+// finalizeBeforeQuit stops an active recording and waits (bounded) for the
+// transcription pipeline to finish so the dictation isn't lost
+func (a *App) finalizeBeforeQuit() {
+	if a.state == hotkey.StateRecording {
+		a.StopRecording()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		a.processingWG.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		fmt.Println("[Quit] Finalized in-progress transcription")
+	case <-time.After(quitFinalizeTimeout):
+		fmt.Println("[Quit] Timed out waiting for transcription, quitting anyway")
+	}
 }
 
 // shutdown is called when the app is closing
@@ -129,16 +411,18 @@ func (a *App) shutdown(ctx context.Context) {
 	if a.historyService != nil {
 		a.historyService.Close()
 	}
-	// Stop position watcher
-	if a.positionWatchCancel != nil {
-		a.positionWatchCancel()
+	if srv := a.getLocalServer(); srv != nil {
+		srv.Stop()
 	}
-
-	// Save window position if we are shutting down in mini mode
-	if a.isMiniMode {
+	// Save window position for whichever mode we are shutting down in
+	if a.miniMode() {
 		a.saveCurrentMiniModePosition()
+	} else {
+		a.saveWindowGeometry()
 	}
 
+	a.clearLastRecording()
+
 	a.config.Save()
 }
 
@@ -157,6 +441,10 @@ func (a *App) checkModelStatus() {
 
 	// Try to load the model
 	if err := a.whisperService.LoadModel(modelSize); err != nil {
+		if errors.Is(err, whisper.ErrModelCorrupt) {
+			a.handleCorruptModel(modelSize, err)
+			return
+		}
 		fmt.Printf("Failed to load model: %v\n", err)
 		runtime.EventsEmit(a.ctx, "model-status", map[string]interface{}{
 			"downloaded": true,
@@ -186,11 +474,48 @@ func (a *App) IsModelDownloaded() bool {
 	return downloaded
 }
 
+// handleCorruptModel stops using a model that failed its header or checksum
+// check and asks the user to re-download it. The file is left in place.
+func (a *App) handleCorruptModel(modelSize string, cause error) {
+	fmt.Printf("[App] Model %s is corrupt: %v\n", modelSize, cause)
+	a.modelReady = false
+	runtime.EventsEmit(a.ctx, "model-corrupt", map[string]interface{}{
+		"model": modelSize,
+		"error": cause.Error(),
+	})
+	runtime.EventsEmit(a.ctx, "model-status", map[string]interface{}{
+		"downloaded": true,
+		"loaded":     false,
+		"model":      modelSize,
+		"error":      cause.Error(),
+	})
+	a.emitToast("The "+modelSize+" model is corrupt. Re-download it in Settings → Models.", "error")
+}
+
+// RedownloadModel replaces a downloaded model with a fresh copy, e.g. after it
+// was reported corrupt, and reloads it if it is the active model
+func (a *App) RedownloadModel(modelName string) error {
+	if err := a.whisperService.DiscardModel(modelName); err != nil {
+		return err
+	}
+	if err := a.DownloadModelByName(modelName); err != nil {
+		return err
+	}
+	if modelName == a.config.GetWhisperModel() {
+		go a.checkModelStatus()
+	}
+	return nil
+}
+
 // DownloadModel downloads the Whisper model
 func (a *App) DownloadModel() error {
 	modelSize := a.config.GetWhisperModel()
 
-	err := a.whisperService.DownloadModel(modelSize, func(downloaded, total int64) {
+	ctx, err := a.beginDownload(modelSize)
+	if err != nil {
+		return err
+	}
+	err = a.whisperService.DownloadModelWithContext(ctx, modelSize, func(downloaded, total int64) {
 		progress := float64(downloaded) / float64(total) * 100
 		runtime.EventsEmit(a.ctx, "model-download-progress", map[string]interface{}{
 			"downloaded": downloaded,
@@ -198,6 +523,7 @@ func (a *App) DownloadModel() error {
 			"progress":   progress,
 		})
 	})
+	a.endDownload(modelSize)
 
 	if err != nil {
 		runtime.EventsEmit(a.ctx, "model-download-error", err.Error())
@@ -223,7 +549,7 @@ func (a *App) DownloadModel() error {
 // onHotkeyPressed is called when the global hotkey is pressed
 func (a *App) onHotkeyPressed(state hotkey.State) {
 	a.state = state
-	runtime.EventsEmit(a.ctx, "state-changed", state.String())
+	a.emitEvent("state-changed", state.String())
 
 	switch state {
 	case hotkey.StateRecording:
@@ -231,87 +557,173 @@ func (a *App) onHotkeyPressed(state hotkey.State) {
 		if !a.userExplicitlyMaximized {
 			a.ShowMiniMode()
 		}
-		a.StartRecording()
+		trigger := a.hotkeyManager.ActiveTrigger()
+		a.startRecording(trigger == hotkey.TriggerQuickNote, trigger == hotkey.TriggerHandsFree)
 	case hotkey.StateProcessing:
 		a.StopRecording()
 		// Note: HideMiniMode is called after processing completes in processRecording()
 	case hotkey.StateIdle:
+		// Push-to-talk released too quickly: drop what was recorded
+		a.discardRecording()
 		if !a.userExplicitlyMaximized {
 			a.HideMiniMode()
 		}
 	}
 }
 
+// discardRecording stops an in-progress recording without processing it
+func (a *App) discardRecording() {
+	if !a.audioRecorder.IsRecording() {
+		return
+	}
+	if wavPath, err := a.audioRecorder.Stop(); err == nil {
+		os.Remove(wavPath)
+	}
+	runtime.EventsEmit(a.ctx, "recording-cancelled", nil)
+	fmt.Println("Recording discarded")
+}
+
 // ShowMiniMode switches the window to a small floating indicator
 func (a *App) ShowMiniMode() {
-	if a.isMiniMode {
+	if !a.setMiniMode(true) {
 		return
 	}
-	a.isMiniMode = true
 	a.userExplicitlyMaximized = false // User explicitly minimized
 
 	// Re-apply floating behavior (in case coming from full app mode)
 	MakeWindowFloatEverywhere()
+	SetWindowAlpha(a.config.GetPillOpacity())
 
 	// Resize to small indicator and lock size
-	runtime.WindowSetMinSize(a.ctx, 200, 60)
-	runtime.WindowSetMaxSize(a.ctx, 200, 60)
-	runtime.WindowSetSize(a.ctx, 200, 60)
+	runtime.WindowSetMinSize(a.ctx, 240, 60)
+	runtime.WindowSetMaxSize(a.ctx, 240, 60)
+	runtime.WindowSetSize(a.ctx, 240, 60)
 
 	// Restore saved position if available
-	x, y := a.config.GetMiniModePosition()
-	if x != 0 || y != 0 {
-		runtime.WindowSetPosition(a.ctx, x, y)
-	}
+	a.restoreMiniModePosition()
+	a.ignoreOwnMoves()
 
 	runtime.WindowSetAlwaysOnTop(a.ctx, true)
 	runtime.EventsEmit(a.ctx, "mini-mode", true)
 
-	// Start watching position for changes
-	a.startPositionWatch()
-
 	fmt.Println("[App] Switched to mini mode")
 }
 
-// startPositionWatch starts a goroutine to poll and save window position
-func (a *App) startPositionWatch() {
-	// Stop existing watcher if any
-	if a.positionWatchCancel != nil {
-		a.positionWatchCancel()
+// minPillOpacity keeps the pill from becoming invisible and unclickable
+const minPillOpacity = 0.3
+
+// SetPillOpacity sets the opacity of the mini pill, from 0.3 to 1
+func (a *App) SetPillOpacity(opacity float64) error {
+	if opacity < minPillOpacity || opacity > 1 {
+		return fmt.Errorf("pill opacity must be between %.1f and 1", minPillOpacity)
+	}
+	if a.miniMode() {
+		SetWindowAlpha(opacity)
 	}
+	a.config.SetPillOpacity(opacity)
+	return a.config.Save()
+}
 
-	ctx, cancel := context.WithCancel(context.Background())
-	a.positionWatchCancel = cancel
+// restoreMiniModePosition moves the pill to its saved position, snapped back
+// onto a connected screen if that one is gone (e.g. after undocking)
+func (a *App) restoreMiniModePosition() {
+	x, y := a.config.GetMiniModePosition()
+	if x == 0 && y == 0 {
+		return
+	}
+	r := clampToScreens(screenRect{X: x, Y: y, Width: 240, Height: 60}, VisibleScreens())
+	if r.X != x || r.Y != y {
+		fmt.Printf("[App] Saved mini mode position %d, %d is off screen, moved to %d, %d\n", x, y, r.X, r.Y)
+	}
+	runtime.WindowSetPosition(a.ctx, r.X, r.Y)
+}
 
-	go func() {
-		ticker := time.NewTicker(500 * time.Millisecond)
-		defer ticker.Stop()
+// ownMoveGrace is how long after a programmatic resize or reposition window
+// moves are still attributed to it, since move notifications arrive late
+const ownMoveGrace = 500 * time.Millisecond
 
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case <-ticker.C:
-				// Get current position
-				rx, ry := runtime.WindowGetPosition(a.ctx)
-				// Get saved position
-				cx, cy := a.config.GetMiniModePosition()
-
-				// If changed, save
-				if rx != cx || ry != cy {
-					a.config.SetMiniModePosition(rx, ry)
-					a.config.Save() // Save to disk to persist across crashes
-					// Avoid spamming logs, but useful for debug
-					// fmt.Printf("[App] Auto-saved position: %d, %d\n", rx, ry)
-				}
-			}
+// miniMode returns whether the app is in mini indicator mode
+func (a *App) miniMode() bool {
+	a.windowMu.Lock()
+	defer a.windowMu.Unlock()
+	return a.isMiniMode
+}
+
+// setMiniMode switches the window mode flag, returning false if it was
+// already set. The moves the switch causes are ignored.
+func (a *App) setMiniMode(mini bool) bool {
+	a.windowMu.Lock()
+	defer a.windowMu.Unlock()
+	if a.isMiniMode == mini {
+		return false
+	}
+	a.isMiniMode = mini
+	a.ignoreMovesUntil = time.Now().Add(ownMoveGrace)
+	return true
+}
+
+// ignoreOwnMoves keeps trackWindowPosition from saving the moves the app is
+// about to cause, or just caused, as if the user made them
+func (a *App) ignoreOwnMoves() {
+	a.windowMu.Lock()
+	defer a.windowMu.Unlock()
+	a.ignoreMovesUntil = time.Now().Add(ownMoveGrace)
+}
+
+// positionSaveDelay is how long the window must stay put before its new
+// position is written to disk, so dragging doesn't save on every move
+const positionSaveDelay = time.Second
+
+// trackWindowPosition keeps the saved position of the mini pill or full app
+// window, depending on the mode, current as the window moves. The config is
+// updated on every move but only saved once moving stops. Moves caused by
+// switching modes are ignored.
+func (a *App) trackWindowPosition() {
+	for range WindowMoves() {
+		a.windowMu.Lock()
+		mini, ignore := a.isMiniMode, time.Now().Before(a.ignoreMovesUntil)
+		a.windowMu.Unlock()
+		if ignore {
+			continue
 		}
-	}()
+		if mini {
+			x, y := runtime.WindowGetPosition(a.ctx)
+			a.config.SetMiniModePosition(x, y)
+		} else {
+			x, y := runtime.WindowGetPosition(a.ctx)
+			w, h := runtime.WindowGetSize(a.ctx)
+			a.config.SetWindowGeometry(x, y, w, h)
+		}
+		a.schedulePositionSave()
+	}
+}
+
+// schedulePositionSave saves the config positionSaveDelay after the last call
+func (a *App) schedulePositionSave() {
+	a.positionSaveMu.Lock()
+	defer a.positionSaveMu.Unlock()
+	if a.positionSaveTimer != nil {
+		a.positionSaveTimer.Stop()
+	}
+	a.positionSaveTimer = time.AfterFunc(positionSaveDelay, func() {
+		a.config.Save()
+	})
+}
+
+// cancelPositionSave stops a pending position save, for callers about to save anyway
+func (a *App) cancelPositionSave() {
+	a.positionSaveMu.Lock()
+	defer a.positionSaveMu.Unlock()
+	if a.positionSaveTimer != nil {
+		a.positionSaveTimer.Stop()
+		a.positionSaveTimer = nil
+	}
 }
 
 // saveCurrentMiniModePosition saves the current window position to config if in mini mode
 func (a *App) saveCurrentMiniModePosition() {
-	if a.isMiniMode {
+	if a.miniMode() {
+		a.cancelPositionSave()
 		x, y := runtime.WindowGetPosition(a.ctx)
 		a.config.SetMiniModePosition(x, y)
 		a.config.Save()
@@ -319,33 +731,55 @@ func (a *App) saveCurrentMiniModePosition() {
 	}
 }
 
-// HideMiniMode restores the window to normal size
-func (a *App) HideMiniMode() {
-	if !a.isMiniMode {
+// saveWindowGeometry saves the full app window's position and size
+func (a *App) saveWindowGeometry() {
+	a.cancelPositionSave()
+	x, y := runtime.WindowGetPosition(a.ctx)
+	w, h := runtime.WindowGetSize(a.ctx)
+	a.config.SetWindowGeometry(x, y, w, h)
+	a.config.Save()
+	fmt.Printf("[App] Saved window geometry: %dx%d at %d, %d\n", w, h, x, y)
+}
+
+// restoreWindowGeometry applies the saved full app window position and size,
+// kept on a connected screen, or centers a default-sized window if none is saved
+func (a *App) restoreWindowGeometry() {
+	x, y, w, h := a.config.GetWindowGeometry()
+	if w <= 0 || h <= 0 {
+		runtime.WindowSetSize(a.ctx, 900, 600)
+		runtime.WindowCenter(a.ctx)
 		return
 	}
 
-	// Stop position watching
-	if a.positionWatchCancel != nil {
-		a.positionWatchCancel()
-		a.positionWatchCancel = nil
+	r := clampToScreens(screenRect{X: x, Y: y, Width: w, Height: h}, VisibleScreens())
+	runtime.WindowSetSize(a.ctx, r.Width, r.Height)
+	runtime.WindowSetPosition(a.ctx, r.X, r.Y)
+}
+
+// HideMiniMode restores the window to normal size
+func (a *App) HideMiniMode() {
+	if !a.miniMode() {
+		return
 	}
 
 	// Save current position one last time
 	a.saveCurrentMiniModePosition()
 
-	a.isMiniMode = false
+	if !a.setMiniMode(false) {
+		return
+	}
 	a.userExplicitlyMaximized = true // User explicitly opened full app
 
 	// Reset window behavior to normal (not floating over fullscreen)
 	ResetWindowBehavior()
+	SetWindowAlpha(1)
 
 	// Restore normal window size limits and dimensions
 	runtime.WindowSetMinSize(a.ctx, 800, 600)
 	runtime.WindowSetMaxSize(a.ctx, 0, 0)
-	runtime.WindowSetSize(a.ctx, 900, 600)
+	a.restoreWindowGeometry()
+	a.ignoreOwnMoves()
 	runtime.WindowSetAlwaysOnTop(a.ctx, false)
-	runtime.WindowCenter(a.ctx)
 	runtime.EventsEmit(a.ctx, "mini-mode", false)
 
 	fmt.Println("[App] Restored normal mode")
@@ -353,7 +787,7 @@ func (a *App) HideMiniMode() {
 
 // IsMiniMode returns whether the app is in mini indicator mode
 func (a *App) IsMiniMode() bool {
-	return a.isMiniMode
+	return a.miniMode()
 }
 
 // GetStatus returns the current application status
@@ -361,68 +795,286 @@ func (a *App) GetStatus() string {
 	return a.state.String()
 }
 
+// GetCurrentState returns the current state and re-emits it to the frontend
+// so listeners that missed an earlier state-changed event can resync
+func (a *App) GetCurrentState() string {
+	a.broadcastState()
+	return a.state.String()
+}
+
+// broadcastState re-emits the current state and window mode
+func (a *App) broadcastState() {
+	a.emitEvent("state-changed", a.state.String())
+	runtime.EventsEmit(a.ctx, "mini-mode", a.miniMode())
+	runtime.EventsEmit(a.ctx, "privacy-mode", a.privacyMode.Load())
+}
+
 // StartRecording begins audio capture
 func (a *App) StartRecording() error {
+	return a.startRecording(false, false)
+}
+
+// StartQuickNote begins a recording that is only saved to history, without
+// touching the clipboard or pasting
+func (a *App) StartQuickNote() error {
+	return a.startRecording(true, false)
+}
+
+// startRecording begins audio capture. quickNote sends the result to history
+// only; autoStop ends the recording after trailing silence (hands-free).
+func (a *App) startRecording(quickNote, autoStop bool) error {
 	if !a.modelReady {
 		return fmt.Errorf("model not ready")
 	}
 
+	a.quickNote.Store(quickNote)
+	a.audioRecorder.ArmSilenceStop(autoStop)
 	a.state = hotkey.StateRecording
 	a.hotkeyManager.SetState(hotkey.StateRecording)
 
-	if err := a.audioRecorder.Start(); err != nil {
+	if a.testAudioPath != "" {
+		fmt.Printf("[App] Using test audio %s instead of the microphone\n", a.testAudioPath)
+	} else if err := a.audioRecorder.Start(); err != nil {
 		a.state = hotkey.StateIdle
 		a.hotkeyManager.SetState(hotkey.StateIdle)
 		runtime.EventsEmit(a.ctx, "error", err.Error())
 		return err
 	}
 
-	runtime.EventsEmit(a.ctx, "state-changed", "Recording")
+	a.emitEvent("state-changed", "Recording")
 	runtime.EventsEmit(a.ctx, "recording-started", nil)
-	fmt.Println("Recording started...")
+	if playSounds, _ := a.config.GetSoundCues(); playSounds {
+		// Capture is already running, so blank it while the cue can be heard
+		a.audioRecorder.MuteFor(sound.Audible)
+		a.playCue(sound.CueStart)
+	}
+	go a.captureTargetApp()
+	if quickNote {
+		fmt.Println("Recording started (quick note)...")
+	} else {
+		fmt.Println("Recording started...")
+	}
 	return nil
 }
 
+// playCue plays a recording sound cue, if enabled
+func (a *App) playCue(cue sound.Cue) {
+	playSounds, volume := a.config.GetSoundCues()
+	if !playSounds {
+		return
+	}
+	if err := sound.Play(cue, volume); err != nil {
+		fmt.Printf("[App] %v\n", err)
+	}
+}
+
+// SetSoundCues sets whether a cue plays when recording starts and stops, and
+// its volume (0-1)
+func (a *App) SetSoundCues(enabled bool, volume float64) error {
+	if volume <= 0 || volume > 1 {
+		return fmt.Errorf("volume must be above 0 and at most 1")
+	}
+	a.config.SetSoundCues(enabled, volume)
+	return a.config.Save()
+}
+
+// captureTargetApp records the app being dictated into, for history. It runs
+// off the hotkey path since the lookup shells out to osascript.
+func (a *App) captureTargetApp() {
+	name := injection.FrontmostApp()
+	a.targetAppMu.Lock()
+	a.targetApp = name
+	a.targetAppMu.Unlock()
+}
+
+// getTargetApp returns the app captured when the recording started ("" if unknown)
+func (a *App) getTargetApp() string {
+	a.targetAppMu.Lock()
+	defer a.targetAppMu.Unlock()
+	return a.targetApp
+}
+
 // StopRecording stops audio capture and begins processing
 func (a *App) StopRecording() {
 	a.state = hotkey.StateProcessing
 	a.hotkeyManager.SetState(hotkey.StateProcessing)
-	runtime.EventsEmit(a.ctx, "state-changed", "Processing")
+	a.emitEvent("state-changed", "Processing")
 	runtime.EventsEmit(a.ctx, "recording-stopped", nil)
 	fmt.Println("Recording stopped, processing...")
+	a.startProcessing(a.processRecording)
+}
 
-	go a.processRecording()
+// startProcessing runs process on its own goroutine with a context that
+// AbortProcessing cancels
+func (a *App) startProcessing(process func(ctx context.Context)) {
+	ctx, cancel := context.WithCancel(context.Background())
+	a.processingMu.Lock()
+	a.processingGen++
+	gen := a.processingGen
+	a.processingCancel = cancel
+	a.processingMu.Unlock()
+
+	if a.miniMode() && a.config.GetKeepPillDuringProcessing() {
+		go a.keepPillVisible(ctx)
+	}
+
+	a.processingWG.Add(1)
+	go func() {
+		defer a.processingWG.Done()
+		defer a.clearProcessingCancel(gen, cancel)
+		process(ctx)
+	}()
 }
 
-// processRecording handles the transcription and refinement pipeline
-func (a *App) processRecording() {
-	processingStartTime := time.Now()
+// keepPillVisible re-pins the mini indicator until ctx ends, so focus changes
+// can't hide it while processing is in progress
+func (a *App) keepPillVisible(ctx context.Context) {
+	ticker := time.NewTicker(pillPinInterval)
+	defer ticker.Stop()
+
+	PinWindowVisible()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !a.miniMode() {
+				return
+			}
+			PinWindowVisible()
+		}
+	}
+}
+
+// emitProgress reports pipeline progress (0-1) for the processing animation
+func (a *App) emitProgress(stage string, progress float64) {
+	a.emitEvent("processing-progress", map[string]interface{}{
+		"stage":    stage,
+		"progress": progress,
+	})
+}
+
+// AbortProcessing cancels the in-flight transcription and refinement, killing
+// whisper if it is running, and returns to idle
+func (a *App) AbortProcessing() error {
+	a.processingMu.Lock()
+	cancel := a.processingCancel
+	a.processingCancel = nil
+	a.processingMu.Unlock()
+
+	if cancel == nil {
+		return fmt.Errorf("nothing is being processed")
+	}
+	cancel()
+	fmt.Println("[App] Processing aborted")
+	a.resetToIdle()
+	a.emitEvent("processing-aborted", nil)
+	a.emitToast("Processing cancelled", "info")
+	return nil
+}
+
+// clearProcessingCancel releases run gen's processing context once its
+// pipeline ends. An aborted run that finishes after the next one started
+// leaves that run's cancel alone.
+func (a *App) clearProcessingCancel(gen uint64, cancel context.CancelFunc) {
+	cancel()
+	a.processingMu.Lock()
+	defer a.processingMu.Unlock()
+	if a.processingGen == gen {
+		a.processingCancel = nil
+	}
+}
 
-	// Capture audio duration before stopping (buffer is still valid after Stop until next Start)
-	audioDuration := a.audioRecorder.GetDuration()
+// stopCapture stops the recorder and returns the recorded WAV file and its
+// duration. With test audio set, it returns a temp copy of that file instead.
+func (a *App) stopCapture() (string, time.Duration, error) {
+	if a.testAudioPath == "" {
+		// Capture audio duration before stopping (buffer is still valid after Stop until next Start)
+		duration := a.audioRecorder.GetDuration()
+		wavPath, err := a.audioRecorder.Stop()
+		// Only after capture stopped, so the cue isn't recorded
+		a.playCue(sound.CueStop)
+		return wavPath, duration, err
+	}
+
+	data, err := os.ReadFile(a.testAudioPath)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read test audio: %w", err)
+	}
+	wavPath := filepath.Join(os.TempDir(), fmt.Sprintf("voxflow_test_%d.wav", time.Now().UnixNano()))
+	if err := os.WriteFile(wavPath, data, 0644); err != nil {
+		return "", 0, fmt.Errorf("failed to copy test audio: %w", err)
+	}
+	duration, err := audio.WavDuration(wavPath)
+	return wavPath, duration, err
+}
+
+// processRecording handles the transcription and refinement pipeline.
+// If ctx is cancelled the pipeline stops at the next stage boundary without
+// touching app state; whoever cancelled it is responsible for resetting.
+func (a *App) processRecording(ctx context.Context) {
+	processingStartTime := time.Now()
+	quickNote := a.quickNote.Load() // Snapshot before the next recording can change it
 
 	// Stop recording and get WAV file
-	wavPath, err := a.audioRecorder.Stop()
+	wavPath, audioDuration, err := a.stopCapture()
+	if ctx.Err() != nil {
+		return
+	}
 	if err != nil {
-		a.emitToast("Failed to stop recording: "+err.Error(), "error")
-		a.resetToIdle()
+		a.failDictation("Failed to stop recording: " + err.Error())
 		return
 	}
-	defer os.Remove(wavPath) // Clean up temp file
 
-	// Transcribe with Whisper - retry up to 3 times if no audio detected
+	if a.privacyMode.Load() {
+		defer os.Remove(wavPath) // Clean up temp file
+	} else {
+		// Keep the audio until it's processed, so a failed run can be retried
+		wavPath = a.keepLastRecording(wavPath, audioDuration)
+	}
+	a.processAudio(ctx, wavPath, audioDuration, quickNote, processingStartTime)
+}
+
+// processAudio transcribes, refines, saves and injects a recording. On
+// success, or if it holds no speech, the kept last recording is deleted, as
+// retrying it wouldn't help. A quickNote result only goes to history.
+func (a *App) processAudio(ctx context.Context, wavPath string, audioDuration time.Duration, quickNote bool, processingStartTime time.Time) {
+	// Transcribe with Whisper, retrying if no audio detected
 	var rawText string
+	var segments []whisper.Segment
 	var whisperDuration time.Duration
-	maxRetries := 3
+	var err error
+	retries, retryDelayMs := a.config.GetBlankAudioRetry()
+	maxRetries := 1 + retries
+	language := a.config.GetLanguage()
+	translated := a.config.GetTranslate()
+	if language != whisper.AutoLanguage {
+		// With a forced language, blank output is more likely a language
+		// mismatch than a quiet start, so retrying won't help
+		maxRetries = 1
+	}
 
+	a.emitProgress("transcribing", 0.1)
 	whisperStart := time.Now()
 	for attempt := 1; attempt <= maxRetries; attempt++ {
-		rawText, err = a.whisperService.Transcribe(wavPath)
-		if err != nil {
-			a.emitToast("Transcription failed: "+err.Error(), "error")
+		rawText, segments, err = a.whisperService.TranscribeSegments(ctx, wavPath)
+		if ctx.Err() != nil {
+			return
+		}
+		if errors.Is(err, whisper.ErrModelCorrupt) {
+			a.handleCorruptModel(a.config.GetWhisperModel(), err)
+			a.notify("Dictation failed", "The transcription model is corrupt")
 			a.resetToIdle()
 			return
 		}
+		if errors.Is(err, whisper.ErrModelLoad) {
+			a.failDictation("Whisper couldn't load the model (low memory?). If it keeps failing, re-download it in Settings → Models.")
+			return
+		}
+		if err != nil {
+			a.failDictation("Transcription failed: " + err.Error())
+			return
+		}
 
 		if rawText != "" {
 			break // Successfully got transcription
@@ -430,54 +1082,181 @@ func (a *App) processRecording() {
 
 		if attempt < maxRetries {
 			fmt.Printf("[App] No speech detected, retrying (%d/%d)...\n", attempt, maxRetries)
-			time.Sleep(500 * time.Millisecond)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Duration(retryDelayMs) * time.Millisecond):
+			}
 		}
 	}
 	whisperDuration = time.Since(whisperStart)
 
 	if rawText == "" {
-		a.emitToast("No audio was captured. Please try speaking louder or check your microphone.", "warning")
+		if language != whisper.AutoLanguage {
+			a.emitToast("No speech recognized in the selected language ("+language+"). Check the transcription language in Settings.", "warning")
+		} else {
+			a.emitToast("No audio was captured. Please try speaking louder or check your microphone.", "warning")
+		}
+		// There's nothing in it worth reprocessing
+		a.clearLastRecording()
 		a.resetToIdle()
 		return
 	}
 
 	// Check for Whisper's blank audio markers
-	if rawText == "[BLANK_AUDIO]" || rawText == "(blank audio)" || rawText == "[NO SPEECH]" {
+	if whisper.IsBlankAudio(rawText) {
 		a.emitToast("No speech detected. Please try speaking into your microphone.", "warning")
+		a.clearLastRecording()
 		a.resetToIdle()
 		return
 	}
 
-	// Refine with Gemini - DO NOT fall back to raw text on error
+	// Expand date/time voice tokens locally before refinement if configured
+	tokenStage := a.config.GetDateTokenStage()
+	refineInput := rawText
+	if tokenStage == "before" {
+		refineInput = a.expandDateTokens(rawText)
+	}
+
+	a.emitProgress("refining", 0.6)
+
+	// Refine with the configured backend - only fall back to raw text if it is unavailable
 	mode := a.config.GetMode()
+	if mode == "code" && a.config.GetCodeSpokenSymbols() {
+		refineInput = textproc.ReplaceSpokenSymbols(refineInput)
+	}
 	geminiStart := time.Now()
-	polishedText, err := a.geminiClient.RefineText(rawText, mode)
+	refiner := a.refinerFor(mode)
+	polishedText, tone, usage, err := refiner.RefineTextWithTone(ctx, refineInput, mode)
 	geminiDuration := time.Since(geminiStart)
 
-	if err != nil {
-		a.emitToast("Gemini error: "+err.Error(), "error")
-		a.resetToIdle()
+	if ctx.Err() != nil {
 		return
 	}
 
-	// Save to history (only polished text is shown, but we still save raw for potential future use)
-	if a.historyService != nil {
-		_, err := a.historyService.Save("", rawText, polishedText, mode)
-		if err != nil {
-			fmt.Printf("Failed to save to history: %v\n", err)
+	if errors.Is(err, gemini.ErrUnavailable) {
+		// Rate limited, down, timed out or offline: keep the user's words
+		// rather than losing the dictation
+		fmt.Printf("[App] %v, using raw transcription\n", err)
+		reason := "Refinement is unavailable right now"
+		switch {
+		case errors.Is(err, gemini.ErrTimeout):
+			reason = "Refinement timed out"
+		case errors.Is(err, gemini.ErrNetwork):
+			reason = "Couldn't reach the refinement service"
 		}
+		a.emitToast(reason+" — using the unrefined transcription", "warning")
+		polishedText, tone, err = refineInput, "", nil
+	}
+	if err != nil {
+		a.failDictation(refineErrorMessage(err))
+		return
 	}
 
-	// Always copy to clipboard first
-	if a.injectionService != nil {
-		// Run in goroutine to not block timing log if clipboard is slow (unlikely but safe)
-		go func() {
-			a.injectionService.CopyToClipboard(polishedText)
-			fmt.Printf("Text copied to clipboard\n")
+	if tokenStage == "after" {
+		polishedText = a.expandDateTokens(polishedText)
+	}
 
-			// Also try to inject at cursor if possible
-			if err := a.injectionService.Inject(polishedText); err != nil {
-				fmt.Printf("Could not inject text (no active cursor?): %v\n", err)
+	polishedText = textproc.ApplyReplacements(polishedText, a.config.GetReplacements())
+	polishedText = textproc.ApplyCaseStyle(polishedText, a.config.GetCaseStyle())
+
+	// Enforce the per-mode output length limit
+	if maxChars := a.config.GetMaxOutputChars(mode); maxChars > 0 {
+		if truncated, ok := textproc.TruncateAtWord(polishedText, maxChars); ok {
+			polishedText = truncated
+			a.emitToast(fmt.Sprintf("Output truncated to %d characters", maxChars), "warning")
+		}
+	}
+
+	a.emitProgress("saving", 0.9)
+
+	// Save to history (only polished text is shown, but we still save raw for potential future use)
+	if a.historyService != nil && !a.privacyMode.Load() {
+		a.retryHistoryKey()
+		saved, err := a.historyService.Save(a.getTargetApp(), rawText, polishedText, mode)
+		if errors.Is(err, history.ErrKeyRequired) {
+			a.emitToast("Not saved to history: the encryption key isn't available", "warning")
+		}
+		if err != nil {
+			fmt.Printf("Failed to save to history: %v\n", err)
+		} else {
+			if tone != "" {
+				if err := a.historyService.SetTone(saved.ID, tone); err != nil {
+					fmt.Printf("Failed to save tone: %v\n", err)
+				}
+			}
+			if err := a.historyService.SetMetrics(saved.ID, audioDuration, whisperDuration, geminiDuration); err != nil {
+				fmt.Printf("Failed to save processing metrics: %v\n", err)
+			}
+			if usage.TotalTokens > 0 {
+				backend, model := a.refinerModel(refiner)
+				if err := a.historyService.SetTokenUsage(saved.ID, backend, model, usage.PromptTokens, usage.OutputTokens); err != nil {
+					fmt.Printf("Failed to save token usage: %v\n", err)
+				}
+			}
+			if translated {
+				if err := a.historyService.SetTranslated(saved.ID, true); err != nil {
+					fmt.Printf("Failed to save translated flag: %v\n", err)
+				}
+			}
+			if len(segments) > 0 {
+				if err := a.historyService.SetSegments(saved.ID, historySegments(segments)); err != nil {
+					fmt.Printf("Failed to save segments: %v\n", err)
+				}
+			}
+			if a.config.GetKeepRecordings() {
+				if err := a.archiveRecording(wavPath, saved.ID); err != nil {
+					fmt.Printf("Failed to keep recording: %v\n", err)
+				}
+			}
+			go a.pruneHistory()
+		}
+	}
+
+	// Quick notes only go to history
+	if quickNote {
+		if a.privacyMode.Load() {
+			a.emitToast("Privacy mode is on — quick note was not saved", "warning")
+		} else {
+			a.emitToast("Saved to history", "success")
+		}
+	}
+
+	if a.injectionService != nil && !quickNote {
+		// Run in goroutine to not block timing log if clipboard is slow (unlikely but safe)
+		go func() {
+			// Optionally ask the user before pasting at the cursor, with the
+			// text on the clipboard in case they'd rather paste it themselves.
+			// Otherwise Inject puts it there, saving the previous clipboard
+			// first, or leaves the clipboard alone in type mode.
+			if a.config.GetConfirmBeforeInject() {
+				typing := a.config.GetInjectionMode() == injection.ModeType
+				if !typing {
+					a.injectionService.CopyToClipboard(polishedText)
+					fmt.Printf("Text copied to clipboard\n")
+				}
+				if !a.awaitInjectionConfirmation(polishedText) {
+					fmt.Println("[App] Injection not approved")
+					return
+				}
+			}
+
+			if ctx.Err() != nil {
+				return
+			}
+
+			// Also try to inject at cursor if possible
+			a.warnSlowTyping(polishedText)
+			err := a.injectionService.Inject(polishedText)
+			a.emitInjectionResult(polishedText, err)
+			if err != nil {
+				fmt.Printf("Could not inject text (no active cursor?): %v\n", err)
+				return
+			}
+
+			// In privacy mode, don't leave the dictation sitting on the clipboard
+			if a.privacyMode.Load() && a.config.GetPrivacyClearClipboard() {
+				a.injectionService.ClearClipboardIfMatches(polishedText)
 			}
 		}()
 	}
@@ -485,24 +1264,40 @@ func (a *App) processRecording() {
 	totalProcessingTime := time.Since(processingStartTime)
 
 	// Create formatted output string
+	beamSize, temperature := a.config.GetWhisperDecoding()
 	output := fmt.Sprintf(
 		"\nProcessing Complete:\n"+
 			"Audio captured:        %.2fs\n"+
-			"Whisper transcription: %.2fs\n"+
+			"Whisper transcription: %.2fs (beam size %d, temperature %.2f, %d threads; larger beams are more accurate but slower)\n"+
 			"Gemini refinement:     %.2fs\n"+
 			"Total processing:      %.2fs\n",
 		audioDuration.Seconds(),
 		whisperDuration.Seconds(),
+		beamSize, temperature, a.config.GetWhisperThreads(),
 		geminiDuration.Seconds(),
 		totalProcessingTime.Seconds(),
 	)
 	fmt.Println(output)
 
+	if !a.privacyMode.Load() {
+		a.latency.Record(audioDuration, whisperDuration, geminiDuration, totalProcessingTime)
+	}
+
+	a.emitProgress("done", 1)
+	a.clearLastRecording()
+
+	// Privacy mode keeps the words out of Notification Center
+	if a.privacyMode.Load() {
+		a.notify("Dictation ready", fmt.Sprintf("%d words", len(strings.Fields(polishedText))))
+	} else {
+		a.notify("Dictation ready", notify.Summary(polishedText))
+	}
+
 	// Reset state (but DON'T hide mini mode - let user stay in mini mode if they started there)
 	a.state = hotkey.StateIdle
 	a.hotkeyManager.SetState(hotkey.StateIdle)
-	runtime.EventsEmit(a.ctx, "state-changed", "Idle")
-	runtime.EventsEmit(a.ctx, "processing-complete", map[string]interface{}{
+	a.emitEvent("state-changed", "Idle")
+	a.emitEvent("processing-complete", map[string]interface{}{
 		"polished": polishedText,
 		"elapsed":  totalProcessingTime.Milliseconds(),
 		"details": map[string]float64{
@@ -513,19 +1308,201 @@ func (a *App) processRecording() {
 	})
 }
 
+// awaitInjectionConfirmation asks the frontend to approve injecting text and
+// waits for ConfirmInjection. Times out to "not approved" (clipboard only).
+func (a *App) awaitInjectionConfirmation(text string) bool {
+	ch := make(chan bool, 1)
+	a.confirmMu.Lock()
+	a.confirmCh = ch
+	a.confirmMu.Unlock()
+
+	defer func() {
+		a.confirmMu.Lock()
+		if a.confirmCh == ch {
+			a.confirmCh = nil
+		}
+		a.confirmMu.Unlock()
+	}()
+
+	runtime.EventsEmit(a.ctx, "confirm-injection", map[string]interface{}{
+		"text":    text,
+		"timeout": confirmInjectionTimeout.Milliseconds(),
+	})
+
+	select {
+	case approved := <-ch:
+		if approved {
+			// The confirmation click focused our window; hand focus back before pasting
+			DeactivateApp()
+			time.Sleep(150 * time.Millisecond)
+		}
+		return approved
+	case <-time.After(confirmInjectionTimeout):
+		a.emitToast("Text copied to clipboard (injection not confirmed)", "info")
+		return false
+	}
+}
+
+// warnSlowTyping lets the user know a long text will take a while in type mode
+func (a *App) warnSlowTyping(text string) {
+	if a.config.GetInjectionMode() != injection.ModeType {
+		return
+	}
+	if n := utf8.RuneCountInString(text); n > injection.SlowTypeChars {
+		a.emitToast(fmt.Sprintf("Typing %d characters, this may take a moment", n), "info")
+	}
+}
+
+// emitInjectionResult reports whether text was pasted at the cursor. On
+// failure it also tells the user how to recover, since the text is still on
+// the clipboard: paste mode puts it there before anything can fail, and type
+// mode, which otherwise leaves the clipboard alone, copies it here.
+func (a *App) emitInjectionResult(text string, err error) {
+	result := map[string]interface{}{
+		"success": err == nil,
+	}
+	if err != nil && !errors.Is(err, injection.ErrAborted) && a.config.GetInjectionMode() == injection.ModeType {
+		a.injectionService.CopyToClipboard(text)
+	}
+	if err != nil {
+		result["error"] = err.Error()
+		result["no_focus"] = errors.Is(err, injection.ErrNoFocusedField)
+		switch {
+		case errors.Is(err, injection.ErrAborted):
+			// Cancelled on purpose, nothing to tell the user
+		case errors.Is(err, injection.ErrNoAccessibility):
+			a.emitToast("voxflow needs Accessibility permission to paste — text is on your clipboard, press Cmd+V to paste", "warning")
+			a.emitAccessibilityRequired()
+		case errors.Is(err, injection.ErrNoFocusedField):
+			a.emitToast("No text field focused — text is on your clipboard, press Cmd+V to paste", "warning")
+		case errors.Is(err, injection.ErrPasteFailed):
+			a.emitToast("Text copied to clipboard but couldn't paste — press Cmd+V", "warning")
+		default:
+			a.emitToast("Couldn't paste automatically — text is on your clipboard, press Cmd+V to paste", "error")
+		}
+	}
+	runtime.EventsEmit(a.ctx, "injection-result", result)
+}
+
+// emitAccessibilityRequired asks the frontend to explain how to grant
+// Accessibility permission
+func (a *App) emitAccessibilityRequired() {
+	runtime.EventsEmit(a.ctx, "accessibility-required", map[string]interface{}{
+		"settings_url": injection.AccessibilitySettingsURL,
+	})
+}
+
+// checkAccessibility warns once per launch if pasting at the cursor won't work
+func (a *App) checkAccessibility() {
+	if a.injectionService == nil || a.accessibilityChecked.Swap(true) {
+		return
+	}
+	trusted, err := a.injectionService.CheckAccessibilityPermission()
+	if err != nil || trusted {
+		return
+	}
+	fmt.Println("[Injection] Accessibility permission not granted, pasting will fall back to the clipboard")
+	a.emitAccessibilityRequired()
+}
+
+// CheckAccessibilityPermission reports whether voxflow may paste into other apps
+func (a *App) CheckAccessibilityPermission() (bool, error) {
+	if a.injectionService == nil {
+		return false, fmt.Errorf("injection service not available")
+	}
+	return a.injectionService.CheckAccessibilityPermission()
+}
+
+// OpenAccessibilitySettings opens the Accessibility pane of System Settings
+func (a *App) OpenAccessibilitySettings() {
+	runtime.BrowserOpenURL(a.ctx, injection.AccessibilitySettingsURL)
+}
+
+// expandDateTokens replaces "insert date"/"insert time" style voice commands with the current date/time
+func (a *App) expandDateTokens(text string) string {
+	dateFormat, timeFormat := a.config.GetDateTimeFormats()
+	return textproc.ExpandDateTokens(text, time.Now(), dateFormat, timeFormat)
+}
+
+// emitEvent sends an event to the frontend and to any local server subscribers
+func (a *App) emitEvent(name string, data interface{}) {
+	runtime.EventsEmit(a.ctx, name, data)
+	if srv := a.getLocalServer(); srv != nil {
+		srv.Publish(name, data)
+	}
+}
+
+// getLocalServer returns the localhost server, or nil if it was never started
+func (a *App) getLocalServer() *server.Server {
+	a.localServerMu.Lock()
+	defer a.localServerMu.Unlock()
+	return a.localServer
+}
+
+// startLocalServer starts the localhost status/events server
+func (a *App) startLocalServer(port int) error {
+	a.localServerMu.Lock()
+	defer a.localServerMu.Unlock()
+	if a.localServer == nil {
+		a.localServer = server.NewServer(port, func() map[string]interface{} {
+			return map[string]interface{}{
+				"state":       a.state.String(),
+				"model_ready": a.modelReady,
+				"mode":        a.config.GetMode(),
+			}
+		})
+	}
+	if err := a.localServer.Start(); err != nil {
+		fmt.Printf("Warning: Failed to start local server: %v\n", err)
+		return err
+	}
+	return nil
+}
+
 // emitToast sends a toast notification to the frontend
 func (a *App) emitToast(message string, toastType string) {
+	if toastType == "error" {
+		a.recentErrors.Add(message)
+	}
 	runtime.EventsEmit(a.ctx, "toast", map[string]interface{}{
 		"message": message,
 		"type":    toastType,
 	})
 }
 
+// failDictation reports an error that ends the current dictation and returns
+// to idle. Only these failures post a notification; other error toasts don't.
+func (a *App) failDictation(message string) {
+	a.emitToast(message, "error")
+	a.notify("Dictation failed", message)
+	a.resetToIdle()
+}
+
+// notify posts a native notification if enabled and voxflow's window isn't
+// already in front of the user
+func (a *App) notify(title, message string) {
+	if !a.config.GetNotifyOnComplete() || IsWindowFocused() {
+		return
+	}
+	go func() {
+		if err := notify.Post(title, message); err != nil {
+			fmt.Printf("[App] %v\n", err)
+		}
+	}()
+}
+
+// SetNotifyOnComplete sets whether a notification is posted when processing
+// finishes or fails while the window isn't focused
+func (a *App) SetNotifyOnComplete(enabled bool) error {
+	a.config.SetNotifyOnComplete(enabled)
+	return a.config.Save()
+}
+
 // resetToIdle resets the app state to idle (stays in current window mode)
 func (a *App) resetToIdle() {
 	a.state = hotkey.StateIdle
 	a.hotkeyManager.SetState(hotkey.StateIdle)
-	runtime.EventsEmit(a.ctx, "state-changed", "Idle")
+	a.emitEvent("state-changed", "Idle")
 }
 
 // handleError handles errors during processing
@@ -540,7 +1517,52 @@ func (a *App) handleError(message string, err error) {
 	a.state = hotkey.StateIdle
 	a.hotkeyManager.SetState(hotkey.StateIdle)
 	a.HideMiniMode()
-	runtime.EventsEmit(a.ctx, "state-changed", "Idle")
+	a.emitEvent("state-changed", "Idle")
+}
+
+// EmergencyStop halts all activity: recording, processing, downloads and
+// injection, then returns to idle. Each step is isolated so one misbehaving
+// subsystem can't prevent the others from stopping.
+func (a *App) EmergencyStop() {
+	fmt.Println("[App] EMERGENCY STOP")
+
+	safeStop := func(name string, fn func()) {
+		defer func() {
+			if r := recover(); r != nil {
+				fmt.Printf("[App] Emergency stop: %s panicked: %v\n", name, r)
+			}
+		}()
+		fn()
+	}
+
+	safeStop("processing", func() {
+		a.processingMu.Lock()
+		defer a.processingMu.Unlock()
+		if a.processingCancel != nil {
+			a.processingCancel()
+			a.processingCancel = nil
+		}
+	})
+	safeStop("recording", func() {
+		if a.audioRecorder.IsRecording() {
+			if wavPath, err := a.audioRecorder.Stop(); err == nil {
+				os.Remove(wavPath)
+			}
+		}
+	})
+	safeStop("injection", func() {
+		if a.injectionService != nil {
+			a.injectionService.Abort()
+		}
+		a.ConfirmInjection(false)
+	})
+	safeStop("download", a.CancelDownload)
+	safeStop("benchmark", a.CancelBenchmark)
+	safeStop("state", func() {
+		a.resetToIdle()
+		runtime.EventsEmit(a.ctx, "emergency-stop", nil)
+		a.emitToast("All activity stopped", "warning")
+	})
 }
 
 // ToggleRecording toggles between recording and idle states
@@ -559,16 +1581,34 @@ func (a *App) ToggleRecording() string {
 	}
 }
 
-// GetConfig returns the current configuration
-func (a *App) GetConfig() map[string]interface{} {
-	return map[string]interface{}{
-		"hotkey":              a.config.GetHotkey(),
-		"hands_free_hotkey":   a.config.GetHandsFreeHotkey(),
-		"push_to_talk_hotkey": a.config.GetPushToTalkHotkey(),
-		"whisper_model":       a.config.GetWhisperModel(),
-		"mode":                a.config.GetMode(),
-		"api_key_set":         a.config.GetGeminiAPIKey() != "",
+// TogglePause pauses or resumes the current recording and returns whether it is now paused
+func (a *App) TogglePause() (bool, error) {
+	if a.state != hotkey.StateRecording {
+		return false, fmt.Errorf("not recording")
+	}
+
+	var err error
+	if a.audioRecorder.IsPaused() {
+		err = a.audioRecorder.Resume()
+	} else {
+		err = a.audioRecorder.Pause()
+	}
+	if err != nil {
+		return false, err
+	}
+
+	paused := a.audioRecorder.IsPaused()
+	fmt.Printf("[App] Recording paused: %t\n", paused)
+	runtime.EventsEmit(a.ctx, "recording-paused", paused)
+	return paused, nil
+}
+
+// GetConfig returns the current user-facing configuration
+func (a *App) GetConfig() (*AppConfigView, error) {
+	if a.config == nil {
+		return nil, fmt.Errorf("config not loaded")
 	}
+	return a.buildConfigView(), nil
 }
 
 // SetAPIKey sets the Gemini API key
@@ -578,6 +1618,88 @@ func (a *App) SetAPIKey(key string) error {
 	return a.config.Save()
 }
 
+// SetKeychainAPIKey sets whether the API keys are kept in the macOS keychain
+// (the default) or in plain text in config.json
+func (a *App) SetKeychainAPIKey(enabled bool) error {
+	if err := a.config.SetKeychainAPIKey(enabled); err != nil {
+		return fmt.Errorf("failed to move API keys: %w", err)
+	}
+	return a.config.Save()
+}
+
+// GetLaunchAtLogin returns whether voxflow is set to start at login. This
+// reflects the login item itself, which the user may have removed.
+func (a *App) GetLaunchAtLogin() bool {
+	return loginitem.IsEnabled()
+}
+
+// SetLaunchAtLogin sets whether voxflow starts when the user logs in
+func (a *App) SetLaunchAtLogin(enabled bool) error {
+	if enabled {
+		if err := loginitem.Enable(); err != nil {
+			return err
+		}
+	} else if err := loginitem.Disable(); err != nil {
+		return err
+	}
+	a.config.SetLaunchAtLogin(enabled)
+	return a.config.Save()
+}
+
+// SetProxy sets the outbound proxy for Gemini requests ("" = HTTPS_PROXY env)
+func (a *App) SetProxy(proxyURL string) error {
+	proxyURL = strings.TrimSpace(proxyURL)
+	if proxyURL != "" {
+		// Validate first so a typo doesn't break the working proxy
+		if _, err := gemini.ParseProxyURL(proxyURL); err != nil {
+			return err
+		}
+	}
+	a.geminiClient.SetProxy(proxyURL)
+	a.config.SetProxy(proxyURL)
+	return a.config.Save()
+}
+
+// GetGeminiModels returns the Gemini models offered in settings
+func (a *App) GetGeminiModels() []string {
+	return gemini.Models
+}
+
+// SetGeminiModel sets the Gemini model used for refinement, e.g. "gemini-2.5-pro"
+func (a *App) SetGeminiModel(model string) error {
+	model = strings.TrimSpace(model)
+	if model == "" {
+		return fmt.Errorf("model name must not be empty")
+	}
+	a.geminiClient.SetModel(model)
+	a.config.SetGeminiModel(model)
+	return a.config.Save()
+}
+
+// SetGeminiRetry sets how many times a rate-limited or failing Gemini request
+// is tried, and the total seconds it may take including backoff
+func (a *App) SetGeminiRetry(attempts, seconds int) error {
+	if attempts < 1 || attempts > 10 {
+		return fmt.Errorf("attempts must be between 1 and 10")
+	}
+	if seconds < 1 || seconds > 120 {
+		return fmt.Errorf("retry time must be between 1 and 120 seconds")
+	}
+	a.geminiClient.SetRetry(attempts, time.Duration(seconds)*time.Second)
+	a.config.SetGeminiRetry(attempts, seconds)
+	return a.config.Save()
+}
+
+// SetGeminiTimeout sets how many seconds a single Gemini request may take
+func (a *App) SetGeminiTimeout(seconds int) error {
+	if seconds < 5 || seconds > 300 {
+		return fmt.Errorf("timeout must be between 5 and 300 seconds")
+	}
+	a.geminiClient.SetTimeout(time.Duration(seconds) * time.Second)
+	a.config.SetGeminiTimeoutSeconds(seconds)
+	return a.config.Save()
+}
+
 // SetHotkey sets the global hotkey
 // reloadHotkeys re-initializes the hotkey manager with current config
 func (a *App) reloadHotkeys() error {
@@ -586,6 +1708,9 @@ func (a *App) reloadHotkeys() error {
 
 	if a.hotkeyManager != nil {
 		fmt.Printf("Updating hotkeys: HF=%s, PTT=%s\n", hf, ptt)
+		a.hotkeyManager.SetQuickNoteHotkey(a.config.GetQuickNoteHotkey())
+		a.hotkeyManager.SetCycleModeHotkey(a.config.GetCycleModeHotkey(), a.cycleModeFromHotkey)
+		a.hotkeyManager.SetEmergencyHotkey(a.config.GetEmergencyStopHotkey(), a.EmergencyStop)
 		return a.hotkeyManager.Update(hf, ptt)
 	}
 	return fmt.Errorf("hotkey manager not initialized")
@@ -596,8 +1721,21 @@ func (a *App) SetHotkey(hotkeyStr string) error {
 	return a.SetHandsFreeHotkey(hotkeyStr)
 }
 
+// ValidateHotkey checks a hotkey string without saving or registering it, so
+// the settings form can show the problem inline. Double-tap specs are only
+// accepted if allowDoubleTap is set (hands-free and quick note).
+func (a *App) ValidateHotkey(hotkeyStr string, allowDoubleTap bool) error {
+	if !allowDoubleTap && hotkey.IsDoubleTap(hotkeyStr) {
+		return fmt.Errorf("double-tap hotkeys (%s) can only be used for hands-free and quick note", hotkeyStr)
+	}
+	return hotkey.ValidateHotkey(hotkeyStr)
+}
+
 // SetHandsFreeHotkey sets the hands-free hotkey
 func (a *App) SetHandsFreeHotkey(hotkeyStr string) error {
+	if err := a.ValidateHotkey(hotkeyStr, true); err != nil {
+		return err
+	}
 	old := a.config.GetHandsFreeHotkey()
 	a.config.SetHandsFreeHotkey(hotkeyStr)
 
@@ -613,6 +1751,9 @@ func (a *App) SetHandsFreeHotkey(hotkeyStr string) error {
 
 // SetPushToTalkHotkey sets the push-to-talk hotkey
 func (a *App) SetPushToTalkHotkey(hotkeyStr string) error {
+	if err := a.ValidateHotkey(hotkeyStr, false); err != nil {
+		return err
+	}
 	old := a.config.GetPushToTalkHotkey()
 	a.config.SetPushToTalkHotkey(hotkeyStr)
 
@@ -626,82 +1767,881 @@ func (a *App) SetPushToTalkHotkey(hotkeyStr string) error {
 	return a.config.Save()
 }
 
-// SetWhisperModel sets the Whisper model size
-func (a *App) SetWhisperModel(model string) error {
-	a.config.SetWhisperModel(model)
-	err := a.config.Save()
-	if err != nil {
+// SetQuickNoteHotkey sets the quick-note hotkey (empty disables it)
+func (a *App) SetQuickNoteHotkey(hotkeyStr string) error {
+	if hotkeyStr != "" {
+		if err := a.ValidateHotkey(hotkeyStr, true); err != nil {
+			return err
+		}
+	}
+	if hotkeyStr != "" && (hotkeyStr == a.config.GetHandsFreeHotkey() || hotkeyStr == a.config.GetPushToTalkHotkey()) {
+		return fmt.Errorf("hotkey %s is already in use", hotkeyStr)
+	}
+
+	old := a.config.GetQuickNoteHotkey()
+	a.config.SetQuickNoteHotkey(hotkeyStr)
+
+	if err := a.reloadHotkeys(); err != nil {
+		fmt.Printf("Error reloading hotkeys (quick note): %v\n", err)
+		a.config.SetQuickNoteHotkey(old) // Revert on error
+		a.reloadHotkeys()                // Restore state
 		return err
 	}
 
-	// Check if model needs to be downloaded
-	a.modelReady = false
-	go a.checkModelStatus()
-	return nil
+	return a.config.Save()
 }
 
-// SetMode sets the transcription mode (casual/formal)
-func (a *App) SetMode(mode string) error {
-	a.config.SetMode(mode)
+// SetPTTMinHold sets the minimum push-to-talk hold in milliseconds; shorter
+// presses are discarded as accidental (0 = off)
+func (a *App) SetPTTMinHold(ms int) error {
+	if ms < 0 || ms > 2000 {
+		return fmt.Errorf("minimum hold must be between 0 and 2000 ms")
+	}
+	if a.hotkeyManager != nil {
+		a.hotkeyManager.SetMinHoldDuration(time.Duration(ms) * time.Millisecond)
+	}
+	a.config.SetPTTMinHoldMs(ms)
 	return a.config.Save()
 }
 
-// GetAllModels returns all available models with their download status
-func (a *App) GetAllModels() ([]whisper.ModelInfo, error) {
-	return a.whisperService.GetAllModels()
+// SetReinjectDelay sets how long in milliseconds to wait for focus to return
+// to the previous app before pasting a transcript from history
+func (a *App) SetReinjectDelay(ms int) error {
+	if ms < 0 || ms > 2000 {
+		return fmt.Errorf("re-inject delay must be between 0 and 2000 ms")
+	}
+	a.config.SetReinjectDelayMs(ms)
+	return a.config.Save()
 }
 
-// DownloadModelByName downloads a specific model by name (cancellable)
-func (a *App) DownloadModelByName(modelName string) error {
-	a.downloadMu.Lock()
-
-	// Cancel any existing download
-	if a.downloadCancel != nil {
-		a.downloadCancel()
+// SetDoubleTapWindow sets the longest gap in milliseconds between the taps
+// of a double-tap ("2x:cmd") hotkey
+func (a *App) SetDoubleTapWindow(ms int) error {
+	if ms < 100 || ms > 1000 {
+		return fmt.Errorf("double tap window must be between 100 and 1000 ms")
 	}
+	if a.hotkeyManager != nil {
+		a.hotkeyManager.SetDoubleTapWindow(time.Duration(ms) * time.Millisecond)
+	}
+	a.config.SetDoubleTapWindowMs(ms)
+	return a.config.Save()
+}
 
-	// Create new context with cancel
-	ctx, cancel := context.WithCancel(context.Background())
-	a.downloadCancel = cancel
-	a.downloadMu.Unlock()
-
-	err := a.whisperService.DownloadModelWithContext(ctx, modelName, func(downloaded, total int64) {
-		progress := float64(downloaded) / float64(total) * 100
-		runtime.EventsEmit(a.ctx, "model-download-progress", map[string]interface{}{
-			"model":      modelName,
-			"downloaded": downloaded,
-			"total":      total,
-			"progress":   progress,
-		})
-	})
+// SetCycleModeHotkey sets the hotkey that switches to the next refinement mode ("" = disabled)
+func (a *App) SetCycleModeHotkey(hotkeyStr string) error {
+	if hotkeyStr != "" {
+		if err := a.ValidateHotkey(hotkeyStr, false); err != nil {
+			return err
+		}
+	}
+	if hotkeyStr != "" && (hotkeyStr == a.config.GetHandsFreeHotkey() || hotkeyStr == a.config.GetPushToTalkHotkey() || hotkeyStr == a.config.GetQuickNoteHotkey()) {
+		return fmt.Errorf("hotkey %s is already in use", hotkeyStr)
+	}
 
-	// Clear the cancel function
-	a.downloadMu.Lock()
-	a.downloadCancel = nil
-	a.downloadMu.Unlock()
+	old := a.config.GetCycleModeHotkey()
+	a.config.SetCycleModeHotkey(hotkeyStr)
 
-	if err != nil {
-		runtime.EventsEmit(a.ctx, "model-download-error", map[string]interface{}{
-			"model": modelName,
-			"error": err.Error(),
-		})
+	if err := a.reloadHotkeys(); err != nil {
+		fmt.Printf("Error reloading hotkeys (cycle mode): %v\n", err)
+		a.config.SetCycleModeHotkey(old) // Revert on error
+		a.reloadHotkeys()                // Restore state
 		return err
 	}
 
-	runtime.EventsEmit(a.ctx, "model-download-complete", modelName)
-	return nil
+	return a.config.Save()
 }
 
-// CancelDownload cancels any active model download
-func (a *App) CancelDownload() {
-	a.downloadMu.Lock()
-	defer a.downloadMu.Unlock()
+// SetAbortHotkey sets the always-on hotkey that triggers EmergencyStop
+func (a *App) SetAbortHotkey(hotkeyStr string) error {
+	if hotkeyStr == "" {
+		return fmt.Errorf("the abort hotkey can't be disabled")
+	}
+	if err := a.ValidateHotkey(hotkeyStr, false); err != nil {
+		return err
+	}
+	if hotkeyStr == a.config.GetHandsFreeHotkey() || hotkeyStr == a.config.GetPushToTalkHotkey() ||
+		hotkeyStr == a.config.GetQuickNoteHotkey() || hotkeyStr == a.config.GetCycleModeHotkey() {
+		return fmt.Errorf("hotkey %s is already in use", hotkeyStr)
+	}
 
-	if a.downloadCancel != nil {
-		fmt.Println("[App] Cancelling download...")
-		a.downloadCancel()
-		a.downloadCancel = nil
-		runtime.EventsEmit(a.ctx, "model-download-cancelled", nil)
+	old := a.config.GetEmergencyStopHotkey()
+	a.config.SetEmergencyStopHotkey(hotkeyStr)
+
+	if err := a.reloadHotkeys(); err != nil {
+		fmt.Printf("Error reloading hotkeys (abort): %v\n", err)
+		a.config.SetEmergencyStopHotkey(old) // Revert on error
+		a.reloadHotkeys()                    // Restore state
+		return err
+	}
+
+	return a.config.Save()
+}
+
+// CycleMode switches to the next refinement mode, built-in then custom,
+// announces it with a toast and returns its name
+func (a *App) CycleMode() (string, error) {
+	modes := a.ListModes()
+	current := a.config.GetMode()
+	next := modes[0].Name
+	for i, m := range modes {
+		if m.Name == current {
+			next = modes[(i+1)%len(modes)].Name
+			break
+		}
+	}
+
+	if err := a.SetMode(next); err != nil {
+		return "", err
+	}
+	a.emitEvent("mode-changed", map[string]interface{}{
+		"mode": next,
+	})
+	a.emitToast("Mode: "+next, "info")
+	return next, nil
+}
+
+// cycleModeFromHotkey handles the cycle mode hotkey
+func (a *App) cycleModeFromHotkey() {
+	if _, err := a.CycleMode(); err != nil {
+		a.emitToast("Couldn't switch mode: "+err.Error(), "error")
+	}
+}
+
+// SetWhisperModel sets the Whisper model size
+func (a *App) SetWhisperModel(model string) error {
+	a.config.SetWhisperModel(model)
+	err := a.config.Save()
+	if err != nil {
+		return err
+	}
+
+	// Check if model needs to be downloaded
+	a.modelReady = false
+	go a.checkModelStatus()
+	return nil
+}
+
+// GetModelsDir returns the directory Whisper models are currently stored in
+func (a *App) GetModelsDir() (string, error) {
+	return whisper.GetModelsDir()
+}
+
+// SetModelsDir sets where Whisper models are stored ("" = ~/.voxflow/models).
+// Existing models are not moved, so the current model may need downloading again.
+func (a *App) SetModelsDir(dir string) error {
+	if err := whisper.SetModelsDir(dir); err != nil {
+		return err
+	}
+	a.config.SetModelsDir(dir)
+	if err := a.config.Save(); err != nil {
+		return err
+	}
+
+	a.modelReady = false
+	go a.checkModelStatus()
+	return nil
+}
+
+// SetMode sets the transcription mode (a built-in or custom mode)
+func (a *App) SetMode(mode string) error {
+	if !a.isKnownMode(mode) {
+		return fmt.Errorf("unknown mode: %s", mode)
+	}
+	a.config.SetMode(mode)
+	return a.config.Save()
+}
+
+// SetLineEnding sets the line ending used when writing text to the clipboard (lf/crlf)
+func (a *App) SetLineEnding(ending string) error {
+	if a.injectionService != nil {
+		if err := a.injectionService.SetLineEnding(ending); err != nil {
+			return err
+		}
+	} else if ending != injection.LineEndingLF && ending != injection.LineEndingCRLF {
+		return fmt.Errorf("unknown line ending: %s", ending)
+	}
+	a.config.SetLineEnding(ending)
+	return a.config.Save()
+}
+
+// SetDateTokenStage sets when date/time voice tokens are expanded (before, after, off)
+func (a *App) SetDateTokenStage(stage string) error {
+	switch stage {
+	case "before", "after", "off":
+	default:
+		return fmt.Errorf("unknown date token stage: %s", stage)
+	}
+	a.config.SetDateTokenStage(stage)
+	return a.config.Save()
+}
+
+// SetDateTimeFormats sets the Go layouts used for expanded date and time tokens
+func (a *App) SetDateTimeFormats(dateFormat, timeFormat string) error {
+	if dateFormat == "" {
+		dateFormat = textproc.DefaultDateFormat
+	}
+	if timeFormat == "" {
+		timeFormat = textproc.DefaultTimeFormat
+	}
+	a.config.SetDateTimeFormats(dateFormat, timeFormat)
+	return a.config.Save()
+}
+
+// SetMaxOutputChars sets the maximum polished output length for a mode (0 = unlimited)
+func (a *App) SetMaxOutputChars(mode string, maxChars int) error {
+	if maxChars < 0 {
+		return fmt.Errorf("max output chars cannot be negative")
+	}
+	a.config.SetMaxOutputChars(mode, maxChars)
+	return a.config.Save()
+}
+
+// SetLocalServerEnabled starts or stops the localhost /status and /events server
+func (a *App) SetLocalServerEnabled(enabled bool) error {
+	_, port := a.config.GetLocalServer()
+	srv := a.getLocalServer()
+	if enabled {
+		if srv == nil || !srv.IsRunning() {
+			if err := a.startLocalServer(port); err != nil {
+				return err
+			}
+		}
+	} else if srv != nil {
+		srv.Stop()
+	}
+
+	a.config.SetLocalServerEnabled(enabled)
+	return a.config.Save()
+}
+
+// SetChunking sets how long recordings are split for transcription (0 seconds disables)
+func (a *App) SetChunking(chunkSeconds, overlapSeconds int) error {
+	if chunkSeconds < 0 || overlapSeconds < 0 {
+		return fmt.Errorf("chunk length and overlap cannot be negative")
+	}
+	if chunkSeconds > 0 && overlapSeconds >= chunkSeconds {
+		return fmt.Errorf("chunk overlap must be shorter than the chunk length")
+	}
+	a.config.SetChunking(chunkSeconds, overlapSeconds)
+	a.whisperService.SetChunking(chunkSeconds, overlapSeconds)
+	return a.config.Save()
+}
+
+// SetPrivacyMode turns session-only privacy mode on or off. While on, nothing
+// from dictation is written to disk. It is intentionally not persisted.
+func (a *App) SetPrivacyMode(enabled bool) {
+	a.privacyMode.Store(enabled)
+	if enabled {
+		a.clearLastRecording()
+	}
+	runtime.EventsEmit(a.ctx, "privacy-mode", enabled)
+	fmt.Printf("[App] Privacy mode: %v\n", enabled)
+}
+
+// GetPrivacyMode returns whether privacy mode is on
+func (a *App) GetPrivacyMode() bool {
+	return a.privacyMode.Load()
+}
+
+// SetPrivacyClearClipboard sets whether privacy mode clears the clipboard after injection
+func (a *App) SetPrivacyClearClipboard(enabled bool) error {
+	a.config.SetPrivacyClearClipboard(enabled)
+	return a.config.Save()
+}
+
+// ModeInfo describes a refinement mode for the mode picker
+type ModeInfo struct {
+	Name    string `json:"name"`
+	Prompt  string `json:"prompt"` // Empty for built-in modes
+	Builtin bool   `json:"builtin"`
+}
+
+// ListModes returns the built-in modes followed by the custom modes in display order
+func (a *App) ListModes() []ModeInfo {
+	modes := make([]ModeInfo, 0, len(gemini.BuiltinModes))
+	for _, name := range gemini.BuiltinModes {
+		modes = append(modes, ModeInfo{Name: name, Builtin: true})
+	}
+	for _, m := range a.config.GetCustomModes() {
+		modes = append(modes, ModeInfo{Name: m.Name, Prompt: m.Prompt})
+	}
+	return modes
+}
+
+// isKnownMode returns whether mode is a built-in or custom mode
+func (a *App) isKnownMode(mode string) bool {
+	if gemini.IsBuiltinMode(mode) {
+		return true
+	}
+	for _, m := range a.config.GetCustomModes() {
+		if m.Name == mode {
+			return true
+		}
+	}
+	return false
+}
+
+// SaveMode creates a custom mode, or updates the prompt of an existing one
+func (a *App) SaveMode(name, prompt string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("mode name cannot be empty")
+	}
+	if gemini.IsBuiltinMode(name) {
+		return fmt.Errorf("%s is a built-in mode name", name)
+	}
+	if strings.TrimSpace(prompt) == "" {
+		return fmt.Errorf("mode prompt cannot be empty")
+	}
+	a.config.SaveCustomMode(name, prompt)
+	a.syncCustomModes()
+	return a.config.Save()
+}
+
+// DeleteMode removes a custom mode. Deleting the active mode switches to casual.
+func (a *App) DeleteMode(name string) error {
+	if gemini.IsBuiltinMode(name) {
+		return fmt.Errorf("built-in mode %s cannot be deleted", name)
+	}
+	if err := a.config.DeleteCustomMode(name); err != nil {
+		return err
+	}
+	a.syncCustomModes()
+	return a.config.Save()
+}
+
+// syncCustomModes passes the custom mode prompts to the refinement clients
+func (a *App) syncCustomModes() {
+	prompts := map[string]string{}
+	for _, m := range a.config.GetCustomModes() {
+		prompts[m.Name] = m.Prompt
+	}
+	a.geminiClient.SetCustomModes(prompts)
+	a.openaiClient.SetCustomModes(prompts)
+}
+
+// ReorderModes sets the display order of custom modes
+func (a *App) ReorderModes(order []string) error {
+	if err := a.config.ReorderCustomModes(order); err != nil {
+		return err
+	}
+	return a.config.Save()
+}
+
+// SetCustomModes replaces all custom modes (in display order)
+func (a *App) SetCustomModes(modes []config.CustomMode) error {
+	if err := validateCustomModes(modes); err != nil {
+		return err
+	}
+	a.config.SetCustomModes(modes)
+	a.syncCustomModes()
+	return a.config.Save()
+}
+
+// validateCustomModes rejects empty, built-in and duplicate custom mode names
+func validateCustomModes(modes []config.CustomMode) error {
+	seen := map[string]bool{}
+	for _, m := range modes {
+		if strings.TrimSpace(m.Name) == "" {
+			return fmt.Errorf("mode name cannot be empty")
+		}
+		if gemini.IsBuiltinMode(m.Name) {
+			return fmt.Errorf("%s is a built-in mode name", m.Name)
+		}
+		if seen[m.Name] {
+			return fmt.Errorf("duplicate mode: %s", m.Name)
+		}
+		seen[m.Name] = true
+	}
+	return nil
+}
+
+// RenameMode renames a custom mode, updating the active mode and per-mode settings
+func (a *App) RenameMode(oldName, newName string) error {
+	newName = strings.TrimSpace(newName)
+	if newName == "" {
+		return fmt.Errorf("mode name cannot be empty")
+	}
+	if gemini.IsBuiltinMode(oldName) {
+		return fmt.Errorf("built-in mode %s cannot be renamed", oldName)
+	}
+	if gemini.IsBuiltinMode(newName) {
+		return fmt.Errorf("%s is a built-in mode name", newName)
+	}
+	if err := a.config.RenameCustomMode(oldName, newName); err != nil {
+		return err
+	}
+	a.syncCustomModes()
+	return a.config.Save()
+}
+
+// GetAppearance returns the app appearance (system, light, dark)
+func (a *App) GetAppearance() string {
+	return a.config.GetAppearance()
+}
+
+// SetAppearance sets the app appearance at runtime and persists it
+func (a *App) SetAppearance(appearance string) error {
+	switch appearance {
+	case "system", "light", "dark":
+	default:
+		return fmt.Errorf("unknown appearance: %s", appearance)
+	}
+
+	SetAppAppearance(appearance)
+	a.config.SetAppearance(appearance)
+	runtime.EventsEmit(a.ctx, "appearance-changed", appearance)
+	return a.config.Save()
+}
+
+// SetDownloadStallTimeout sets how many seconds a download may stall before
+// retrying (min 5, or 0 to never retry a stalled download)
+func (a *App) SetDownloadStallTimeout(seconds int) error {
+	if seconds != 0 && seconds < 5 {
+		return fmt.Errorf("stall timeout must be at least 5 seconds, or 0 to disable it")
+	}
+	a.config.SetDownloadStallSeconds(seconds)
+	a.whisperService.SetStallTimeout(time.Duration(seconds) * time.Second)
+	return a.config.Save()
+}
+
+// SetConfirmBeforeInject sets whether to ask before pasting dictated text
+func (a *App) SetConfirmBeforeInject(enabled bool) error {
+	a.config.SetConfirmBeforeInject(enabled)
+	return a.config.Save()
+}
+
+// ConfirmInjection answers a pending confirm-injection request
+func (a *App) ConfirmInjection(approved bool) error {
+	a.confirmMu.Lock()
+	defer a.confirmMu.Unlock()
+
+	if a.confirmCh == nil {
+		return fmt.Errorf("no injection awaiting confirmation")
+	}
+	select {
+	case a.confirmCh <- approved:
+	default:
+	}
+	a.confirmCh = nil
+	return nil
+}
+
+// testAudioEnv must be set to "1" to allow UseTestAudio
+const testAudioEnv = "VOXFLOW_DEV"
+
+// UseTestAudio feeds a fixed WAV file (16kHz mono) into the pipeline instead of
+// the microphone; an empty path switches back to the mic. Dev/test only:
+// requires VOXFLOW_DEV=1.
+func (a *App) UseTestAudio(path string) error {
+	if os.Getenv(testAudioEnv) != "1" {
+		return fmt.Errorf("test audio is a development feature; set %s=1 to enable it", testAudioEnv)
+	}
+	if path != "" {
+		if _, err := audio.WavDuration(path); err != nil {
+			return fmt.Errorf("invalid test audio: %w", err)
+		}
+	}
+	a.testAudioPath = path
+	fmt.Printf("[App] Test audio set to %q\n", path)
+	return nil
+}
+
+// applySilenceStop copies the silence auto-stop settings to the recorder
+func (a *App) applySilenceStop() {
+	timeoutSecs, thresholdDB := a.config.GetSilenceStop()
+	a.audioRecorder.SilenceTimeout = time.Duration(timeoutSecs * float64(time.Second))
+	a.audioRecorder.SilenceThresholdDB = thresholdDB
+}
+
+// SetSilenceStop configures hands-free auto-stop: recording ends after
+// timeoutSecs of trailing silence below thresholdDB (dBFS). 0 seconds disables it.
+func (a *App) SetSilenceStop(timeoutSecs, thresholdDB float64) error {
+	if timeoutSecs < 0 || timeoutSecs > 60 {
+		return fmt.Errorf("silence timeout must be between 0 and 60 seconds")
+	}
+	if thresholdDB < -90 || thresholdDB >= 0 {
+		return fmt.Errorf("silence threshold must be between -90 and 0 dB")
+	}
+	a.config.SetSilenceStop(timeoutSecs, thresholdDB)
+	a.applySilenceStop()
+	return a.config.Save()
+}
+
+// SetInputGain sets the software amplification for the microphone (1 = unchanged)
+func (a *App) SetInputGain(gain float64) error {
+	if gain < 0.1 || gain > 10 {
+		return fmt.Errorf("gain must be between 0.1 and 10")
+	}
+	a.config.SetInputGain(gain)
+	a.audioRecorder.SetGain(float32(gain))
+	return a.config.Save()
+}
+
+// SetPreRoll sets how many milliseconds of audio from before the hotkey are
+// prepended to each recording. Any value above 0 keeps the microphone open while idle.
+func (a *App) SetPreRoll(ms int) error {
+	if ms < 0 || ms > 2000 {
+		return fmt.Errorf("pre-roll must be between 0 and 2000 ms")
+	}
+	if err := a.audioRecorder.EnablePreRoll(time.Duration(ms) * time.Millisecond); err != nil {
+		return err
+	}
+	a.config.SetPreRollMs(ms)
+	return a.config.Save()
+}
+
+// SetMaxRecordingDuration caps recording length in seconds (0 = unlimited)
+func (a *App) SetMaxRecordingDuration(secs int) error {
+	if secs < 0 || secs > 3600 {
+		return fmt.Errorf("max recording duration must be between 0 and 3600 seconds")
+	}
+	a.config.SetMaxRecordingSecs(secs)
+	a.audioRecorder.SetMaxDuration(time.Duration(secs) * time.Second)
+	return a.config.Save()
+}
+
+// ListInputDevices returns the available microphones
+func (a *App) ListInputDevices() ([]audio.DeviceInfo, error) {
+	return a.audioRecorder.ListInputDevices()
+}
+
+// SetInputDevice selects the microphone by name ("" = system default)
+func (a *App) SetInputDevice(name string) error {
+	if name != "" {
+		devices, err := a.audioRecorder.ListInputDevices()
+		if err != nil {
+			return err
+		}
+		found := false
+		for _, d := range devices {
+			if d.Name == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("input device not found: %s", name)
+		}
+	}
+
+	a.audioRecorder.SetInputDevice(name)
+	a.config.SetInputDevice(name)
+	return a.config.Save()
+}
+
+// SetKeepPillDuringProcessing sets whether the mini indicator stays on screen,
+// above other windows, while a recording is being processed
+func (a *App) SetKeepPillDuringProcessing(enabled bool) error {
+	a.config.SetKeepPillDuringProcessing(enabled)
+	return a.config.Save()
+}
+
+// pasteDelays converts the configured paste waits to injection.Delays
+func pasteDelays(settleMs, postPasteMs, restoreMs int, adaptive bool) injection.Delays {
+	return injection.Delays{
+		ClipboardSettle: time.Duration(settleMs) * time.Millisecond,
+		PostPaste:       time.Duration(postPasteMs) * time.Millisecond,
+		Restore:         time.Duration(restoreMs) * time.Millisecond,
+		Adaptive:        adaptive,
+	}
+}
+
+// SetPasteDelays sets the waits in milliseconds around a paste: after writing
+// the clipboard (skipped when adaptive, which waits for macOS to confirm the
+// write instead), after Cmd+V, and before restoring the previous clipboard
+func (a *App) SetPasteDelays(settleMs, postPasteMs, restoreMs int, adaptive bool) error {
+	for _, ms := range []int{settleMs, postPasteMs, restoreMs} {
+		if ms < 0 || ms > 2000 {
+			return fmt.Errorf("paste delays must be between 0 and 2000 ms")
+		}
+	}
+	if a.injectionService != nil {
+		a.injectionService.SetDelays(pasteDelays(settleMs, postPasteMs, restoreMs, adaptive))
+	}
+	a.config.SetPasteDelays(settleMs, postPasteMs, restoreMs, adaptive)
+	return a.config.Save()
+}
+
+// SetInjectionMode sets how text reaches the focused app: "paste" via the
+// clipboard, or "type" as keystrokes for apps that block or mangle paste
+func (a *App) SetInjectionMode(mode string) error {
+	if a.injectionService != nil {
+		if err := a.injectionService.SetMode(mode); err != nil {
+			return err
+		}
+	}
+	a.config.SetInjectionMode(mode)
+	return a.config.Save()
+}
+
+// SetBlankAudioRetry sets how many times transcription is retried when no
+// speech is detected (0-5), and the wait between attempts (0-5000 ms)
+func (a *App) SetBlankAudioRetry(retries, delayMs int) error {
+	if retries < 0 || retries > 5 {
+		return fmt.Errorf("blank audio retries must be between 0 and 5")
+	}
+	if delayMs < 0 || delayMs > 5000 {
+		return fmt.Errorf("blank audio retry delay must be between 0 and 5000 ms")
+	}
+	a.config.SetBlankAudioRetry(retries, delayMs)
+	return a.config.Save()
+}
+
+// SetPasteRetries sets how many times a failed paste keystroke is retried (0-5)
+func (a *App) SetPasteRetries(retries int) error {
+	if retries < 0 || retries > 5 {
+		return fmt.Errorf("paste retries must be between 0 and 5")
+	}
+	if a.injectionService != nil {
+		a.injectionService.SetPasteRetries(retries)
+	}
+	a.config.SetPasteRetries(retries)
+	return a.config.Save()
+}
+
+// SetCodeSpokenSymbols enables local conversion of spoken symbols ("open brace",
+// "equals", "dot") before refinement in code mode
+func (a *App) SetCodeSpokenSymbols(enabled bool) error {
+	a.config.SetCodeSpokenSymbols(enabled)
+	return a.config.Save()
+}
+
+// SetToneTagging enables classifying each recording's tone during refinement.
+// This makes Gemini output slightly larger, so it is off by default.
+func (a *App) SetToneTagging(enabled bool) error {
+	a.config.SetToneTagging(enabled)
+	a.geminiClient.SetToneTagging(enabled)
+	a.openaiClient.SetToneTagging(enabled)
+	return a.config.Save()
+}
+
+// ListReplacements returns the text replacement dictionary
+func (a *App) ListReplacements() map[string]string {
+	return a.config.GetReplacements()
+}
+
+// AddReplacement adds or updates a find-and-replace applied to polished text.
+// Prefix from with "re:" to use a regular expression.
+func (a *App) AddReplacement(from, to string) error {
+	if err := textproc.ValidateReplacement(from); err != nil {
+		return err
+	}
+	a.config.AddReplacement(from, to)
+	return a.config.Save()
+}
+
+// RemoveReplacement removes a text replacement
+func (a *App) RemoveReplacement(from string) error {
+	if err := a.config.RemoveReplacement(from); err != nil {
+		return err
+	}
+	return a.config.Save()
+}
+
+// SetReplacements replaces the whole text replacement dictionary
+func (a *App) SetReplacements(replacements map[string]string) error {
+	for from := range replacements {
+		if err := textproc.ValidateReplacement(from); err != nil {
+			return err
+		}
+	}
+	a.config.SetReplacements(replacements)
+	return a.config.Save()
+}
+
+// SetCaseStyle sets the casing applied to polished text (asis, sentence, title, upper, lower)
+func (a *App) SetCaseStyle(style string) error {
+	if err := textproc.ValidateCaseStyle(style); err != nil {
+		return err
+	}
+	a.config.SetCaseStyle(style)
+	return a.config.Save()
+}
+
+// GetAverageLatency returns the rolling average of pipeline timings over recent runs
+func (a *App) GetAverageLatency() LatencyBreakdown {
+	return a.latency.Average()
+}
+
+// GetProcessingStats returns totals and averages of the pipeline timings
+// stored with every transcript
+func (a *App) GetProcessingStats() (*history.Stats, error) {
+	if a.historyService == nil {
+		return nil, fmt.Errorf("history service not available")
+	}
+	return a.historyService.GetStats()
+}
+
+// SetQuitWhileBusy sets what happens when quitting during recording or processing
+func (a *App) SetQuitWhileBusy(policy string) error {
+	switch policy {
+	case "ask", "finish", "discard":
+	default:
+		return fmt.Errorf("unknown quit policy: %s", policy)
+	}
+	a.config.SetQuitWhileBusy(policy)
+	return a.config.Save()
+}
+
+// SetLanguage sets the transcription language ("auto" to let whisper detect it)
+func (a *App) SetLanguage(code string) error {
+	if err := a.whisperService.SetLanguage(code); err != nil {
+		return err
+	}
+	a.config.SetLanguage(code)
+	return a.config.Save()
+}
+
+// SetWhisperThreads sets how many CPU threads whisper uses (at least 1)
+func (a *App) SetWhisperThreads(threads int) error {
+	if threads < 1 {
+		return fmt.Errorf("whisper threads must be at least 1")
+	}
+	a.whisperService.SetThreads(threads)
+	a.config.SetWhisperThreads(threads)
+	return a.config.Save()
+}
+
+// SetVocabularyPrompt sets terms (product names, jargon) that whisper is nudged
+// towards recognizing. This is a soft bias, not a guarantee. Overly long
+// lists are trimmed to fit whisper's context.
+func (a *App) SetVocabularyPrompt(prompt string) error {
+	prompt, trimmed := whisper.TrimPrompt(prompt)
+	if trimmed {
+		a.emitToast("Vocabulary list was too long for Whisper and has been shortened", "warning")
+	}
+	a.whisperService.SetPrompt(prompt)
+	a.config.SetVocabularyPrompt(prompt)
+	return a.config.Save()
+}
+
+// SetTranslate sets whether speech is translated to English before refinement
+func (a *App) SetTranslate(translate bool) error {
+	a.whisperService.SetTranslate(translate)
+	a.config.SetTranslate(translate)
+	return a.config.Save()
+}
+
+// GetSupportedLanguages returns the languages offered for transcription
+func (a *App) GetSupportedLanguages() []whisper.Language {
+	return whisper.SupportedLanguages
+}
+
+// SetBeamSize sets the whisper beam size (1-16). Larger is more accurate but slower.
+func (a *App) SetBeamSize(beamSize int) error {
+	_, temperature := a.config.GetWhisperDecoding()
+	if err := a.whisperService.SetDecoding(beamSize, temperature); err != nil {
+		return err
+	}
+	a.config.SetBeamSize(beamSize)
+	return a.config.Save()
+}
+
+// SetWhisperTemperature sets the whisper sampling temperature (0-1)
+func (a *App) SetWhisperTemperature(temperature float64) error {
+	beamSize, _ := a.config.GetWhisperDecoding()
+	if err := a.whisperService.SetDecoding(beamSize, temperature); err != nil {
+		return err
+	}
+	a.config.SetWhisperTemperature(temperature)
+	return a.config.Save()
+}
+
+// GetAllModels returns all available models with their download status
+func (a *App) GetAllModels() ([]whisper.ModelInfo, error) {
+	return a.whisperService.GetAllModels()
+}
+
+// DownloadModelByName downloads a specific model by name (cancellable)
+func (a *App) DownloadModelByName(modelName string) error {
+	ctx, err := a.beginDownload(modelName)
+	if err != nil {
+		return err
+	}
+
+	err = a.whisperService.DownloadModelWithContext(ctx, modelName, func(downloaded, total int64) {
+		progress := float64(downloaded) / float64(total) * 100
+		runtime.EventsEmit(a.ctx, "model-download-progress", map[string]interface{}{
+			"model":      modelName,
+			"downloaded": downloaded,
+			"total":      total,
+			"progress":   progress,
+		})
+	})
+
+	a.endDownload(modelName)
+
+	if err != nil {
+		runtime.EventsEmit(a.ctx, "model-download-error", map[string]interface{}{
+			"model": modelName,
+			"error": err.Error(),
+		})
+		return err
+	}
+
+	runtime.EventsEmit(a.ctx, "model-download-complete", modelName)
+	return nil
+}
+
+// beginDownload registers a download for modelName, cancelling any download of
+// a different model. A second request for a model already downloading is
+// rejected so two downloads never write the same temp file.
+func (a *App) beginDownload(modelName string) (context.Context, error) {
+	a.downloadMu.Lock()
+	defer a.downloadMu.Unlock()
+
+	if a.downloading[modelName] {
+		runtime.EventsEmit(a.ctx, "model-download-in-progress", modelName)
+		return nil, fmt.Errorf("model %s is already downloading", modelName)
+	}
+
+	// Cancel any existing download
+	if a.downloadCancel != nil {
+		a.downloadCancel()
+	}
+
+	// Create new context with cancel
+	ctx, cancel := context.WithCancel(context.Background())
+	a.downloadCancel = cancel
+	a.activeDownload = modelName
+	a.downloading[modelName] = true
+	return ctx, nil
+}
+
+// endDownload clears the download state registered by beginDownload
+func (a *App) endDownload(modelName string) {
+	a.downloadMu.Lock()
+	defer a.downloadMu.Unlock()
+
+	delete(a.downloading, modelName)
+	if a.activeDownload == modelName {
+		if a.downloadCancel != nil {
+			a.downloadCancel()
+		}
+		a.downloadCancel = nil
+		a.activeDownload = ""
+	}
+}
+
+// IsDownloading returns whether a download of modelName is in progress
+func (a *App) IsDownloading(modelName string) bool {
+	a.downloadMu.Lock()
+	defer a.downloadMu.Unlock()
+	return a.downloading[modelName]
+}
+
+// CancelDownload cancels any active model download
+func (a *App) CancelDownload() {
+	a.downloadMu.Lock()
+	defer a.downloadMu.Unlock()
+
+	if a.downloadCancel != nil {
+		fmt.Println("[App] Cancelling download...")
+		a.downloadCancel()
+		a.downloadCancel = nil
+		a.activeDownload = ""
+		runtime.EventsEmit(a.ctx, "model-download-cancelled", nil)
 	}
 }
 
@@ -721,6 +2661,74 @@ func (a *App) DeleteModelByName(modelName string) error {
 	return nil
 }
 
+// PickModelFile opens a file picker for choosing a model file to import
+func (a *App) PickModelFile() (string, error) {
+	return runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title: "Import Whisper Model",
+		Filters: []runtime.FileFilter{
+			{DisplayName: "Whisper models (*.bin)", Pattern: "*.bin"},
+		},
+	})
+}
+
+// ImportModel copies a model file from disk instead of downloading it
+func (a *App) ImportModel(modelName, srcPath string) error {
+	if err := a.whisperService.ImportModel(modelName, srcPath); err != nil {
+		return err
+	}
+
+	// Load it right away if it is the model we were waiting for
+	if modelName == a.config.GetWhisperModel() && !a.modelReady {
+		go a.checkModelStatus()
+	}
+	return nil
+}
+
+// VerifyModel checks a downloaded model's SHA-256 against the published hash
+func (a *App) VerifyModel(modelName string) error {
+	return a.whisperService.VerifyModel(modelName)
+}
+
+// BenchmarkModels transcribes a sample clip with every downloaded model
+// (cancellable). An empty wavPath uses the built-in clip.
+func (a *App) BenchmarkModels(wavPath string) ([]whisper.BenchmarkResult, error) {
+	a.benchmarkMu.Lock()
+	if a.benchmarkCancel != nil {
+		a.benchmarkMu.Unlock()
+		return nil, fmt.Errorf("benchmark already running")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	a.benchmarkCancel = cancel
+	a.benchmarkMu.Unlock()
+
+	defer func() {
+		a.benchmarkMu.Lock()
+		a.benchmarkCancel = nil
+		a.benchmarkMu.Unlock()
+		cancel()
+	}()
+
+	return a.whisperService.BenchmarkModels(ctx, wavPath, func(done, total int, result whisper.BenchmarkResult) {
+		runtime.EventsEmit(a.ctx, "benchmark-progress", map[string]interface{}{
+			"done":   done,
+			"total":  total,
+			"result": result,
+		})
+	})
+}
+
+// CancelBenchmark cancels a running model benchmark
+func (a *App) CancelBenchmark() {
+	a.benchmarkMu.Lock()
+	defer a.benchmarkMu.Unlock()
+
+	if a.benchmarkCancel != nil {
+		fmt.Println("[App] Cancelling benchmark...")
+		a.benchmarkCancel()
+		a.benchmarkCancel = nil
+	}
+}
+
 // IsWhisperCLIReady returns whether whisper-cli is available
 func (a *App) IsWhisperCLIReady() bool {
 	return a.whisperService.IsWhisperCLIInstalled()
@@ -731,12 +2739,21 @@ func (a *App) EnsureWhisperCLI() error {
 	return a.whisperService.EnsureWhisperCLI(nil)
 }
 
-// GetHistory returns transcript history
-func (a *App) GetHistory(limit int) ([]*history.Transcript, error) {
+// GetHistory returns a page of transcript history, newest first, with the
+// total count so the list can load more as it scrolls
+func (a *App) GetHistory(offset, limit int) (*history.Page, error) {
+	if a.historyService == nil {
+		return nil, fmt.Errorf("history service not available")
+	}
+	return a.historyService.GetPage(offset, limit)
+}
+
+// GetFavorites returns favorite transcripts, newest first
+func (a *App) GetFavorites(limit int) ([]*history.Transcript, error) {
 	if a.historyService == nil {
 		return nil, fmt.Errorf("history service not available")
 	}
-	return a.historyService.GetAll(limit)
+	return a.historyService.GetAll(limit, true)
 }
 
 // SearchHistory searches transcript history
@@ -744,7 +2761,47 @@ func (a *App) SearchHistory(query string, limit int) ([]*history.Transcript, err
 	if a.historyService == nil {
 		return nil, fmt.Errorf("history service not available")
 	}
-	return a.historyService.Search(query, limit)
+	return a.historyService.Search(query, limit, false)
+}
+
+// SetFavorite pins or unpins a transcript
+func (a *App) SetFavorite(id int64, favorite bool) error {
+	if a.historyService == nil {
+		return fmt.Errorf("history service not available")
+	}
+	return a.historyService.SetFavorite(id, favorite)
+}
+
+// AddTag adds a tag to a transcript
+func (a *App) AddTag(id int64, tag string) error {
+	if a.historyService == nil {
+		return fmt.Errorf("history service not available")
+	}
+	return a.historyService.AddTag(id, tag)
+}
+
+// RemoveTag removes a tag from a transcript
+func (a *App) RemoveTag(id int64, tag string) error {
+	if a.historyService == nil {
+		return fmt.Errorf("history service not available")
+	}
+	return a.historyService.RemoveTag(id, tag)
+}
+
+// GetHistoryByTag returns transcripts with the given tag, newest first
+func (a *App) GetHistoryByTag(tag string) ([]*history.Transcript, error) {
+	if a.historyService == nil {
+		return nil, fmt.Errorf("history service not available")
+	}
+	return a.historyService.GetByTag(tag)
+}
+
+// GetHistoryByApp returns transcripts dictated into the named app, newest first
+func (a *App) GetHistoryByApp(appName string) ([]*history.Transcript, error) {
+	if a.historyService == nil {
+		return nil, fmt.Errorf("history service not available")
+	}
+	return a.historyService.GetByApp(appName)
 }
 
 // GetTranscript returns a single transcript by ID
@@ -755,7 +2812,32 @@ func (a *App) GetTranscript(id int64) (*history.Transcript, error) {
 	return a.historyService.GetByID(id)
 }
 
-// DeleteTranscript deletes a transcript by ID
+// GetTranscriptSegments returns a transcript's timestamped segments, or an
+// empty list if none were recorded
+func (a *App) GetTranscriptSegments(id int64) ([]history.Segment, error) {
+	if a.historyService == nil {
+		return nil, fmt.Errorf("history service not available")
+	}
+	segments, err := a.historyService.GetSegments(id)
+	if err != nil {
+		return nil, err
+	}
+	if segments == nil {
+		segments = []history.Segment{}
+	}
+	return segments, nil
+}
+
+// historySegments converts whisper segments for storage in history
+func historySegments(segments []whisper.Segment) []history.Segment {
+	out := make([]history.Segment, len(segments))
+	for i, seg := range segments {
+		out[i] = history.Segment(seg)
+	}
+	return out
+}
+
+// DeleteTranscript moves a transcript to the trash
 func (a *App) DeleteTranscript(id int64) error {
 	if a.historyService == nil {
 		return fmt.Errorf("history service not available")
@@ -763,7 +2845,7 @@ func (a *App) DeleteTranscript(id int64) error {
 	return a.historyService.Delete(id)
 }
 
-// ClearAllHistory deletes all transcripts
+// ClearAllHistory moves all transcripts to the trash
 func (a *App) ClearAllHistory() error {
 	if a.historyService == nil {
 		return fmt.Errorf("history service not available")
@@ -771,6 +2853,153 @@ func (a *App) ClearAllHistory() error {
 	return a.historyService.DeleteAll()
 }
 
+// DeleteHistoryByDateRange moves transcripts created from start up to end
+// (RFC 3339 times) to the trash and returns how many were moved
+func (a *App) DeleteHistoryByDateRange(start, end string, keepFavorites bool) (int64, error) {
+	if a.historyService == nil {
+		return 0, fmt.Errorf("history service not available")
+	}
+	from, err := time.Parse(time.RFC3339, start)
+	if err != nil {
+		return 0, fmt.Errorf("invalid start time: %w", err)
+	}
+	to, err := time.Parse(time.RFC3339, end)
+	if err != nil {
+		return 0, fmt.Errorf("invalid end time: %w", err)
+	}
+	return a.historyService.DeleteByDateRange(from, to, keepFavorites)
+}
+
+// DeleteHistoryByMode moves transcripts refined in mode to the trash and
+// returns how many were moved
+func (a *App) DeleteHistoryByMode(mode string, keepFavorites bool) (int64, error) {
+	if a.historyService == nil {
+		return 0, fmt.Errorf("history service not available")
+	}
+	return a.historyService.DeleteByMode(mode, keepFavorites)
+}
+
+// DeleteHistoryByApp moves transcripts dictated into the named app to the
+// trash and returns how many were moved
+func (a *App) DeleteHistoryByApp(appName string, keepFavorites bool) (int64, error) {
+	if a.historyService == nil {
+		return 0, fmt.Errorf("history service not available")
+	}
+	return a.historyService.DeleteByApp(appName, keepFavorites)
+}
+
+// GetTrash returns deleted transcripts that can still be restored
+func (a *App) GetTrash() ([]*history.Transcript, error) {
+	if a.historyService == nil {
+		return nil, fmt.Errorf("history service not available")
+	}
+	return a.historyService.GetTrash()
+}
+
+// RestoreTranscript takes a transcript back out of the trash
+func (a *App) RestoreTranscript(id int64) error {
+	if a.historyService == nil {
+		return fmt.Errorf("history service not available")
+	}
+	return a.historyService.Restore(id)
+}
+
+// EmptyTrash permanently removes everything in the trash
+func (a *App) EmptyTrash() error {
+	if a.historyService == nil {
+		return fmt.Errorf("history service not available")
+	}
+	ids, err := a.historyService.PurgeDeleted(0)
+	a.deleteRecordings(ids)
+	return err
+}
+
+// SetConfirmDelete sets whether the UI asks before deleting history entries
+func (a *App) SetConfirmDelete(confirm bool) error {
+	a.config.SetConfirmDelete(confirm)
+	return a.config.Save()
+}
+
+// SetTrashRetentionDays sets how long deleted transcripts stay restorable
+func (a *App) SetTrashRetentionDays(days int) error {
+	if days < 1 || days > 365 {
+		return fmt.Errorf("trash retention must be between 1 and 365 days")
+	}
+	a.config.SetTrashRetentionDays(days)
+	return a.config.Save()
+}
+
+// SetHistoryRetention deletes transcripts older than days and keeps at most
+// maxEntries, never deleting favorites. 0 disables either limit.
+func (a *App) SetHistoryRetention(days, maxEntries int) error {
+	if days < 0 || maxEntries < 0 {
+		return fmt.Errorf("retention limits must not be negative")
+	}
+	a.config.SetHistoryRetention(days, maxEntries)
+	if err := a.config.Save(); err != nil {
+		return err
+	}
+	a.pruneHistory()
+	return nil
+}
+
+// pruneHistory enforces the history retention settings, along with the
+// recordings of removed transcripts
+func (a *App) pruneHistory() {
+	if a.historyService == nil {
+		return
+	}
+	days, maxEntries := a.config.GetHistoryRetention()
+	if days <= 0 && maxEntries <= 0 {
+		return
+	}
+
+	ids, err := a.historyService.PruneOlderThan(days)
+	if err != nil {
+		fmt.Printf("[History] Failed to prune old transcripts: %v\n", err)
+	}
+	capped, err := a.historyService.PruneToCount(maxEntries)
+	if err != nil {
+		fmt.Printf("[History] Failed to prune transcripts beyond %d: %v\n", maxEntries, err)
+	}
+	ids = append(ids, capped...)
+	if len(ids) == 0 {
+		return
+	}
+
+	a.deleteRecordings(ids)
+	fmt.Printf("[History] Pruned %d transcripts past the retention limit\n", len(ids))
+	a.emitEvent("history-pruned", map[string]interface{}{
+		"count": len(ids),
+	})
+}
+
+// trashPurgeInterval is how often expired trash is purged
+const trashPurgeInterval = time.Hour
+
+// purgeTrashLoop permanently removes expired trash now and every
+// trashPurgeInterval until the app shuts down
+func (a *App) purgeTrashLoop() {
+	ticker := time.NewTicker(trashPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		retention := time.Duration(a.config.GetTrashRetentionDays()) * 24 * time.Hour
+		if ids, err := a.historyService.PurgeDeleted(retention); err != nil {
+			fmt.Printf("[History] Failed to purge trash: %v\n", err)
+		} else if len(ids) > 0 {
+			a.deleteRecordings(ids)
+			fmt.Printf("[History] Purged %d expired transcripts from the trash\n", len(ids))
+		}
+
+		select {
+		case <-ticker.C:
+		case <-a.ctx.Done():
+			return
+		}
+	}
+}
+
 // RetryWithGemini re-processes a transcript with a custom instruction
 func (a *App) RetryWithGemini(id int64, instruction string) (string, error) {
 	if a.historyService == nil {
@@ -785,9 +3014,9 @@ func (a *App) RetryWithGemini(id int64, instruction string) (string, error) {
 	// Use raw text if no instruction, otherwise apply instruction
 	var newPolished string
 	if instruction == "" {
-		newPolished, err = a.geminiClient.RefineText(transcript.RawText, a.config.GetMode())
+		newPolished, err = a.refinerFor(a.config.GetMode()).RefineText(transcript.RawText, a.config.GetMode())
 	} else {
-		newPolished, err = a.geminiClient.RetryWithInstruction(transcript.PolishedText, instruction)
+		newPolished, err = a.refinerFor(transcript.Mode).RetryWithInstruction(transcript.PolishedText, instruction)
 	}
 
 	if err != nil {
@@ -802,6 +3031,95 @@ func (a *App) RetryWithGemini(id int64, instruction string) (string, error) {
 	return newPolished, nil
 }
 
+// RetranscribeWithLanguage re-runs whisper on a transcript's archived audio
+// with the given language, refines the result and stores it with the language.
+// Used to fix a wrongly detected language without re-recording.
+func (a *App) RetranscribeWithLanguage(id int64, lang string) (*history.Transcript, error) {
+	if a.historyService == nil {
+		return nil, fmt.Errorf("history service not available")
+	}
+	if lang == "" {
+		return nil, fmt.Errorf("language is required")
+	}
+	if err := whisper.ValidateLanguage(lang); err != nil {
+		return nil, err
+	}
+	if !a.modelReady {
+		return nil, fmt.Errorf("model not ready")
+	}
+
+	transcript, err := a.historyService.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	wavPath, err := a.recordingPath(id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(wavPath); err != nil {
+		return nil, fmt.Errorf("no saved audio for transcript %d", id)
+	}
+
+	rawText, segments, err := a.whisperService.TranscribeWithSegments(context.Background(), wavPath, lang)
+	if errors.Is(err, whisper.ErrModelCorrupt) {
+		a.handleCorruptModel(a.config.GetWhisperModel(), err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("transcription failed: %w", err)
+	}
+	rawText = strings.TrimSpace(rawText)
+	if rawText == "" {
+		return nil, fmt.Errorf("no speech detected in %s", lang)
+	}
+
+	polishedText, err := a.refinerFor(transcript.Mode).RefineText(rawText, transcript.Mode)
+	if err != nil {
+		return nil, fmt.Errorf("refinement failed: %w", err)
+	}
+
+	if err := a.historyService.UpdateTranscription(id, rawText, polishedText, lang); err != nil {
+		return nil, err
+	}
+	if err := a.historyService.SetSegments(id, historySegments(segments)); err != nil {
+		fmt.Printf("Failed to save segments: %v\n", err)
+	}
+	return a.historyService.GetByID(id)
+}
+
+// InjectTranscript pastes a saved transcript's polished text at the cursor of
+// the app that was focused before voxflow's window
+func (a *App) InjectTranscript(id int64) error {
+	if a.historyService == nil {
+		return fmt.Errorf("history service not available")
+	}
+	if a.injectionService == nil {
+		return fmt.Errorf("injection service not available")
+	}
+
+	transcript, err := a.historyService.GetByID(id)
+	if err != nil {
+		return fmt.Errorf("transcript %d: %w", id, err)
+	}
+
+	text := transcript.PolishedText
+	if text == "" {
+		text = transcript.RawText
+	}
+
+	// The click that asked for this focused our window; hand focus back first
+	DeactivateApp()
+	time.Sleep(time.Duration(a.config.GetReinjectDelayMs()) * time.Millisecond)
+
+	a.warnSlowTyping(text)
+	err = a.injectionService.Inject(text)
+	a.emitInjectionResult(text, err)
+	if err != nil {
+		return fmt.Errorf("failed to inject transcript: %w", err)
+	}
+	return nil
+}
+
 // CopyToClipboard copies text to clipboard
 func (a *App) CopyToClipboard(text string) error {
 	if a.injectionService == nil {
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// maxBatchConcurrency caps parallel Gemini requests in batch operations
+const maxBatchConcurrency = 8
+
+// BatchResult is the outcome of one item in a batch operation
+type BatchResult struct {
+	ID       int64  `json:"id"`
+	Polished string `json:"polished,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// runBatch calls fn for each id on a bounded pool of workers. Items not yet
+// started when ctx is cancelled are reported as cancelled. Results are in
+// the same order as ids.
+func runBatch(ctx context.Context, ids []int64, concurrency int, fn func(ctx context.Context, id int64) (string, error)) []BatchResult {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	results := make([]BatchResult, len(ids))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i].ID = ids[i]
+				if ctx.Err() != nil {
+					results[i].Error = "cancelled"
+					continue
+				}
+				polished, err := fn(ctx, ids[i])
+				if err != nil {
+					results[i].Error = err.Error()
+					continue
+				}
+				results[i].Polished = polished
+			}
+		}()
+	}
+
+	for i := range ids {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// beginBatch registers a cancellable batch; only one runs at a time
+func (a *App) beginBatch() (context.Context, error) {
+	a.batchMu.Lock()
+	defer a.batchMu.Unlock()
+	if a.batchCancel != nil {
+		return nil, fmt.Errorf("a batch operation is already running")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	a.batchCancel = cancel
+	return ctx, nil
+}
+
+// endBatch releases the running batch
+func (a *App) endBatch() {
+	a.batchMu.Lock()
+	defer a.batchMu.Unlock()
+	if a.batchCancel != nil {
+		a.batchCancel()
+		a.batchCancel = nil
+	}
+}
+
+// CancelBatch stops a running batch operation after its in-flight items finish
+func (a *App) CancelBatch() {
+	a.batchMu.Lock()
+	defer a.batchMu.Unlock()
+	if a.batchCancel != nil {
+		a.batchCancel()
+	}
+}
+
+// SetBatchConcurrency sets how many items batch operations process in parallel
+func (a *App) SetBatchConcurrency(n int) error {
+	if n < 1 || n > maxBatchConcurrency {
+		return fmt.Errorf("batch concurrency must be between 1 and %d", maxBatchConcurrency)
+	}
+	a.config.SetBatchConcurrency(n)
+	return a.config.Save()
+}
+
+// BatchReRefine re-runs Gemini refinement on the raw text of each transcript
+// using its own mode, updating the stored polished text
+func (a *App) BatchReRefine(ids []int64) ([]BatchResult, error) {
+	return a.batchRefine(ids, "")
+}
+
+// ReprocessByMode re-refines every transcript saved with fromMode using toMode
+func (a *App) ReprocessByMode(fromMode, toMode string) ([]BatchResult, error) {
+	if a.historyService == nil {
+		return nil, fmt.Errorf("history service not available")
+	}
+	transcripts, err := a.historyService.GetAll(0, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []int64
+	for _, t := range transcripts {
+		if t.Mode == fromMode {
+			ids = append(ids, t.ID)
+		}
+	}
+	return a.batchRefine(ids, toMode)
+}
+
+// batchRefine re-refines ids with mode ("" = each transcript's own mode)
+func (a *App) batchRefine(ids []int64, mode string) ([]BatchResult, error) {
+	if a.historyService == nil {
+		return nil, fmt.Errorf("history service not available")
+	}
+
+	ctx, err := a.beginBatch()
+	if err != nil {
+		return nil, err
+	}
+	defer a.endBatch()
+
+	fmt.Printf("[Batch] Re-refining %d transcripts (concurrency %d)\n", len(ids), a.config.GetBatchConcurrency())
+	results := runBatch(ctx, ids, a.config.GetBatchConcurrency(), func(ctx context.Context, id int64) (string, error) {
+		transcript, err := a.historyService.GetByID(id)
+		if err != nil {
+			return "", err
+		}
+		refineMode := mode
+		if refineMode == "" {
+			refineMode = transcript.Mode
+		}
+
+		polished, err := a.refinerFor(refineMode).RefineText(transcript.RawText, refineMode)
+		if err != nil {
+			return "", err
+		}
+		if err := a.historyService.UpdatePolishedText(id, polished); err != nil {
+			return "", err
+		}
+		if refineMode != transcript.Mode {
+			if err := a.historyService.UpdateMode(id, refineMode); err != nil {
+				return "", err
+			}
+		}
+		return polished, nil
+	})
+
+	return results, nil
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// configExport is the file format of an exported configuration. The API key
+// is only included when asked for.
+type configExport struct {
+	AppConfigView
+	GeminiAPIKey string `json:"gemini_api_key,omitempty"`
+}
+
+// ExportConfig returns the configuration as JSON, with the Gemini API key
+// only if includeAPIKey is set
+func (a *App) ExportConfig(includeAPIKey bool) (string, error) {
+	if a.config == nil {
+		return "", fmt.Errorf("config not loaded")
+	}
+	export := configExport{AppConfigView: *a.buildConfigView()}
+	if includeAPIKey {
+		export.GeminiAPIKey = a.config.GetGeminiAPIKey()
+	}
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode config: %w", err)
+	}
+	return string(data), nil
+}
+
+// SaveConfigExport asks where to save the configuration and writes it there.
+// Returns the chosen path, or "" if the dialog was cancelled.
+func (a *App) SaveConfigExport(includeAPIKey bool) (string, error) {
+	path, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:           "Export Settings",
+		DefaultFilename: "voxflow-settings.json",
+		Filters: []runtime.FileFilter{
+			{DisplayName: "JSON (*.json)", Pattern: "*.json"},
+		},
+	})
+	if err != nil || path == "" {
+		return "", err
+	}
+
+	data, err := a.ExportConfig(includeAPIKey)
+	if err != nil {
+		return "", err
+	}
+	// May hold the API key, so keep it private like config.json
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		return "", fmt.Errorf("failed to write settings: %w", err)
+	}
+	fmt.Printf("[Config] Exported settings to %s\n", path)
+	return path, nil
+}
+
+// PickConfigFile opens a file picker for choosing settings to import
+func (a *App) PickConfigFile() (string, error) {
+	return runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title: "Import Settings",
+		Filters: []runtime.FileFilter{
+			{DisplayName: "JSON (*.json)", Pattern: "*.json"},
+		},
+	})
+}
+
+// ImportConfig applies the settings exported to path. Values are checked
+// before anything changes; settings missing from the file keep their current
+// value. If applying fails part way, the previous settings are restored.
+func (a *App) ImportConfig(path string) error {
+	if a.config == nil {
+		return fmt.Errorf("config not loaded")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read settings: %w", err)
+	}
+
+	previous := a.buildConfigView()
+	imported := configExport{AppConfigView: *previous}
+	if err := json.Unmarshal(data, &imported); err != nil {
+		return fmt.Errorf("invalid settings file: %w", err)
+	}
+	// Session-only, never taken from a file
+	imported.PrivacyMode = previous.PrivacyMode
+
+	if err := a.ApplyConfig(imported.AppConfigView); err != nil {
+		return fmt.Errorf("failed to import settings: %w", err)
+	}
+	if imported.GeminiAPIKey != "" {
+		if err := a.SetAPIKey(imported.GeminiAPIKey); err != nil {
+			return fmt.Errorf("failed to import API key: %w", err)
+		}
+	}
+	if err := a.reloadHotkeys(); err != nil {
+		return fmt.Errorf("settings imported, but hotkeys failed to register: %w", err)
+	}
+
+	fmt.Printf("[Config] Imported settings from %s\n", path)
+	return nil
+}
@@ -0,0 +1,800 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"voxflow/internal/config"
+	"voxflow/internal/gemini"
+	"voxflow/internal/injection"
+	"voxflow/internal/textproc"
+	"voxflow/internal/whisper"
+)
+
+// ConfigViewVersion is bumped whenever AppConfigView changes shape
+const ConfigViewVersion = 1
+
+// AppConfigView is the user-facing configuration exposed to the frontend.
+// It never contains the raw API key.
+type AppConfigView struct {
+	Version                  int                 `json:"version"`
+	Hotkey                   string              `json:"hotkey"` // Legacy, same as HandsFreeHotkey
+	HandsFreeHotkey          string              `json:"hands_free_hotkey"`
+	PushToTalkHotkey         string              `json:"push_to_talk_hotkey"`
+	WhisperModel             string              `json:"whisper_model"`
+	Mode                     string              `json:"mode"`
+	APIKeySet                bool                `json:"api_key_set"`
+	LineEnding               string              `json:"line_ending"`
+	DateTokenStage           string              `json:"date_token_stage"`
+	DateFormat               string              `json:"date_format"`
+	TimeFormat               string              `json:"time_format"`
+	MaxOutputChars           map[string]int      `json:"max_output_chars"`
+	LocalServerEnabled       bool                `json:"local_server_enabled"`
+	LocalServerPort          int                 `json:"local_server_port"`
+	ChunkSeconds             int                 `json:"chunk_seconds"`
+	ChunkOverlapSeconds      int                 `json:"chunk_overlap_seconds"`
+	PrivacyMode              bool                `json:"privacy_mode"` // Session only, not persisted
+	PrivacyClearClipboard    bool                `json:"privacy_clear_clipboard"`
+	CustomModes              []config.CustomMode `json:"custom_modes"`
+	Appearance               string              `json:"appearance"`
+	DownloadStallSeconds     int                 `json:"download_stall_seconds"`
+	ConfirmBeforeInject      bool                `json:"confirm_before_inject"`
+	BeamSize                 int                 `json:"beam_size"`
+	WhisperTemperature       float64             `json:"whisper_temperature"`
+	QuitWhileBusy            string              `json:"quit_while_busy"`
+	CaseStyle                string              `json:"case_style"`
+	ToneTagging              bool                `json:"tone_tagging"`
+	CodeSpokenSymbols        bool                `json:"code_spoken_symbols"`
+	PasteRetries             int                 `json:"paste_retries"`
+	QuickNoteHotkey          string              `json:"quick_note_hotkey"`
+	KeepPillDuringProcessing bool                `json:"keep_pill_during_processing"`
+	InputDevice              string              `json:"input_device"`
+	SilenceTimeoutSeconds    float64             `json:"silence_timeout_seconds"`
+	SilenceThresholdDB       float64             `json:"silence_threshold_db"`
+	BatchConcurrency         int                 `json:"batch_concurrency"`
+	MaxRecordingSeconds      int                 `json:"max_recording_seconds"`
+	ConfirmDelete            bool                `json:"confirm_delete"`
+	TrashRetentionDays       int                 `json:"trash_retention_days"`
+	PreRollMs                int                 `json:"pre_roll_ms"`
+	InputGain                float64             `json:"input_gain"`
+	KeepRecordings           bool                `json:"keep_recordings"`
+	RecordingsDir            string              `json:"recordings_dir"`
+	RecordingRetentionCount  int                 `json:"recording_retention_count"`
+	RecordingRetentionDays   int                 `json:"recording_retention_days"`
+	Language                 string              `json:"language"`
+	Translate                bool                `json:"translate"`
+	WhisperThreads           int                 `json:"whisper_threads"`
+	ModelsDir                string              `json:"models_dir"`
+	VocabularyPrompt         string              `json:"vocabulary_prompt"`
+	GeminiModel              string              `json:"gemini_model"`
+	GeminiMaxAttempts        int                 `json:"gemini_max_attempts"`
+	GeminiRetrySeconds       int                 `json:"gemini_retry_seconds"`
+	RefinementBackend        string              `json:"refinement_backend"`
+	OpenAIBaseURL            string              `json:"openai_base_url"`
+	OpenAIModel              string              `json:"openai_model"`
+	OpenAIAPIKeySet          bool                `json:"openai_api_key_set"`
+	RefinementEnabled        bool                `json:"refinement_enabled"`
+	Replacements             map[string]string   `json:"replacements"`
+	Proxy                    string              `json:"proxy"`
+	GeminiTimeoutSeconds     int                 `json:"gemini_timeout_seconds"`
+	DoubleTapWindowMs        int                 `json:"double_tap_window_ms"`
+	PTTMinHoldMs             int                 `json:"ptt_min_hold_ms"`
+	CycleModeHotkey          string              `json:"cycle_mode_hotkey"`
+	AbortHotkey              string              `json:"abort_hotkey"`
+	HistoryRetentionDays     int                 `json:"history_retention_days"`
+	HistoryMaxEntries        int                 `json:"history_max_entries"`
+	EncryptHistory           bool                `json:"encrypt_history"`
+	ReinjectDelayMs          int                 `json:"reinject_delay_ms"`
+	InjectionMode            string              `json:"injection_mode"`
+	ClipboardSettleMs        int                 `json:"clipboard_settle_ms"`
+	PostPasteMs              int                 `json:"post_paste_ms"`
+	ClipboardRestoreMs       int                 `json:"clipboard_restore_ms"`
+	AdaptivePaste            bool                `json:"adaptive_paste"`
+	PillOpacity              float64             `json:"pill_opacity"`
+	KeychainAPIKey           bool                `json:"keychain_api_key"`
+	LaunchAtLogin            bool                `json:"launch_at_login"`
+	PlaySounds               bool                `json:"play_sounds"`
+	SoundVolume              float64             `json:"sound_volume"`
+	NotifyOnComplete         bool                `json:"notify_on_complete"`
+	ShowTrayIcon             bool                `json:"show_tray_icon"`
+	BlankAudioRetries        int                 `json:"blank_audio_retries"`
+	BlankAudioRetryDelayMs   int                 `json:"blank_audio_retry_delay_ms"`
+}
+
+// buildConfigView snapshots the current configuration
+func (a *App) buildConfigView() *AppConfigView {
+	dateFormat, timeFormat := a.config.GetDateTimeFormats()
+	serverEnabled, serverPort := a.config.GetLocalServer()
+	chunkSeconds, overlapSeconds := a.config.GetChunking()
+	beamSize, temperature := a.config.GetWhisperDecoding()
+	silenceTimeout, silenceThreshold := a.config.GetSilenceStop()
+	retentionCount, retentionDays := a.config.GetRecordingRetention()
+	historyRetentionDays, historyMaxEntries := a.config.GetHistoryRetention()
+	playSounds, soundVolume := a.config.GetSoundCues()
+	settleMs, postPasteMs, restoreMs, adaptivePaste := a.config.GetPasteDelays()
+	geminiAttempts, geminiRetrySeconds := a.config.GetGeminiRetry()
+	blankAudioRetries, blankAudioRetryDelayMs := a.config.GetBlankAudioRetry()
+	openaiBaseURL, openaiModel, openaiAPIKey := a.config.GetOpenAIEndpoint()
+
+	maxOutput := map[string]int{}
+	modes := append([]string{}, gemini.BuiltinModes...)
+	for _, m := range a.config.GetCustomModes() {
+		modes = append(modes, m.Name)
+	}
+	for _, mode := range modes {
+		if limit := a.config.GetMaxOutputChars(mode); limit > 0 {
+			maxOutput[mode] = limit
+		}
+	}
+
+	return &AppConfigView{
+		Version:                  ConfigViewVersion,
+		Hotkey:                   a.config.GetHotkey(),
+		HandsFreeHotkey:          a.config.GetHandsFreeHotkey(),
+		PushToTalkHotkey:         a.config.GetPushToTalkHotkey(),
+		WhisperModel:             a.config.GetWhisperModel(),
+		Mode:                     a.config.GetMode(),
+		APIKeySet:                a.config.GetGeminiAPIKey() != "",
+		LineEnding:               a.config.GetLineEnding(),
+		DateTokenStage:           a.config.GetDateTokenStage(),
+		DateFormat:               dateFormat,
+		TimeFormat:               timeFormat,
+		MaxOutputChars:           maxOutput,
+		LocalServerEnabled:       serverEnabled,
+		LocalServerPort:          serverPort,
+		ChunkSeconds:             chunkSeconds,
+		ChunkOverlapSeconds:      overlapSeconds,
+		PrivacyMode:              a.privacyMode.Load(),
+		PrivacyClearClipboard:    a.config.GetPrivacyClearClipboard(),
+		CustomModes:              a.config.GetCustomModes(),
+		Appearance:               a.config.GetAppearance(),
+		DownloadStallSeconds:     a.config.GetDownloadStallSeconds(),
+		ConfirmBeforeInject:      a.config.GetConfirmBeforeInject(),
+		BeamSize:                 beamSize,
+		WhisperTemperature:       temperature,
+		QuitWhileBusy:            a.config.GetQuitWhileBusy(),
+		CaseStyle:                a.config.GetCaseStyle(),
+		ToneTagging:              a.config.GetToneTagging(),
+		CodeSpokenSymbols:        a.config.GetCodeSpokenSymbols(),
+		PasteRetries:             a.config.GetPasteRetries(),
+		QuickNoteHotkey:          a.config.GetQuickNoteHotkey(),
+		KeepPillDuringProcessing: a.config.GetKeepPillDuringProcessing(),
+		InputDevice:              a.config.GetInputDevice(),
+		SilenceTimeoutSeconds:    silenceTimeout,
+		SilenceThresholdDB:       silenceThreshold,
+		BatchConcurrency:         a.config.GetBatchConcurrency(),
+		MaxRecordingSeconds:      a.config.GetMaxRecordingSecs(),
+		ConfirmDelete:            a.config.GetConfirmDelete(),
+		TrashRetentionDays:       a.config.GetTrashRetentionDays(),
+		PreRollMs:                a.config.GetPreRollMs(),
+		InputGain:                a.config.GetInputGain(),
+		KeepRecordings:           a.config.GetKeepRecordings(),
+		RecordingsDir:            a.config.GetRecordingsDir(),
+		RecordingRetentionCount:  retentionCount,
+		RecordingRetentionDays:   retentionDays,
+		Language:                 a.config.GetLanguage(),
+		Translate:                a.config.GetTranslate(),
+		WhisperThreads:           a.config.GetWhisperThreads(),
+		ModelsDir:                a.config.GetModelsDir(),
+		VocabularyPrompt:         a.config.GetVocabularyPrompt(),
+		GeminiModel:              a.config.GetGeminiModel(),
+		GeminiMaxAttempts:        geminiAttempts,
+		GeminiRetrySeconds:       geminiRetrySeconds,
+		RefinementBackend:        a.config.GetRefinementBackend(),
+		OpenAIBaseURL:            openaiBaseURL,
+		OpenAIModel:              openaiModel,
+		OpenAIAPIKeySet:          openaiAPIKey != "",
+		RefinementEnabled:        a.config.GetRefinementEnabled(),
+		Replacements:             a.config.GetReplacements(),
+		Proxy:                    a.config.GetProxy(),
+		GeminiTimeoutSeconds:     a.config.GetGeminiTimeoutSeconds(),
+		DoubleTapWindowMs:        a.config.GetDoubleTapWindowMs(),
+		PTTMinHoldMs:             a.config.GetPTTMinHoldMs(),
+		CycleModeHotkey:          a.config.GetCycleModeHotkey(),
+		AbortHotkey:              a.config.GetEmergencyStopHotkey(),
+		HistoryRetentionDays:     historyRetentionDays,
+		HistoryMaxEntries:        historyMaxEntries,
+		EncryptHistory:           a.config.GetEncryptHistory(),
+		ReinjectDelayMs:          a.config.GetReinjectDelayMs(),
+		InjectionMode:            a.config.GetInjectionMode(),
+		ClipboardSettleMs:        settleMs,
+		PostPasteMs:              postPasteMs,
+		ClipboardRestoreMs:       restoreMs,
+		AdaptivePaste:            adaptivePaste,
+		PillOpacity:              a.config.GetPillOpacity(),
+		KeychainAPIKey:           a.config.GetKeychainAPIKey(),
+		LaunchAtLogin:            a.GetLaunchAtLogin(),
+		PlaySounds:               playSounds,
+		SoundVolume:              soundVolume,
+		NotifyOnComplete:         a.config.GetNotifyOnComplete(),
+		ShowTrayIcon:             a.config.GetShowTrayIcon(),
+		BlankAudioRetries:        blankAudioRetries,
+		BlankAudioRetryDelayMs:   blankAudioRetryDelayMs,
+	}
+}
+
+// ApplyConfig applies a full configuration view in one call. The whole view is
+// validated before anything changes, then only the settings that differ from
+// the current ones go through their regular setters, so side effects (hotkey
+// reload, model loading, etc.) are the same as when changed individually. If
+// a setter still fails, the settings applied so far are restored.
+func (a *App) ApplyConfig(view AppConfigView) error {
+	current := a.buildConfigView()
+
+	// A view without these (e.g. an older settings file) keeps the current ones
+	if view.CustomModes == nil {
+		view.CustomModes = current.CustomModes
+	}
+	if view.MaxOutputChars == nil {
+		view.MaxOutputChars = current.MaxOutputChars
+	}
+	if view.Replacements == nil {
+		view.Replacements = current.Replacements
+	}
+
+	if err := a.validateConfigView(&view, current); err != nil {
+		return err
+	}
+	if err := a.applyConfigView(&view, current); err != nil {
+		if rollbackErr := a.applyConfigView(current, a.buildConfigView()); rollbackErr != nil {
+			fmt.Printf("[Config] Failed to restore settings after apply error: %v\n", rollbackErr)
+		}
+		return err
+	}
+	return a.config.Save()
+}
+
+// validateConfigView checks the values of view that differ from current, so
+// ApplyConfig can reject a bad view before any setting changes
+func (a *App) validateConfigView(view, current *AppConfigView) error {
+	if view.Version > ConfigViewVersion {
+		return fmt.Errorf("unsupported config version %d (expected %d or lower)", view.Version, ConfigViewVersion)
+	}
+	if view.HandsFreeHotkey != current.HandsFreeHotkey {
+		if err := a.ValidateHotkey(view.HandsFreeHotkey, true); err != nil {
+			return fmt.Errorf("hands-free hotkey: %w", err)
+		}
+	}
+	if view.PushToTalkHotkey != current.PushToTalkHotkey {
+		if err := a.ValidateHotkey(view.PushToTalkHotkey, false); err != nil {
+			return fmt.Errorf("push-to-talk hotkey: %w", err)
+		}
+	}
+	if view.QuickNoteHotkey != current.QuickNoteHotkey && view.QuickNoteHotkey != "" {
+		if err := a.ValidateHotkey(view.QuickNoteHotkey, true); err != nil {
+			return fmt.Errorf("quick note hotkey: %w", err)
+		}
+	}
+	if view.CycleModeHotkey != current.CycleModeHotkey && view.CycleModeHotkey != "" {
+		if err := a.ValidateHotkey(view.CycleModeHotkey, false); err != nil {
+			return fmt.Errorf("cycle mode hotkey: %w", err)
+		}
+	}
+	if view.AbortHotkey != current.AbortHotkey {
+		if view.AbortHotkey == "" {
+			return fmt.Errorf("abort hotkey: the abort hotkey can't be disabled")
+		}
+		if err := a.ValidateHotkey(view.AbortHotkey, false); err != nil {
+			return fmt.Errorf("abort hotkey: %w", err)
+		}
+	}
+	if view.WhisperModel != current.WhisperModel {
+		if _, ok := whisper.ModelDescriptions[view.WhisperModel]; !ok {
+			return fmt.Errorf("unknown whisper model: %s", view.WhisperModel)
+		}
+	}
+	if err := validateCustomModes(view.CustomModes); err != nil {
+		return fmt.Errorf("custom modes: %w", err)
+	}
+
+	// Modes may refer to the view's custom modes rather than the current ones
+	known := map[string]bool{}
+	for _, mode := range gemini.BuiltinModes {
+		known[mode] = true
+	}
+	for _, m := range view.CustomModes {
+		known[m.Name] = true
+	}
+	if !known[view.Mode] {
+		return fmt.Errorf("unknown mode: %s", view.Mode)
+	}
+	for mode, limit := range view.MaxOutputChars {
+		if !known[mode] {
+			return fmt.Errorf("max output chars set for unknown mode: %s", mode)
+		}
+		if limit < 0 {
+			return fmt.Errorf("max output chars cannot be negative")
+		}
+	}
+
+	if view.Language != current.Language {
+		if err := whisper.ValidateLanguage(view.Language); err != nil {
+			return err
+		}
+	}
+	if view.CaseStyle != current.CaseStyle {
+		if err := textproc.ValidateCaseStyle(view.CaseStyle); err != nil {
+			return fmt.Errorf("case style: %w", err)
+		}
+	}
+	if proxy := strings.TrimSpace(view.Proxy); view.Proxy != current.Proxy && proxy != "" {
+		if _, err := gemini.ParseProxyURL(proxy); err != nil {
+			return fmt.Errorf("proxy: %w", err)
+		}
+	}
+	if !maps.Equal(view.Replacements, current.Replacements) {
+		for from := range view.Replacements {
+			if err := textproc.ValidateReplacement(from); err != nil {
+				return fmt.Errorf("replacements: %w", err)
+			}
+		}
+	}
+
+	openAIBaseURL := strings.TrimSpace(view.OpenAIBaseURL)
+	openAIChanged := view.OpenAIBaseURL != current.OpenAIBaseURL || view.OpenAIModel != current.OpenAIModel
+	checks := []struct {
+		changed bool
+		valid   bool
+		err     string
+	}{
+		{view.LineEnding != current.LineEnding,
+			view.LineEnding == injection.LineEndingLF || view.LineEnding == injection.LineEndingCRLF,
+			"unknown line ending: " + view.LineEnding},
+		{view.DateTokenStage != current.DateTokenStage,
+			slices.Contains([]string{"before", "after", "off"}, view.DateTokenStage),
+			"unknown date token stage: " + view.DateTokenStage},
+		{view.ChunkSeconds != current.ChunkSeconds || view.ChunkOverlapSeconds != current.ChunkOverlapSeconds,
+			view.ChunkSeconds >= 0 && view.ChunkOverlapSeconds >= 0 && (view.ChunkSeconds == 0 || view.ChunkOverlapSeconds < view.ChunkSeconds),
+			"chunking: chunk length and overlap must not be negative, and the overlap must be shorter than the chunk"},
+		{view.Appearance != current.Appearance,
+			slices.Contains([]string{"system", "light", "dark"}, view.Appearance),
+			"unknown appearance: " + view.Appearance},
+		{view.DownloadStallSeconds != current.DownloadStallSeconds,
+			view.DownloadStallSeconds == 0 || view.DownloadStallSeconds >= 5,
+			"download stall timeout must be at least 5 seconds, or 0 to disable it"},
+		{view.BeamSize != current.BeamSize,
+			view.BeamSize >= 1 && view.BeamSize <= whisper.MaxBeamSize,
+			fmt.Sprintf("beam size must be between 1 and %d", whisper.MaxBeamSize)},
+		{view.WhisperTemperature != current.WhisperTemperature,
+			view.WhisperTemperature >= 0 && view.WhisperTemperature <= 1,
+			"whisper temperature must be between 0 and 1"},
+		{view.QuitWhileBusy != current.QuitWhileBusy,
+			slices.Contains([]string{"ask", "finish", "discard"}, view.QuitWhileBusy),
+			"unknown quit policy: " + view.QuitWhileBusy},
+		{view.PasteRetries != current.PasteRetries,
+			view.PasteRetries >= 0 && view.PasteRetries <= 5,
+			"paste retries must be between 0 and 5"},
+		{view.SilenceTimeoutSeconds != current.SilenceTimeoutSeconds,
+			view.SilenceTimeoutSeconds >= 0 && view.SilenceTimeoutSeconds <= 60,
+			"silence timeout must be between 0 and 60 seconds"},
+		{view.SilenceThresholdDB != current.SilenceThresholdDB,
+			view.SilenceThresholdDB >= -90 && view.SilenceThresholdDB < 0,
+			"silence threshold must be between -90 and 0 dB"},
+		{view.BatchConcurrency != current.BatchConcurrency,
+			view.BatchConcurrency >= 1 && view.BatchConcurrency <= maxBatchConcurrency,
+			fmt.Sprintf("batch concurrency must be between 1 and %d", maxBatchConcurrency)},
+		{view.MaxRecordingSeconds != current.MaxRecordingSeconds,
+			view.MaxRecordingSeconds >= 0 && view.MaxRecordingSeconds <= 3600,
+			"max recording duration must be between 0 and 3600 seconds"},
+		{view.TrashRetentionDays != current.TrashRetentionDays,
+			view.TrashRetentionDays >= 1 && view.TrashRetentionDays <= 365,
+			"trash retention must be between 1 and 365 days"},
+		{view.PreRollMs != current.PreRollMs,
+			view.PreRollMs >= 0 && view.PreRollMs <= 2000,
+			"pre-roll must be between 0 and 2000 ms"},
+		{view.InputGain != current.InputGain,
+			view.InputGain >= 0.1 && view.InputGain <= 10,
+			"input gain must be between 0.1 and 10"},
+		{view.RecordingsDir != current.RecordingsDir,
+			view.RecordingsDir == "" || filepath.IsAbs(view.RecordingsDir),
+			"recordings directory must be an absolute path"},
+		{view.RecordingRetentionCount != current.RecordingRetentionCount || view.RecordingRetentionDays != current.RecordingRetentionDays,
+			view.RecordingRetentionCount >= 0 && view.RecordingRetentionDays >= 0,
+			"recording retention limits must not be negative"},
+		{view.WhisperThreads != current.WhisperThreads,
+			view.WhisperThreads >= 1,
+			"whisper threads must be at least 1"},
+		{view.GeminiModel != current.GeminiModel,
+			strings.TrimSpace(view.GeminiModel) != "",
+			"gemini model name must not be empty"},
+		{view.GeminiMaxAttempts != current.GeminiMaxAttempts,
+			view.GeminiMaxAttempts >= 1 && view.GeminiMaxAttempts <= 10,
+			"gemini attempts must be between 1 and 10"},
+		{view.GeminiRetrySeconds != current.GeminiRetrySeconds,
+			view.GeminiRetrySeconds >= 1 && view.GeminiRetrySeconds <= 120,
+			"gemini retry time must be between 1 and 120 seconds"},
+		{view.RefinementBackend != current.RefinementBackend,
+			view.RefinementBackend == BackendGemini || view.RefinementBackend == BackendOpenAI,
+			"unknown refinement backend: " + view.RefinementBackend},
+		{openAIChanged,
+			openAIBaseURL == "" || strings.HasPrefix(openAIBaseURL, "http://") || strings.HasPrefix(openAIBaseURL, "https://"),
+			"openai base URL must start with http:// or https://"},
+		{openAIChanged,
+			strings.TrimSpace(view.OpenAIModel) != "",
+			"openai model name must not be empty"},
+		{view.GeminiTimeoutSeconds != current.GeminiTimeoutSeconds,
+			view.GeminiTimeoutSeconds >= 5 && view.GeminiTimeoutSeconds <= 300,
+			"gemini timeout must be between 5 and 300 seconds"},
+		{view.DoubleTapWindowMs != current.DoubleTapWindowMs,
+			view.DoubleTapWindowMs >= 100 && view.DoubleTapWindowMs <= 1000,
+			"double tap window must be between 100 and 1000 ms"},
+		{view.PTTMinHoldMs != current.PTTMinHoldMs,
+			view.PTTMinHoldMs >= 0 && view.PTTMinHoldMs <= 2000,
+			"ptt minimum hold must be between 0 and 2000 ms"},
+		{view.HistoryRetentionDays != current.HistoryRetentionDays || view.HistoryMaxEntries != current.HistoryMaxEntries,
+			view.HistoryRetentionDays >= 0 && view.HistoryMaxEntries >= 0,
+			"history retention limits must not be negative"},
+		{view.ReinjectDelayMs != current.ReinjectDelayMs,
+			view.ReinjectDelayMs >= 0 && view.ReinjectDelayMs <= 2000,
+			"re-inject delay must be between 0 and 2000 ms"},
+		{view.InjectionMode != current.InjectionMode,
+			view.InjectionMode == injection.ModePaste || view.InjectionMode == injection.ModeType,
+			"unknown injection mode: " + view.InjectionMode},
+		{view.ClipboardSettleMs != current.ClipboardSettleMs || view.PostPasteMs != current.PostPasteMs || view.ClipboardRestoreMs != current.ClipboardRestoreMs,
+			!slices.ContainsFunc([]int{view.ClipboardSettleMs, view.PostPasteMs, view.ClipboardRestoreMs}, func(ms int) bool { return ms < 0 || ms > 2000 }),
+			"paste delays must be between 0 and 2000 ms"},
+		{view.PillOpacity != current.PillOpacity,
+			view.PillOpacity >= minPillOpacity && view.PillOpacity <= 1,
+			fmt.Sprintf("pill opacity must be between %.1f and 1", minPillOpacity)},
+		{view.SoundVolume != current.SoundVolume,
+			view.SoundVolume > 0 && view.SoundVolume <= 1,
+			"sound volume must be above 0 and at most 1"},
+		{view.BlankAudioRetries != current.BlankAudioRetries,
+			view.BlankAudioRetries >= 0 && view.BlankAudioRetries <= 5,
+			"blank audio retries must be between 0 and 5"},
+		{view.BlankAudioRetryDelayMs != current.BlankAudioRetryDelayMs,
+			view.BlankAudioRetryDelayMs >= 0 && view.BlankAudioRetryDelayMs <= 5000,
+			"blank audio retry delay must be between 0 and 5000 ms"},
+	}
+	for _, c := range checks {
+		if c.changed && !c.valid {
+			return errors.New(c.err)
+		}
+	}
+	return nil
+}
+
+// applyConfigView calls the setter of every setting in view that differs from
+// current, stopping at the first error
+func (a *App) applyConfigView(view, current *AppConfigView) error {
+	if view.HandsFreeHotkey != current.HandsFreeHotkey {
+		if err := a.SetHandsFreeHotkey(view.HandsFreeHotkey); err != nil {
+			return fmt.Errorf("hands-free hotkey: %w", err)
+		}
+	}
+	if view.PushToTalkHotkey != current.PushToTalkHotkey {
+		if err := a.SetPushToTalkHotkey(view.PushToTalkHotkey); err != nil {
+			return fmt.Errorf("push-to-talk hotkey: %w", err)
+		}
+	}
+	if view.WhisperModel != current.WhisperModel {
+		if err := a.SetWhisperModel(view.WhisperModel); err != nil {
+			return fmt.Errorf("whisper model: %w", err)
+		}
+	}
+	if !slices.Equal(view.CustomModes, current.CustomModes) {
+		if err := a.SetCustomModes(view.CustomModes); err != nil {
+			return fmt.Errorf("custom modes: %w", err)
+		}
+	}
+	if view.Mode != current.Mode {
+		if err := a.SetMode(view.Mode); err != nil {
+			return fmt.Errorf("mode: %w", err)
+		}
+	}
+	if view.LineEnding != current.LineEnding {
+		if err := a.SetLineEnding(view.LineEnding); err != nil {
+			return fmt.Errorf("line ending: %w", err)
+		}
+	}
+	if view.DateTokenStage != current.DateTokenStage {
+		if err := a.SetDateTokenStage(view.DateTokenStage); err != nil {
+			return fmt.Errorf("date token stage: %w", err)
+		}
+	}
+	if view.DateFormat != current.DateFormat || view.TimeFormat != current.TimeFormat {
+		if err := a.SetDateTimeFormats(view.DateFormat, view.TimeFormat); err != nil {
+			return fmt.Errorf("date/time formats: %w", err)
+		}
+	}
+	if !maps.Equal(view.MaxOutputChars, current.MaxOutputChars) {
+		for mode := range current.MaxOutputChars {
+			if _, ok := view.MaxOutputChars[mode]; !ok {
+				a.config.SetMaxOutputChars(mode, 0)
+			}
+		}
+		for mode, limit := range view.MaxOutputChars {
+			if limit == current.MaxOutputChars[mode] {
+				continue
+			}
+			if err := a.SetMaxOutputChars(mode, limit); err != nil {
+				return fmt.Errorf("max output chars: %w", err)
+			}
+		}
+	}
+	if view.ChunkSeconds != current.ChunkSeconds || view.ChunkOverlapSeconds != current.ChunkOverlapSeconds {
+		if err := a.SetChunking(view.ChunkSeconds, view.ChunkOverlapSeconds); err != nil {
+			return fmt.Errorf("chunking: %w", err)
+		}
+	}
+	if view.LocalServerEnabled != current.LocalServerEnabled {
+		if err := a.SetLocalServerEnabled(view.LocalServerEnabled); err != nil {
+			return fmt.Errorf("local server: %w", err)
+		}
+	}
+	if view.PrivacyMode != current.PrivacyMode {
+		a.SetPrivacyMode(view.PrivacyMode)
+	}
+	if view.PrivacyClearClipboard != current.PrivacyClearClipboard {
+		if err := a.SetPrivacyClearClipboard(view.PrivacyClearClipboard); err != nil {
+			return err
+		}
+	}
+	if view.Appearance != current.Appearance {
+		if err := a.SetAppearance(view.Appearance); err != nil {
+			return fmt.Errorf("appearance: %w", err)
+		}
+	}
+	if view.DownloadStallSeconds != current.DownloadStallSeconds {
+		if err := a.SetDownloadStallTimeout(view.DownloadStallSeconds); err != nil {
+			return fmt.Errorf("download stall timeout: %w", err)
+		}
+	}
+	if view.ConfirmBeforeInject != current.ConfirmBeforeInject {
+		if err := a.SetConfirmBeforeInject(view.ConfirmBeforeInject); err != nil {
+			return err
+		}
+	}
+	if view.BeamSize != current.BeamSize {
+		if err := a.SetBeamSize(view.BeamSize); err != nil {
+			return fmt.Errorf("beam size: %w", err)
+		}
+	}
+	if view.WhisperTemperature != current.WhisperTemperature {
+		if err := a.SetWhisperTemperature(view.WhisperTemperature); err != nil {
+			return fmt.Errorf("whisper temperature: %w", err)
+		}
+	}
+	if view.QuitWhileBusy != current.QuitWhileBusy {
+		if err := a.SetQuitWhileBusy(view.QuitWhileBusy); err != nil {
+			return fmt.Errorf("quit policy: %w", err)
+		}
+	}
+	if view.CaseStyle != current.CaseStyle {
+		if err := a.SetCaseStyle(view.CaseStyle); err != nil {
+			return fmt.Errorf("case style: %w", err)
+		}
+	}
+	if view.ToneTagging != current.ToneTagging {
+		if err := a.SetToneTagging(view.ToneTagging); err != nil {
+			return err
+		}
+	}
+	if view.CodeSpokenSymbols != current.CodeSpokenSymbols {
+		if err := a.SetCodeSpokenSymbols(view.CodeSpokenSymbols); err != nil {
+			return err
+		}
+	}
+	if view.PasteRetries != current.PasteRetries {
+		if err := a.SetPasteRetries(view.PasteRetries); err != nil {
+			return fmt.Errorf("paste retries: %w", err)
+		}
+	}
+	if view.QuickNoteHotkey != current.QuickNoteHotkey {
+		if err := a.SetQuickNoteHotkey(view.QuickNoteHotkey); err != nil {
+			return fmt.Errorf("quick note hotkey: %w", err)
+		}
+	}
+	if view.KeepPillDuringProcessing != current.KeepPillDuringProcessing {
+		if err := a.SetKeepPillDuringProcessing(view.KeepPillDuringProcessing); err != nil {
+			return err
+		}
+	}
+	if view.InputDevice != current.InputDevice {
+		if err := a.SetInputDevice(view.InputDevice); err != nil {
+			return fmt.Errorf("input device: %w", err)
+		}
+	}
+	if view.SilenceTimeoutSeconds != current.SilenceTimeoutSeconds || view.SilenceThresholdDB != current.SilenceThresholdDB {
+		if err := a.SetSilenceStop(view.SilenceTimeoutSeconds, view.SilenceThresholdDB); err != nil {
+			return fmt.Errorf("silence auto-stop: %w", err)
+		}
+	}
+	if view.BatchConcurrency != current.BatchConcurrency {
+		if err := a.SetBatchConcurrency(view.BatchConcurrency); err != nil {
+			return err
+		}
+	}
+	if view.MaxRecordingSeconds != current.MaxRecordingSeconds {
+		if err := a.SetMaxRecordingDuration(view.MaxRecordingSeconds); err != nil {
+			return fmt.Errorf("max recording duration: %w", err)
+		}
+	}
+	if view.ConfirmDelete != current.ConfirmDelete {
+		if err := a.SetConfirmDelete(view.ConfirmDelete); err != nil {
+			return err
+		}
+	}
+	if view.TrashRetentionDays != current.TrashRetentionDays {
+		if err := a.SetTrashRetentionDays(view.TrashRetentionDays); err != nil {
+			return fmt.Errorf("trash retention: %w", err)
+		}
+	}
+	if view.PreRollMs != current.PreRollMs {
+		if err := a.SetPreRoll(view.PreRollMs); err != nil {
+			return fmt.Errorf("pre-roll: %w", err)
+		}
+	}
+	if view.InputGain != current.InputGain {
+		if err := a.SetInputGain(view.InputGain); err != nil {
+			return fmt.Errorf("input gain: %w", err)
+		}
+	}
+	if view.KeepRecordings != current.KeepRecordings {
+		if err := a.SetKeepRecordings(view.KeepRecordings); err != nil {
+			return err
+		}
+	}
+	if view.RecordingsDir != current.RecordingsDir {
+		if err := a.SetRecordingsDir(view.RecordingsDir); err != nil {
+			return fmt.Errorf("recordings directory: %w", err)
+		}
+	}
+	if view.RecordingRetentionCount != current.RecordingRetentionCount || view.RecordingRetentionDays != current.RecordingRetentionDays {
+		if err := a.SetRecordingRetention(view.RecordingRetentionCount, view.RecordingRetentionDays); err != nil {
+			return fmt.Errorf("recording retention: %w", err)
+		}
+	}
+	if view.Language != current.Language {
+		if err := a.SetLanguage(view.Language); err != nil {
+			return err
+		}
+	}
+	if view.Translate != current.Translate {
+		if err := a.SetTranslate(view.Translate); err != nil {
+			return err
+		}
+	}
+	if view.WhisperThreads != current.WhisperThreads {
+		if err := a.SetWhisperThreads(view.WhisperThreads); err != nil {
+			return fmt.Errorf("whisper threads: %w", err)
+		}
+	}
+	if view.ModelsDir != current.ModelsDir {
+		if err := a.SetModelsDir(view.ModelsDir); err != nil {
+			return fmt.Errorf("models directory: %w", err)
+		}
+	}
+	if view.VocabularyPrompt != current.VocabularyPrompt {
+		if err := a.SetVocabularyPrompt(view.VocabularyPrompt); err != nil {
+			return err
+		}
+	}
+	if view.GeminiModel != current.GeminiModel {
+		if err := a.SetGeminiModel(view.GeminiModel); err != nil {
+			return fmt.Errorf("gemini model: %w", err)
+		}
+	}
+	if view.GeminiMaxAttempts != current.GeminiMaxAttempts || view.GeminiRetrySeconds != current.GeminiRetrySeconds {
+		if err := a.SetGeminiRetry(view.GeminiMaxAttempts, view.GeminiRetrySeconds); err != nil {
+			return fmt.Errorf("gemini retry: %w", err)
+		}
+	}
+	if view.RefinementBackend != current.RefinementBackend {
+		if err := a.SetRefinementBackend(view.RefinementBackend); err != nil {
+			return err
+		}
+	}
+	if view.OpenAIBaseURL != current.OpenAIBaseURL || view.OpenAIModel != current.OpenAIModel {
+		_, _, apiKey := a.config.GetOpenAIEndpoint()
+		if err := a.SetOpenAIEndpoint(view.OpenAIBaseURL, view.OpenAIModel, apiKey); err != nil {
+			return fmt.Errorf("openai endpoint: %w", err)
+		}
+	}
+	if view.RefinementEnabled != current.RefinementEnabled {
+		if err := a.SetRefinementEnabled(view.RefinementEnabled); err != nil {
+			return err
+		}
+	}
+	if !maps.Equal(view.Replacements, current.Replacements) {
+		if err := a.SetReplacements(view.Replacements); err != nil {
+			return fmt.Errorf("replacements: %w", err)
+		}
+	}
+	if view.Proxy != current.Proxy {
+		if err := a.SetProxy(view.Proxy); err != nil {
+			return fmt.Errorf("proxy: %w", err)
+		}
+	}
+	if view.GeminiTimeoutSeconds != current.GeminiTimeoutSeconds {
+		if err := a.SetGeminiTimeout(view.GeminiTimeoutSeconds); err != nil {
+			return fmt.Errorf("gemini timeout: %w", err)
+		}
+	}
+	if view.DoubleTapWindowMs != current.DoubleTapWindowMs {
+		if err := a.SetDoubleTapWindow(view.DoubleTapWindowMs); err != nil {
+			return fmt.Errorf("double tap window: %w", err)
+		}
+	}
+	if view.PTTMinHoldMs != current.PTTMinHoldMs {
+		if err := a.SetPTTMinHold(view.PTTMinHoldMs); err != nil {
+			return fmt.Errorf("ptt min hold: %w", err)
+		}
+	}
+	if view.CycleModeHotkey != current.CycleModeHotkey {
+		if err := a.SetCycleModeHotkey(view.CycleModeHotkey); err != nil {
+			return fmt.Errorf("cycle mode hotkey: %w", err)
+		}
+	}
+	if view.AbortHotkey != current.AbortHotkey {
+		if err := a.SetAbortHotkey(view.AbortHotkey); err != nil {
+			return fmt.Errorf("abort hotkey: %w", err)
+		}
+	}
+	if view.HistoryRetentionDays != current.HistoryRetentionDays || view.HistoryMaxEntries != current.HistoryMaxEntries {
+		if err := a.SetHistoryRetention(view.HistoryRetentionDays, view.HistoryMaxEntries); err != nil {
+			return fmt.Errorf("history retention: %w", err)
+		}
+	}
+	if view.EncryptHistory != current.EncryptHistory {
+		if err := a.SetEncryptHistory(view.EncryptHistory); err != nil {
+			return fmt.Errorf("history encryption: %w", err)
+		}
+	}
+	if view.ReinjectDelayMs != current.ReinjectDelayMs {
+		if err := a.SetReinjectDelay(view.ReinjectDelayMs); err != nil {
+			return err
+		}
+	}
+	if view.InjectionMode != current.InjectionMode {
+		if err := a.SetInjectionMode(view.InjectionMode); err != nil {
+			return err
+		}
+	}
+	if view.ClipboardSettleMs != current.ClipboardSettleMs || view.PostPasteMs != current.PostPasteMs ||
+		view.ClipboardRestoreMs != current.ClipboardRestoreMs || view.AdaptivePaste != current.AdaptivePaste {
+		if err := a.SetPasteDelays(view.ClipboardSettleMs, view.PostPasteMs, view.ClipboardRestoreMs, view.AdaptivePaste); err != nil {
+			return err
+		}
+	}
+	if view.PillOpacity != current.PillOpacity {
+		if err := a.SetPillOpacity(view.PillOpacity); err != nil {
+			return err
+		}
+	}
+	if view.KeychainAPIKey != current.KeychainAPIKey {
+		if err := a.SetKeychainAPIKey(view.KeychainAPIKey); err != nil {
+			return err
+		}
+	}
+	if view.LaunchAtLogin != current.LaunchAtLogin {
+		if err := a.SetLaunchAtLogin(view.LaunchAtLogin); err != nil {
+			return fmt.Errorf("launch at login: %w", err)
+		}
+	}
+	if view.PlaySounds != current.PlaySounds || view.SoundVolume != current.SoundVolume {
+		if err := a.SetSoundCues(view.PlaySounds, view.SoundVolume); err != nil {
+			return fmt.Errorf("sound cues: %w", err)
+		}
+	}
+	if view.NotifyOnComplete != current.NotifyOnComplete {
+		if err := a.SetNotifyOnComplete(view.NotifyOnComplete); err != nil {
+			return err
+		}
+	}
+	if view.ShowTrayIcon != current.ShowTrayIcon {
+		if err := a.SetShowTrayIcon(view.ShowTrayIcon); err != nil {
+			return err
+		}
+	}
+	if view.BlankAudioRetries != current.BlankAudioRetries || view.BlankAudioRetryDelayMs != current.BlankAudioRetryDelayMs {
+		if err := a.SetBlankAudioRetry(view.BlankAudioRetries, view.BlankAudioRetryDelayMs); err != nil {
+			return fmt.Errorf("blank audio retry: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	goruntime "runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"voxflow/internal/audio"
+)
+
+const (
+	maxRecentErrors    = 20  // Errors kept for diagnostics
+	diagnosticsLogTail = 200 // Log lines included in diagnostics
+)
+
+// recentError is an error surfaced to the user, kept for bug reports
+type recentError struct {
+	Time    time.Time
+	Message string
+}
+
+// errorLog is a bounded list of recent user-facing errors
+type errorLog struct {
+	entries []recentError
+	mu      sync.Mutex
+}
+
+// Add records an error, dropping the oldest past maxRecentErrors
+func (l *errorLog) Add(message string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, recentError{Time: time.Now(), Message: message})
+	if len(l.entries) > maxRecentErrors {
+		l.entries = l.entries[len(l.entries)-maxRecentErrors:]
+	}
+}
+
+// Entries returns a copy of the recorded errors, oldest first
+func (l *errorLog) Entries() []recentError {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]recentError(nil), l.entries...)
+}
+
+// CollectDiagnostics bundles app status, configuration (without the API key),
+// recent errors and the log tail into a single report for bug reports
+func (a *App) CollectDiagnostics() (string, error) {
+	var b strings.Builder
+	section := func(title string) {
+		fmt.Fprintf(&b, "\n=== %s ===\n", title)
+	}
+
+	fmt.Fprintf(&b, "voxflow diagnostics — %s\n", time.Now().Format(time.RFC3339))
+
+	section("System")
+	fmt.Fprintf(&b, "OS/Arch:        %s/%s\n", goruntime.GOOS, goruntime.GOARCH)
+	fmt.Fprintf(&b, "Go version:     %s\n", goruntime.Version())
+
+	section("Status")
+	modelSize := a.config.GetWhisperModel()
+	downloaded, _ := a.whisperService.IsModelDownloaded(modelSize)
+	fmt.Fprintf(&b, "State:          %s\n", a.state.String())
+	fmt.Fprintf(&b, "Whisper model:  %s (downloaded: %t, ready: %t)\n", modelSize, downloaded, a.modelReady)
+	fmt.Fprintf(&b, "Whisper CLI:    installed: %t\n", a.whisperService.IsWhisperCLIInstalled())
+	fmt.Fprintf(&b, "Gemini API key: set: %t\n", a.config.GetGeminiAPIKey() != "")
+	fmt.Fprintf(&b, "Refinement:     %s\n", a.config.GetRefinementBackend())
+	fmt.Fprintf(&b, "Input device:   %s\n", a.audioRecorder.GetDeviceName())
+	fmt.Fprintf(&b, "History:        available: %t\n", a.historyService != nil)
+	fmt.Fprintf(&b, "Injection:      available: %t\n", a.injectionService != nil)
+	fmt.Fprintf(&b, "Privacy mode:   %t\n", a.privacyMode.Load())
+	avg := a.latency.Average()
+	fmt.Fprintf(&b, "Avg latency:    %.0fms over %d runs\n", avg.TotalMs, avg.Samples)
+
+	section("Permissions")
+	fmt.Fprintf(&b, "Microphone:     %s\n", audio.MicrophonePermission())
+	if a.injectionService != nil {
+		if trusted, err := a.injectionService.CheckAccessibilityPermission(); err != nil {
+			fmt.Fprintf(&b, "Accessibility:  unknown (%v)\n", err)
+		} else {
+			fmt.Fprintf(&b, "Accessibility:  granted: %t\n", trusted)
+		}
+	}
+
+	section("Configuration")
+	view := a.buildConfigView()
+	view.Proxy = redactURL(view.Proxy)
+	cfgJSON, err := json.MarshalIndent(view, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode config: %w", err)
+	}
+	b.Write(cfgJSON)
+	b.WriteString("\n")
+
+	section("Recent Errors")
+	errs := a.recentErrors.Entries()
+	if len(errs) == 0 {
+		b.WriteString("(none)\n")
+	}
+	for _, e := range errs {
+		fmt.Fprintf(&b, "%s  %s\n", e.Time.Format(time.RFC3339), e.Message)
+	}
+
+	section("Log")
+	b.WriteString(readLogTail(diagnosticsLogTail))
+
+	return b.String(), nil
+}
+
+// redactURL drops any credentials from rawURL, e.g. a proxy's user:password
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		if strings.Contains(rawURL, "@") {
+			return "(redacted)"
+		}
+		return rawURL
+	}
+	if u.User == nil {
+		return rawURL
+	}
+	u.User = nil
+	return u.String()
+}
+
+// readLogTail returns the last n lines of the log file
+func readLogTail(n int) string {
+	logPath, err := logFilePath()
+	if err != nil {
+		return fmt.Sprintf("(unavailable: %v)\n", err)
+	}
+
+	f, err := os.Open(logPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "(no log file)\n"
+		}
+		return fmt.Sprintf("(unavailable: %v)\n", err)
+	}
+	defer f.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+		if len(lines) > n {
+			lines = lines[1:]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Sprintf("(unavailable: %v)\n", err)
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+
+	"voxflow/internal/history"
+	"voxflow/internal/keychain"
+)
+
+// historyKeyAccount is the keychain account holding the history encryption key
+const historyKeyAccount = "history-key"
+
+// storedHistoryKey returns the history encryption key from the keychain, or
+// keychain.ErrNotFound if none was generated yet
+func storedHistoryKey() ([]byte, error) {
+	stored, err := keychain.Get(historyKeyAccount)
+	if err != nil {
+		return nil, err
+	}
+	key, err := hex.DecodeString(stored)
+	if err != nil || len(key) != history.KeySize {
+		return nil, fmt.Errorf("history key in keychain is malformed")
+	}
+	return key, nil
+}
+
+// errHistoryKeyMissing means the keychain has no history key although
+// transcripts were encrypted with one. A new key can't read them, so one is
+// only generated once the user agrees (resetHistoryKey).
+var errHistoryKeyMissing = errors.New("the history encryption key is missing from the keychain")
+
+// historyKey returns the history encryption key from the keychain, generating
+// and storing one the first time
+func (a *App) historyKey() ([]byte, error) {
+	key, err := storedHistoryKey()
+	if !errors.Is(err, keychain.ErrNotFound) {
+		return key, err
+	}
+	encrypted, err := a.historyService.HasEncryptedRows()
+	if err != nil {
+		return nil, err
+	}
+	if encrypted {
+		return nil, errHistoryKeyMissing
+	}
+	return newHistoryKey()
+}
+
+// newHistoryKey generates a history encryption key and stores it in the keychain
+func newHistoryKey() ([]byte, error) {
+	key, err := history.GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+	if err := keychain.Set(historyKeyAccount, hex.EncodeToString(key)); err != nil {
+		return nil, err
+	}
+	fmt.Println("[History] Generated a new encryption key in the keychain")
+	return key, nil
+}
+
+// enableHistoryEncryption loads the key and encrypts any plain-text transcripts.
+// Without a key, saving is blocked so transcripts aren't stored unencrypted.
+func (a *App) enableHistoryEncryption() error {
+	key, err := a.historyKey()
+	if err != nil {
+		a.historyService.RequireKey()
+		return err
+	}
+	return a.historyService.EnableEncryption(key)
+}
+
+// retryHistoryKey tries again to load a history key that was unavailable,
+// e.g. because the keychain was locked at startup
+func (a *App) retryHistoryKey() {
+	if a.historyService == nil || !a.historyService.KeyRequired() {
+		return
+	}
+	if err := a.enableHistoryEncryption(); err != nil {
+		fmt.Printf("[History] Encryption key still unavailable: %v\n", err)
+	}
+}
+
+// historyKeyMissing reports whether history is encrypted with a key that is
+// no longer in the keychain, so resetHistoryKey is needed to save again
+func (a *App) historyKeyMissing() bool {
+	if a.historyService == nil || !a.historyService.KeyRequired() {
+		return false
+	}
+	if _, err := storedHistoryKey(); !errors.Is(err, keychain.ErrNotFound) {
+		return false
+	}
+	encrypted, err := a.historyService.HasEncryptedRows()
+	return err == nil && encrypted
+}
+
+// confirmHistoryKeyReset asks, once per launch, whether to generate a new
+// history key when the old one is missing from the keychain
+func (a *App) confirmHistoryKeyReset() {
+	if !a.historyKeyMissing() || !a.historyKeyPrompted.CompareAndSwap(false, true) {
+		return
+	}
+	choice, err := runtime.MessageDialog(a.ctx, runtime.MessageDialogOptions{
+		Type:          runtime.QuestionDialog,
+		Title:         "History Encryption Key Missing",
+		Message:       "The key your history was encrypted with is no longer in the keychain. Until it is restored, new transcripts aren't saved. Generate a new key? Transcripts encrypted with the old key will stay unreadable.",
+		Buttons:       []string{"Generate New Key", "Not Now"},
+		DefaultButton: "Not Now",
+		CancelButton:  "Not Now",
+	})
+	if err != nil {
+		fmt.Printf("[History] Key dialog failed: %v\n", err)
+	}
+	if choice != "Generate New Key" {
+		return
+	}
+	if err := a.resetHistoryKey(); err != nil {
+		a.emitToast("Failed to generate a new history key: "+err.Error(), "error")
+		return
+	}
+	a.emitToast("New history encryption key generated", "success")
+}
+
+// resetHistoryKey generates a new history key after the old one went missing.
+// Transcripts encrypted with the old key stay unreadable (unless it is put
+// back in the keychain); new ones are encrypted with the new key.
+func (a *App) resetHistoryKey() error {
+	if a.historyService == nil {
+		return fmt.Errorf("history service not available")
+	}
+	if _, err := storedHistoryKey(); !errors.Is(err, keychain.ErrNotFound) {
+		return fmt.Errorf("the history key is in the keychain, nothing to reset")
+	}
+	key, err := newHistoryKey()
+	if err != nil {
+		return err
+	}
+	if err := a.historyService.EnableEncryption(key); err != nil {
+		return fmt.Errorf("failed to encrypt history: %w", err)
+	}
+	a.config.SetEncryptHistory(true)
+	return a.config.Save()
+}
+
+// SetEncryptHistory turns encryption of transcript text at rest on or off,
+// re-writing existing transcripts. The key stays in the keychain either way,
+// so turning it back on doesn't need a new one.
+func (a *App) SetEncryptHistory(enabled bool) error {
+	if a.historyService == nil {
+		return fmt.Errorf("history service not available")
+	}
+	if enabled {
+		if err := a.enableHistoryEncryption(); err != nil {
+			return fmt.Errorf("failed to encrypt history: %w", err)
+		}
+	} else {
+		// No key means nothing was ever encrypted
+		key, err := storedHistoryKey()
+		if err != nil && !errors.Is(err, keychain.ErrNotFound) {
+			return fmt.Errorf("failed to load history key: %w", err)
+		}
+		if err := a.historyService.DisableEncryption(key); err != nil {
+			return fmt.Errorf("failed to decrypt history: %w", err)
+		}
+	}
+	a.config.SetEncryptHistory(enabled)
+	return a.config.Save()
+}
@@ -8,7 +8,7 @@ import RecordingIndicator from "./components/RecordingIndicator";
 import RecordingPill from "./components/RecordingPill";
 import { ThemeProvider, useTheme } from "./contexts/ThemeContext";
 import { ToastProvider, useToast } from "./contexts/ToastContext";
-import { EventsOn, Quit } from "../wailsjs/runtime/runtime";
+import { EventsEmit, EventsOn, Quit } from "../wailsjs/runtime/runtime";
 import { IsMiniMode, ShowMiniMode } from "../wailsjs/go/main/App";
 import { Logger } from "./utils/logger";
 
@@ -154,6 +154,13 @@ function AppContent() {
       }
     );
 
+    EventsOn("accessibility-required", () => {
+      showToast(
+        "To paste at the cursor, allow voxflow in System Settings → Privacy & Security → Accessibility",
+        "warning"
+      );
+    });
+
     EventsOn(
       "model-status",
       (status: { downloaded: boolean; loaded: boolean }) => {
@@ -166,6 +173,9 @@ function AppContent() {
         }
       }
     );
+
+    // Ask the backend to re-broadcast state now that listeners are registered
+    EventsEmit("frontend-ready");
   }, [showToast]);
 
   // Transparency Watchdog - Force transparency every 100ms in mini-mode
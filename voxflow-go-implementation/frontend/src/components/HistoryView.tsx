@@ -5,9 +5,12 @@ import {
   DeleteTranscript,
   ClearAllHistory,
   CopyToClipboard,
+  InjectTranscript,
 } from "../../wailsjs/go/main/App";
 import { useConfirmModal } from "./ConfirmModal";
 
+const PAGE_SIZE = 100;
+
 interface Transcript {
   id: number;
   timestamp: string;
@@ -15,6 +18,32 @@ interface Transcript {
   raw_text: string;
   polished_text: string;
   mode: string;
+  tone?: string;
+  translated?: boolean;
+}
+
+// Tag colors for the tone classified during refinement
+const TONE_STYLES: Record<string, string> = {
+  neutral: "bg-gray-500/10 text-gray-500",
+  positive: "bg-green-500/10 text-green-600",
+  negative: "bg-red-500/10 text-red-500",
+  excited: "bg-amber-500/10 text-amber-600",
+};
+
+function ToneTag({ tone }: { tone?: string }) {
+  if (!tone) {
+    return null;
+  }
+  return (
+    <span
+      className={`px-1.5 py-0.5 rounded-md text-[10px] font-medium capitalize ${
+        TONE_STYLES[tone] || TONE_STYLES.neutral
+      }`}
+      title="Tone"
+    >
+      {tone}
+    </span>
+  );
 }
 
 export default function HistoryView() {
@@ -22,16 +51,23 @@ export default function HistoryView() {
   const [selectedId, setSelectedId] = useState<number | null>(null);
   const [searchQuery, setSearchQuery] = useState("");
   const [loading, setLoading] = useState(true);
+  const [total, setTotal] = useState(0);
+  const [loadingMore, setLoadingMore] = useState(false);
 
   const { confirm, ConfirmModalComponent } = useConfirmModal();
 
   const loadTranscripts = async () => {
     setLoading(true);
     try {
-      const data = searchQuery
-        ? await SearchHistory(searchQuery, 100)
-        : await GetHistory(100);
-      setTranscripts(data || []);
+      if (searchQuery) {
+        const data = await SearchHistory(searchQuery, PAGE_SIZE);
+        setTranscripts(data || []);
+        setTotal(data?.length || 0);
+      } else {
+        const page = await GetHistory(0, PAGE_SIZE);
+        setTranscripts(page?.transcripts || []);
+        setTotal(page?.total || 0);
+      }
     } catch (err) {
       console.error("Failed to load history:", err);
     } finally {
@@ -39,6 +75,19 @@ export default function HistoryView() {
     }
   };
 
+  const loadMore = async () => {
+    setLoadingMore(true);
+    try {
+      const page = await GetHistory(transcripts.length, PAGE_SIZE);
+      setTranscripts((prev) => [...prev, ...(page?.transcripts || [])]);
+      setTotal(page?.total || 0);
+    } catch (err) {
+      console.error("Failed to load more history:", err);
+    } finally {
+      setLoadingMore(false);
+    }
+  };
+
   useEffect(() => {
     loadTranscripts();
   }, []);
@@ -64,6 +113,7 @@ export default function HistoryView() {
     try {
       await DeleteTranscript(id);
       setTranscripts(transcripts.filter((t) => t.id !== id));
+      setTotal((n) => Math.max(0, n - 1));
       if (selectedId === id) setSelectedId(null);
     } catch (err) {
       console.error("Failed to delete:", err);
@@ -97,6 +147,14 @@ export default function HistoryView() {
     }
   };
 
+  const handleInject = async (id: number) => {
+    try {
+      await InjectTranscript(id);
+    } catch (err) {
+      console.error("Failed to paste transcript:", err);
+    }
+  };
+
   const formatDate = (timestamp: string) => {
     const date = new Date(timestamp);
     return date.toLocaleDateString("en-US", {
@@ -206,14 +264,28 @@ export default function HistoryView() {
                       : "hover:bg-tertiary border-l-2 border-l-transparent"
                   }`}
                 >
-                  <p className="text-xs text-tertiary mb-1">
-                    {formatDate(t.timestamp)}
-                  </p>
+                  <div className="flex items-center gap-2 mb-1">
+                    <p className="text-xs text-tertiary">
+                      {formatDate(t.timestamp)}
+                    </p>
+                    <ToneTag tone={t.tone} />
+                  </div>
                   <p className="text-sm text-primary line-clamp-2">
                     {truncate(t.polished_text || t.raw_text, 80)}
                   </p>
                 </button>
               ))}
+              {!searchQuery && transcripts.length < total && (
+                <button
+                  onClick={loadMore}
+                  disabled={loadingMore}
+                  className="w-full p-3 text-sm text-tertiary hover:text-primary hover:bg-tertiary transition-colors"
+                >
+                  {loadingMore
+                    ? "Loading..."
+                    : `Load more (${total - transcripts.length} older)`}
+                </button>
+              )}
             </div>
           )}
         </div>
@@ -234,6 +306,21 @@ export default function HistoryView() {
                   <span className="capitalize">
                     {selectedTranscript.mode || "casual"}
                   </span>
+                  {selectedTranscript.app_name && (
+                    <span className="ml-2 text-xs text-tertiary">
+                      • {selectedTranscript.app_name}
+                    </span>
+                  )}
+                  {selectedTranscript.translated && (
+                    <span className="ml-2 text-xs text-tertiary">
+                      • Translated to English
+                    </span>
+                  )}
+                  {selectedTranscript.tone && (
+                    <span className="ml-2">
+                      <ToneTag tone={selectedTranscript.tone} />
+                    </span>
+                  )}
                 </p>
               </div>
               <button
@@ -264,12 +351,23 @@ export default function HistoryView() {
                   <h3 className="text-xs font-medium text-tertiary uppercase tracking-wider">
                     Result
                   </h3>
-                  <button
-                    onClick={() => handleCopy(selectedTranscript.polished_text)}
-                    className="text-xs text-tertiary hover:text-[var(--accent)] transition-colors"
-                  >
-                    Copy
-                  </button>
+                  <div className="flex items-center gap-3">
+                    <button
+                      onClick={() => handleInject(selectedTranscript.id)}
+                      className="text-xs text-tertiary hover:text-[var(--accent)] transition-colors"
+                      title="Paste into the app you were using before"
+                    >
+                      Paste
+                    </button>
+                    <button
+                      onClick={() =>
+                        handleCopy(selectedTranscript.polished_text)
+                      }
+                      className="text-xs text-tertiary hover:text-[var(--accent)] transition-colors"
+                    >
+                      Copy
+                    </button>
+                  </div>
                 </div>
                 <div className="card p-4">
                   <p className="text-primary whitespace-pre-wrap leading-relaxed">
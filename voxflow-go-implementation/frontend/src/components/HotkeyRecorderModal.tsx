@@ -5,6 +5,8 @@ interface HotkeyRecorderModalProps {
   onClose: () => void;
   onSave: (hotkey: string) => void;
   initialValue?: string;
+  // Backend check of the recorded combo; resolves to an error message, or "" if valid
+  validate?: (hotkey: string) => Promise<string>;
 }
 
 const MODIFIERS = new Set(["cmd", "ctrl", "alt", "shift", "win", "super"]);
@@ -19,6 +21,7 @@ export default function HotkeyRecorderModal({
   onClose,
   onSave,
   initialValue = "",
+  validate,
 }: HotkeyRecorderModalProps) {
   const [currentKeys, setCurrentKeys] = useState<Set<string>>(new Set());
   const [displayKeys, setDisplayKeys] = useState<string[]>([]);
@@ -28,8 +31,9 @@ export default function HotkeyRecorderModal({
   // We keep track if the user has actively pressed a new combo to replace the initial one
   const [hasStartedRecording, setHasStartedRecording] = useState(false);
 
-  // Validation: must have at least one modifier AND exactly one non-modifier
-  const validation = useMemo(() => {
+  // Validation: must have at least one modifier AND exactly one non-modifier,
+  // except function keys, which may be used alone
+  const localValidation = useMemo(() => {
     if (displayKeys.length === 0) {
       return { isValid: true, message: "" }; // No input yet, no error
     }
@@ -37,12 +41,21 @@ export default function HotkeyRecorderModal({
     const modifierKeys = displayKeys.filter((k) => MODIFIERS.has(k));
     const regularKeys = displayKeys.filter((k) => !MODIFIERS.has(k));
 
+    const isFunctionKey = (k: string) => /^f([1-9]|1[0-9]|20)$/.test(k);
+    if (
+      modifierKeys.length === 0 &&
+      regularKeys.length === 1 &&
+      isFunctionKey(regularKeys[0])
+    ) {
+      return { isValid: true, message: "" };
+    }
+
     if (modifierKeys.length === 0) {
       return {
         isValid: false,
         message: isMac
-          ? "Global shortcuts require a modifier (⌘ Cmd, ⌃ Ctrl, ⌥ Alt, or ⇧ Shift)"
-          : "Global shortcuts require a modifier (Ctrl, Alt, Shift, or Win)",
+          ? "Global shortcuts require a modifier (⌘ Cmd, ⌃ Ctrl, ⌥ Alt, or ⇧ Shift) unless they are a function key"
+          : "Global shortcuts require a modifier (Ctrl, Alt, Shift, or Win) unless they are a function key",
       };
     }
     if (regularKeys.length === 0) {
@@ -61,6 +74,27 @@ export default function HotkeyRecorderModal({
     return { isValid: true, message: "" };
   }, [displayKeys]);
 
+  // Ask the backend about combos that pass the local checks, e.g. unknown keys
+  const [backendError, setBackendError] = useState("");
+  useEffect(() => {
+    setBackendError("");
+    if (!validate || displayKeys.length === 0 || !localValidation.isValid) {
+      return;
+    }
+    let cancelled = false;
+    validate(displayKeys.join("+")).then((message) => {
+      if (!cancelled) setBackendError(message);
+    });
+    return () => {
+      cancelled = true;
+    };
+  }, [validate, displayKeys, localValidation.isValid]);
+
+  const validation =
+    localValidation.isValid && backendError
+      ? { isValid: false, message: backendError }
+      : localValidation;
+
   // Initialize display keys from initialValue
   useEffect(() => {
     if (isOpen) {
@@ -88,7 +122,18 @@ export default function HotkeyRecorderModal({
       ArrowLeft: "left",
       ArrowRight: "right",
       Backspace: "backspace",
-      Delete: "delete",
+      Delete: "forwarddelete",
+      Minus: "minus",
+      Equal: "equal",
+      BracketLeft: "leftbracket",
+      BracketRight: "rightbracket",
+      Semicolon: "semicolon",
+      Quote: "quote",
+      Comma: "comma",
+      Period: "period",
+      Slash: "slash",
+      Backslash: "backslash",
+      Backquote: "grave",
     };
 
     if (codeMap[code]) return codeMap[code];
@@ -1,6 +1,12 @@
 import { useState, useEffect } from "react";
 import { EventsOn } from "../../wailsjs/runtime/runtime";
-import { ToggleRecording, GetStatus } from "../../wailsjs/go/main/App";
+import {
+  ToggleRecording,
+  AbortProcessing,
+  GetStatus,
+  HasLastRecording,
+  RetryLastRecording,
+} from "../../wailsjs/go/main/App";
 
 type Status = "Idle" | "Recording" | "Processing";
 
@@ -10,12 +16,20 @@ export default function MainView() {
     null
   );
   const [error, setError] = useState<string | null>(null);
+  // A recording that failed to process and can be retried
+  const [canRetry, setCanRetry] = useState(false);
 
   useEffect(() => {
     GetStatus().then((s) => setStatus(s as Status));
+    HasLastRecording().then(setCanRetry);
 
     EventsOn("state-changed", (newStatus: string) => {
       setStatus(newStatus as Status);
+      if (newStatus === "Idle") {
+        HasLastRecording().then(setCanRetry);
+      } else {
+        setCanRetry(false);
+      }
       if (newStatus === "Recording") {
         setError(null);
         setLastTranscription(null);
@@ -34,6 +48,23 @@ export default function MainView() {
     });
   }, []);
 
+  const handleAbort = async () => {
+    try {
+      await AbortProcessing();
+    } catch (err) {
+      setError(String(err));
+    }
+  };
+
+  const handleRetry = async () => {
+    try {
+      setError(null);
+      await RetryLastRecording();
+    } catch (err) {
+      setError(String(err));
+    }
+  };
+
   const handleToggle = async () => {
     try {
       await ToggleRecording();
@@ -144,6 +175,22 @@ export default function MainView() {
             )}
           </button>
         </div>
+        {status === "Processing" && (
+          <button
+            onClick={handleAbort}
+            className="mt-4 text-sm text-tertiary hover:text-primary transition-colors"
+          >
+            Cancel
+          </button>
+        )}
+        {status === "Idle" && canRetry && (
+          <button
+            onClick={handleRetry}
+            className="mt-4 text-sm text-tertiary hover:text-primary transition-colors"
+          >
+            Retry last recording
+          </button>
+        )}
       </div>
 
       {/* Error display */}
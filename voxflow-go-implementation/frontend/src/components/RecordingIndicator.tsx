@@ -3,7 +3,10 @@ import { EventsOn, Quit } from "../../wailsjs/runtime/runtime";
 import {
   HideMiniMode,
   ToggleRecording,
+  AbortProcessing,
   GetStatus,
+  GetPrivacyMode,
+  SetPrivacyMode,
 } from "../../wailsjs/go/main/App";
 import { useTheme } from "../contexts/ThemeContext";
 
@@ -12,6 +15,7 @@ type Status = "Idle" | "Recording" | "Processing";
 export default function RecordingIndicator() {
   const [status, setStatus] = useState<Status>("Idle");
   const [hoveredButton, setHoveredButton] = useState<string | null>(null);
+  const [privacyMode, setPrivacyMode] = useState<boolean>(false);
   const { theme } = useTheme();
 
   useEffect(() => {
@@ -20,12 +24,17 @@ export default function RecordingIndicator() {
     EventsOn("state-changed", (newStatus: string) => {
       setStatus(newStatus as Status);
     });
+
+    GetPrivacyMode().then(setPrivacyMode);
+    EventsOn("privacy-mode", (enabled: boolean) => setPrivacyMode(enabled));
   }, []);
 
   const handleRecordClick = async (e: React.MouseEvent) => {
     e.preventDefault();
     e.stopPropagation();
-    if (status !== "Processing") {
+    if (status === "Processing") {
+      await AbortProcessing();
+    } else {
       await ToggleRecording();
     }
   };
@@ -36,6 +45,12 @@ export default function RecordingIndicator() {
     HideMiniMode();
   };
 
+  const handlePrivacyClick = (e: React.MouseEvent) => {
+    e.preventDefault();
+    e.stopPropagation();
+    SetPrivacyMode(!privacyMode);
+  };
+
   const handleQuitClick = (e: React.MouseEvent) => {
     e.preventDefault();
     e.stopPropagation();
@@ -109,7 +124,13 @@ export default function RecordingIndicator() {
         className="flex-none flex items-center justify-center cursor-pointer no-drag mr-3"
         style={{ WebkitAppRegion: "no-drag" } as React.CSSProperties}
         onClick={handleRecordClick}
-        title={status === "Idle" ? "Start Recording" : "Stop Recording"}
+        title={
+          status === "Idle"
+            ? "Start Recording"
+            : status === "Recording"
+            ? "Stop Recording"
+            : "Cancel Processing"
+        }
       >
         <div
           className={`relative rounded-full flex items-center justify-center transition-all duration-300 w-10 h-10 hover:scale-105`}
@@ -163,8 +184,52 @@ export default function RecordingIndicator() {
         </div>
       </div>
 
-      {/* === RIGHT: ACTIONS (Expand & Quit) === */}
+      {/* === RIGHT: ACTIONS (Privacy, Expand & Quit) === */}
       <div className="flex-none flex items-center gap-2">
+        {/* Privacy Toggle - stays lit while privacy mode is on */}
+        <div
+          className={`w-8 h-8 flex items-center justify-center cursor-pointer transition-colors no-drag rounded-full ${
+            status === "Recording" ? "text-white hover:bg-white/20" : ""
+          }`}
+          style={
+            status !== "Recording"
+              ? {
+                  WebkitAppRegion: "no-drag",
+                  background: privacyMode
+                    ? "rgba(168, 85, 247, 0.2)"
+                    : hoveredButton === "privacy"
+                    ? isDark
+                      ? "rgba(255,255,255,0.15)"
+                      : "rgba(0,0,0,0.08)"
+                    : "transparent",
+                  color: privacyMode ? "#a855f7" : "inherit",
+                }
+              : ({ WebkitAppRegion: "no-drag" } as any)
+          }
+          onClick={handlePrivacyClick}
+          onMouseEnter={() => setHoveredButton("privacy")}
+          onMouseLeave={() => setHoveredButton(null)}
+          title={
+            privacyMode
+              ? "Privacy mode on - nothing is saved. Click to turn off"
+              : "Turn on privacy mode"
+          }
+        >
+          <svg
+            className={`w-4 h-4 ${privacyMode ? "opacity-100" : "opacity-70"}`}
+            fill={privacyMode ? "currentColor" : "none"}
+            viewBox="0 0 24 24"
+            stroke="currentColor"
+            strokeWidth={2}
+          >
+            <path
+              strokeLinecap="round"
+              strokeLinejoin="round"
+              d="M12 3l7 3v5c0 4.5-3 8.5-7 10-4-1.5-7-5.5-7-10V6l7-3z"
+            />
+          </svg>
+        </div>
+
         {/* Expand Button */}
         <div
           className={`w-8 h-8 flex items-center justify-center cursor-pointer transition-colors no-drag rounded-full ${
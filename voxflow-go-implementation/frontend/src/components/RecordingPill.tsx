@@ -1,11 +1,16 @@
 import { useState, useEffect } from "react";
 import { EventsOn } from "../../wailsjs/runtime/runtime";
-import { ToggleRecording, GetStatus } from "../../wailsjs/go/main/App";
+import {
+  ToggleRecording,
+  GetStatus,
+  GetPrivacyMode,
+} from "../../wailsjs/go/main/App";
 
 type Status = "Idle" | "Recording" | "Processing";
 
 export default function RecordingPill() {
   const [status, setStatus] = useState<Status>("Idle");
+  const [privacyMode, setPrivacyMode] = useState<boolean>(false);
 
   useEffect(() => {
     GetStatus().then((s) => setStatus(s as Status));
@@ -13,6 +18,9 @@ export default function RecordingPill() {
     EventsOn("state-changed", (newStatus: string) => {
       setStatus(newStatus as Status);
     });
+
+    GetPrivacyMode().then(setPrivacyMode);
+    EventsOn("privacy-mode", (enabled: boolean) => setPrivacyMode(enabled));
   }, []);
 
   const handleStop = async () => {
@@ -46,6 +54,16 @@ export default function RecordingPill() {
         {/* Recording text */}
         <span className="text-sm text-secondary font-medium">Recording</span>
 
+        {/* Privacy indicator */}
+        {privacyMode && (
+          <span
+            className="text-xs font-medium text-purple-500"
+            title="Privacy mode on - nothing is saved"
+          >
+            Private
+          </span>
+        )}
+
         {/* Stop button (red dot) */}
         <button
           onClick={handleStop}
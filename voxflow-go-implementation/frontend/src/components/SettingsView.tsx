@@ -1,17 +1,40 @@
-import { useState, useEffect } from "react";
+import { useState, useEffect, useCallback } from "react";
 import {
   GetConfig,
   SetAPIKey,
   SetHotkey,
   SetHandsFreeHotkey,
   SetPushToTalkHotkey,
+  ValidateHotkey,
   SetWhisperModel,
   SetMode,
+  ListModes,
+  SaveMode,
+  DeleteMode,
+  GetUsageThisMonth,
+  CheckAccessibilityPermission,
+  OpenAccessibilitySettings,
+  GetProcessingStats,
+  GetGeminiModels,
+  SetGeminiModel,
   GetAllModels,
   DownloadModelByName,
   DeleteModelByName,
+  RedownloadModel,
+  PickModelFile,
+  ImportModel,
   IsWhisperCLIReady,
   CancelDownload,
+  CollectDiagnostics,
+  ClearLog,
+  CopyToClipboard,
+  SaveConfigExport,
+  SetLaunchAtLogin,
+  SetSoundCues,
+  SetNotifyOnComplete,
+  SetShowTrayIcon,
+  PickConfigFile,
+  ImportConfig,
 } from "../../wailsjs/go/main/App";
 
 import { EventsOn } from "../../wailsjs/runtime/runtime";
@@ -24,12 +47,44 @@ interface Config {
   hotkey: string; // Keep for legacy
   whisper_model: string;
   mode: string;
+  gemini_model: string;
   api_key_set: boolean;
+  launch_at_login: boolean;
+  play_sounds: boolean;
+  sound_volume: number;
+  notify_on_complete: boolean;
+  show_tray_icon: boolean;
+}
+
+interface RefineMode {
+  name: string;
+  prompt: string;
+  builtin: boolean;
+}
+
+interface MonthlyUsage {
+  requests: number;
+  prompt_tokens: number;
+  output_tokens: number;
+  total_tokens: number;
+  estimated_cost_usd: number;
+  cost_known: boolean;
+}
+
+interface ProcessingStats {
+  count: number;
+  total_audio_ms: number;
+  total_whisper_ms: number;
+  total_gemini_ms: number;
+  avg_audio_ms: number;
+  avg_whisper_ms: number;
+  avg_gemini_ms: number;
 }
 
 interface ModelInfo {
   name: string;
   description: string;
+  tier: string;
   size: number;
   downloaded: boolean;
   file_path: string;
@@ -46,11 +101,32 @@ export default function SettingsView() {
   const [downloading, setDownloading] = useState<string | null>(null);
   const [downloadProgress, setDownloadProgress] = useState(0);
   const [whisperReady, setWhisperReady] = useState(false);
+  const [accessibilityGranted, setAccessibilityGranted] = useState(true);
+  const [geminiModels, setGeminiModels] = useState<string[]>([]);
+  const [customModes, setCustomModes] = useState<RefineMode[]>([]);
+  const [modeName, setModeName] = useState("");
+  const [modePrompt, setModePrompt] = useState("");
+  const [modeError, setModeError] = useState<string | null>(null);
+  const [usage, setUsage] = useState<MonthlyUsage | null>(null);
+  const [stats, setStats] = useState<ProcessingStats | null>(null);
 
   useEffect(() => {
     loadConfig();
     loadModels();
     checkWhisperCLI();
+    CheckAccessibilityPermission()
+      .then(setAccessibilityGranted)
+      .catch((err) => console.error("Failed to check accessibility:", err));
+    loadModes();
+    GetUsageThisMonth()
+      .then(setUsage)
+      .catch((err) => console.error("Failed to load usage:", err));
+    GetProcessingStats()
+      .then(setStats)
+      .catch((err) => console.error("Failed to load processing stats:", err));
+    GetGeminiModels()
+      .then((list) => setGeminiModels(list || []))
+      .catch((err) => console.error("Failed to load Gemini models:", err));
 
     // Listen for download progress
     EventsOn(
@@ -65,6 +141,11 @@ export default function SettingsView() {
       setDownloadProgress(0);
       loadModels();
     });
+
+    // Keep the mode picker in sync with the cycle mode hotkey
+    EventsOn("mode-changed", (data: { mode: string }) => {
+      setConfig((prev) => (prev ? { ...prev, mode: data.mode } : null));
+    });
   }, []);
 
   const loadConfig = async () => {
@@ -176,6 +257,34 @@ export default function SettingsView() {
     }
   };
 
+  // Replaces a model whisper reported as corrupt (or that keeps failing to load)
+  const handleRedownloadModel = async (modelName: string) => {
+    setDownloading(modelName);
+    setDownloadProgress(0);
+    try {
+      await RedownloadModel(modelName);
+    } catch (err) {
+      console.error("Failed to re-download model:", err);
+      setDownloading(null);
+      loadModels();
+    }
+  };
+
+  const handleImportModel = async (modelName: string) => {
+    try {
+      const path = await PickModelFile();
+      if (!path) return;
+      setSaving("import");
+      await ImportModel(modelName, path);
+      loadModels();
+    } catch (err) {
+      console.error("Failed to import model:", err);
+      alert(String(err));
+    } finally {
+      setSaving(null);
+    }
+  };
+
   const handleCancelDownload = async () => {
     try {
       await CancelDownload();
@@ -201,6 +310,14 @@ export default function SettingsView() {
     setHotkeyModalOpen(true);
   };
 
+  const validateHotkey = useCallback(
+    (hotkey: string) =>
+      ValidateHotkey(hotkey, activeHotkeyField === "handsFree")
+        .then(() => "")
+        .catch((err) => String(err)),
+    [activeHotkeyField]
+  );
+
   const handleHotkeySave = async (newHotkey: string) => {
     if (activeHotkeyField === "ptt") {
       await handlePushToTalkChange(newHotkey);
@@ -248,6 +365,107 @@ export default function SettingsView() {
     }
   };
 
+  const handleCopyDiagnostics = async () => {
+    setSaving("diagnostics");
+    try {
+      const report = await CollectDiagnostics();
+      await CopyToClipboard(report);
+      showSuccess("diagnostics");
+    } catch (err) {
+      console.error("Failed to collect diagnostics:", err);
+    } finally {
+      setSaving(null);
+    }
+  };
+
+  const handleClearLog = async () => {
+    setSaving("clearLog");
+    try {
+      await ClearLog();
+      showSuccess("clearLog");
+    } catch (err) {
+      console.error("Failed to clear log:", err);
+      alert(String(err));
+    } finally {
+      setSaving(null);
+    }
+  };
+
+  const handleLaunchAtLoginChange = async (enabled: boolean) => {
+    setSaving("launchAtLogin");
+    try {
+      await SetLaunchAtLogin(enabled);
+      setConfig((prev) =>
+        prev ? { ...prev, launch_at_login: enabled } : null
+      );
+    } catch (err) {
+      console.error("Failed to set launch at login:", err);
+      alert(String(err));
+    } finally {
+      setSaving(null);
+    }
+  };
+
+  const handleSoundCuesChange = async (enabled: boolean, volume: number) => {
+    try {
+      await SetSoundCues(enabled, volume);
+      setConfig((prev) =>
+        prev ? { ...prev, play_sounds: enabled, sound_volume: volume } : null
+      );
+    } catch (err) {
+      console.error("Failed to save sound cues:", err);
+    }
+  };
+
+  const handleNotifyChange = async (enabled: boolean) => {
+    try {
+      await SetNotifyOnComplete(enabled);
+      setConfig((prev) =>
+        prev ? { ...prev, notify_on_complete: enabled } : null
+      );
+    } catch (err) {
+      console.error("Failed to save notification setting:", err);
+    }
+  };
+
+  const handleTrayIconChange = async (enabled: boolean) => {
+    try {
+      await SetShowTrayIcon(enabled);
+      setConfig((prev) => (prev ? { ...prev, show_tray_icon: enabled } : null));
+    } catch (err) {
+      console.error("Failed to save menu bar icon setting:", err);
+    }
+  };
+
+  const handleExportConfig = async (includeAPIKey: boolean) => {
+    setSaving("export");
+    try {
+      const path = await SaveConfigExport(includeAPIKey);
+      if (path) showSuccess("export");
+    } catch (err) {
+      console.error("Failed to export settings:", err);
+      alert(String(err));
+    } finally {
+      setSaving(null);
+    }
+  };
+
+  const handleImportConfig = async () => {
+    try {
+      const path = await PickConfigFile();
+      if (!path) return;
+      setSaving("import-config");
+      await ImportConfig(path);
+      loadConfig();
+      showSuccess("import-config");
+    } catch (err) {
+      console.error("Failed to import settings:", err);
+      alert(String(err));
+    } finally {
+      setSaving(null);
+    }
+  };
+
   const handleModeChange = async (value: string) => {
     setSaving("mode");
     try {
@@ -261,6 +479,56 @@ export default function SettingsView() {
     }
   };
 
+  const loadModes = async () => {
+    try {
+      const list: RefineMode[] = (await ListModes()) || [];
+      setCustomModes(list.filter((m) => !m.builtin));
+    } catch (err) {
+      console.error("Failed to load modes:", err);
+    }
+  };
+
+  const handleSaveMode = async () => {
+    setSaving("customMode");
+    setModeError(null);
+    try {
+      await SaveMode(modeName, modePrompt);
+      await loadModes();
+      setModeName("");
+      setModePrompt("");
+      showSuccess("customMode");
+    } catch (err) {
+      setModeError(String(err));
+    } finally {
+      setSaving(null);
+    }
+  };
+
+  const handleDeleteMode = async (name: string) => {
+    try {
+      await DeleteMode(name);
+      await loadModes();
+      if (config?.mode === name) {
+        setConfig((prev) => (prev ? { ...prev, mode: "casual" } : null));
+      }
+    } catch (err) {
+      console.error("Failed to delete mode:", err);
+    }
+  };
+
+  const handleGeminiModelChange = async (value: string) => {
+    setSaving("geminiModel");
+    try {
+      await SetGeminiModel(value);
+      setConfig((prev) => (prev ? { ...prev, gemini_model: value } : null));
+      showSuccess("geminiModel");
+    } catch (err) {
+      console.error("Failed to save Gemini model:", err);
+    } finally {
+      setSaving(null);
+    }
+  };
+
   const formatSize = (bytes: number) => {
     if (bytes >= 1024 * 1024 * 1024) {
       return `${(bytes / (1024 * 1024 * 1024)).toFixed(1)} GB`;
@@ -295,6 +563,21 @@ export default function SettingsView() {
           </section>
         )}
 
+        {!accessibilityGranted && (
+          <section className="p-4 bg-amber-500/10 border border-amber-500/30 rounded-xl">
+            <p className="text-sm text-amber-600 dark:text-amber-400">
+              ⚠️ voxflow can't paste into other apps without Accessibility
+              permission, so dictations only go to the clipboard.{" "}
+              <button
+                onClick={() => OpenAccessibilitySettings()}
+                className="underline hover:no-underline"
+              >
+                Open System Settings
+              </button>
+            </p>
+          </section>
+        )}
+
         {/* API Key */}
         <section className="card p-6">
           <h3 className="font-serif text-lg font-medium text-primary mb-4">
@@ -452,53 +735,98 @@ export default function SettingsView() {
                 No models available
               </p>
             ) : (
-              models.map((model) => (
-                <div
-                  key={model.name}
-                  className={`flex items-center justify-between p-4 rounded-lg border transition-colors ${
-                    config.whisper_model === model.name
-                      ? "bg-accent-600/10 border-accent-600"
-                      : "border-dark-800 hover:bg-dark-800/50"
-                  }`}
-                >
-                  <div className="flex items-center gap-4">
-                    {/* Select radio */}
-                    <input
-                      type="radio"
-                      name="active_model"
-                      checked={config.whisper_model === model.name}
-                      onChange={() =>
-                        model.downloaded && handleModelSelect(model.name)
-                      }
-                      disabled={!model.downloaded || saving === "model"}
-                      className="w-4 h-4 text-accent-600 focus:ring-accent-600"
-                    />
-                    <div>
-                      <p className="text-dark-200 capitalize font-medium">
-                        {model.name}
-                        {config.whisper_model === model.name && (
-                          <span className="ml-2 text-xs text-accent-400">
-                            (Active)
-                          </span>
-                        )}
-                      </p>
-                      <p className="text-sm text-dark-500">
-                        {model.description} • {formatSize(model.size)}
-                      </p>
+              models.map((model, i) => (
+                <div key={model.name}>
+                  {(i === 0 || models[i - 1].tier !== model.tier) && (
+                    <h3 className="text-xs font-medium text-dark-500 uppercase tracking-wider pt-2 pb-1">
+                      {model.tier}
+                    </h3>
+                  )}
+                  <div
+                    className={`flex items-center justify-between p-4 rounded-lg border transition-colors ${
+                      config.whisper_model === model.name
+                        ? "bg-accent-600/10 border-accent-600"
+                        : "border-dark-800 hover:bg-dark-800/50"
+                    }`}
+                  >
+                    <div className="flex items-center gap-4">
+                      {/* Select radio */}
+                      <input
+                        type="radio"
+                        name="active_model"
+                        checked={config.whisper_model === model.name}
+                        onChange={() =>
+                          model.downloaded && handleModelSelect(model.name)
+                        }
+                        disabled={!model.downloaded || saving === "model"}
+                        className="w-4 h-4 text-accent-600 focus:ring-accent-600"
+                      />
+                      <div>
+                        <p className="text-dark-200 capitalize font-medium">
+                          {model.name}
+                          {config.whisper_model === model.name && (
+                            <span className="ml-2 text-xs text-accent-400">
+                              (Active)
+                            </span>
+                          )}
+                        </p>
+                        <p className="text-sm text-dark-500">
+                          {model.description} • {formatSize(model.size)}
+                        </p>
+                      </div>
                     </div>
-                  </div>
 
-                  <div className="flex items-center gap-2">
-                    {model.downloaded ? (
-                      <>
-                        <span className="text-xs text-idle">✓ Downloaded</span>
-                        {config.whisper_model !== model.name && (
+                    <div className="flex items-center gap-2">
+                      {model.downloaded && downloading !== model.name ? (
+                        <>
+                          <span className="text-xs text-idle">✓ Downloaded</span>
+                          <button
+                            onClick={() => handleRedownloadModel(model.name)}
+                            disabled={downloading !== null}
+                            className="px-2 py-1 text-xs text-dark-400 hover:text-dark-200 hover:bg-dark-700 rounded transition-colors disabled:opacity-50"
+                            title="Replace a corrupt model with a fresh download"
+                          >
+                            Re-download
+                          </button>
+                          {config.whisper_model !== model.name && (
+                            <button
+                              onClick={() => {
+                                startDeleteModel(model.name);
+                              }}
+                              className="p-1.5 text-dark-500 hover:text-red-400 hover:bg-red-900/20 rounded transition-colors"
+                              title="Delete model"
+                            >
+                              <svg
+                                className="w-4 h-4"
+                                fill="none"
+                                stroke="currentColor"
+                                viewBox="0 0 24 24"
+                              >
+                                <path
+                                  strokeLinecap="round"
+                                  strokeLinejoin="round"
+                                  strokeWidth={2}
+                                  d="M19 7l-.867 12.142A2 2 0 0116.138 21H7.862a2 2 0 01-1.995-1.858L5 7m5 4v6m4-6v6m1-10V4a1 1 0 00-1-1h-4a1 1 0 00-1 1v3M4 7h16"
+                                />
+                              </svg>
+                            </button>
+                          )}
+                        </>
+                      ) : downloading === model.name ? (
+                        <div className="flex items-center gap-2">
+                          <div className="w-16 h-2 bg-dark-700 rounded-full overflow-hidden">
+                            <div
+                              className="h-full bg-accent-500 transition-all duration-300"
+                              style={{ width: `${downloadProgress}%` }}
+                            />
+                          </div>
+                          <span className="text-xs text-dark-400 w-8">
+                            {downloadProgress}%
+                          </span>
                           <button
-                            onClick={() => {
-                              startDeleteModel(model.name);
-                            }}
-                            className="p-1.5 text-dark-500 hover:text-red-400 hover:bg-red-900/20 rounded transition-colors"
-                            title="Delete model"
+                            onClick={handleCancelDownload}
+                            className="p-1 text-dark-500 hover:text-red-400 hover:bg-red-900/20 rounded transition-colors"
+                            title="Cancel download"
                           >
                             <svg
                               className="w-4 h-4"
@@ -510,51 +838,30 @@ export default function SettingsView() {
                                 strokeLinecap="round"
                                 strokeLinejoin="round"
                                 strokeWidth={2}
-                                d="M19 7l-.867 12.142A2 2 0 0116.138 21H7.862a2 2 0 01-1.995-1.858L5 7m5 4v6m4-6v6m1-10V4a1 1 0 00-1-1h-4a1 1 0 00-1 1v3M4 7h16"
+                                d="M6 18L18 6M6 6l12 12"
                               />
                             </svg>
                           </button>
-                        )}
-                      </>
-                    ) : downloading === model.name ? (
-                      <div className="flex items-center gap-2">
-                        <div className="w-16 h-2 bg-dark-700 rounded-full overflow-hidden">
-                          <div
-                            className="h-full bg-accent-500 transition-all duration-300"
-                            style={{ width: `${downloadProgress}%` }}
-                          />
                         </div>
-                        <span className="text-xs text-dark-400 w-8">
-                          {downloadProgress}%
-                        </span>
-                        <button
-                          onClick={handleCancelDownload}
-                          className="p-1 text-dark-500 hover:text-red-400 hover:bg-red-900/20 rounded transition-colors"
-                          title="Cancel download"
-                        >
-                          <svg
-                            className="w-4 h-4"
-                            fill="none"
-                            stroke="currentColor"
-                            viewBox="0 0 24 24"
+                      ) : (
+                        <div className="flex items-center gap-2">
+                          <button
+                            onClick={() => handleImportModel(model.name)}
+                            disabled={saving === "import"}
+                            className="px-3 py-1.5 text-xs bg-dark-700 hover:bg-dark-600 text-dark-200 rounded-lg transition-colors disabled:opacity-50"
+                            title="Use a model file you already have"
                           >
-                            <path
-                              strokeLinecap="round"
-                              strokeLinejoin="round"
-                              strokeWidth={2}
-                              d="M6 18L18 6M6 6l12 12"
-                            />
-                          </svg>
-                        </button>
-                      </div>
-                    ) : (
-                      <button
-                        onClick={() => handleDownloadModel(model.name)}
-                        className="px-3 py-1.5 text-xs bg-accent-600 hover:bg-accent-500 text-white rounded-lg transition-colors"
-                      >
-                        Download
-                      </button>
-                    )}
+                            Import
+                          </button>
+                          <button
+                            onClick={() => handleDownloadModel(model.name)}
+                            className="px-3 py-1.5 text-xs bg-accent-600 hover:bg-accent-500 text-white rounded-lg transition-colors"
+                          >
+                            Download
+                          </button>
+                        </div>
+                      )}
+                    </div>
                   </div>
                 </div>
               ))
@@ -568,7 +875,7 @@ export default function SettingsView() {
             Transcription Mode
           </h3>
           <p className="text-sm text-dark-500 mb-4">
-            Choose how Gemini should refine your transcriptions.
+            Choose how your transcriptions should be refined.
           </p>
           <div className="flex gap-4">
             <button
@@ -599,6 +906,334 @@ export default function SettingsView() {
                 Professional, polished
               </p>
             </button>
+            <button
+              onClick={() => handleModeChange("code")}
+              disabled={saving === "mode"}
+              className={`flex-1 p-4 rounded-lg border transition-colors ${
+                config.mode === "code"
+                  ? "bg-accent-600/10 border-accent-600"
+                  : "border-dark-800 hover:bg-dark-800"
+              }`}
+            >
+              <p className="font-medium text-dark-200">Code</p>
+              <p className="text-sm text-dark-500 mt-1">
+                Verbatim, keeps symbols
+              </p>
+            </button>
+            <button
+              onClick={() => handleModeChange("raw")}
+              disabled={saving === "mode"}
+              className={`flex-1 p-4 rounded-lg border transition-colors ${
+                config.mode === "raw"
+                  ? "bg-accent-600/10 border-accent-600"
+                  : "border-dark-800 hover:bg-dark-800"
+              }`}
+            >
+              <p className="font-medium text-dark-200">Raw</p>
+              <p className="text-sm text-dark-500 mt-1">
+                Whisper output, works offline
+              </p>
+            </button>
+          </div>
+          {customModes.length > 0 && (
+            <div className="flex flex-wrap gap-4 mt-4">
+              {customModes.map((m) => (
+                <div
+                  key={m.name}
+                  className={`flex-1 min-w-[10rem] p-4 rounded-lg border transition-colors ${
+                    config.mode === m.name
+                      ? "bg-accent-600/10 border-accent-600"
+                      : "border-dark-800 hover:bg-dark-800"
+                  }`}
+                >
+                  <button
+                    onClick={() => handleModeChange(m.name)}
+                    disabled={saving === "mode"}
+                    className="w-full text-left"
+                  >
+                    <p className="font-medium text-dark-200">{m.name}</p>
+                    <p className="text-sm text-dark-500 mt-1 truncate">
+                      {m.prompt}
+                    </p>
+                  </button>
+                  <div className="flex gap-3 mt-2 text-xs">
+                    <button
+                      onClick={() => {
+                        setModeName(m.name);
+                        setModePrompt(m.prompt);
+                      }}
+                      className="text-dark-400 hover:text-dark-200"
+                    >
+                      Edit
+                    </button>
+                    <button
+                      onClick={() => handleDeleteMode(m.name)}
+                      className="text-dark-500 hover:text-red-400"
+                    >
+                      Delete
+                    </button>
+                  </div>
+                </div>
+              ))}
+            </div>
+          )}
+          <div className="mt-4 space-y-2">
+            <input
+              type="text"
+              value={modeName}
+              onChange={(e) => setModeName(e.target.value)}
+              placeholder="Custom mode name, e.g. email"
+              className="w-full px-4 py-2.5 bg-dark-800 border border-dark-700 rounded-lg
+                       text-dark-200 placeholder-dark-500
+                       focus:outline-none focus:ring-2 focus:ring-accent-600"
+            />
+            <textarea
+              value={modePrompt}
+              onChange={(e) => setModePrompt(e.target.value)}
+              placeholder="Instructions, e.g. Rewrite as a short, friendly email with a greeting and sign-off."
+              rows={3}
+              className="w-full px-4 py-2.5 bg-dark-800 border border-dark-700 rounded-lg
+                       text-dark-200 placeholder-dark-500
+                       focus:outline-none focus:ring-2 focus:ring-accent-600"
+            />
+            <div className="flex items-center gap-3">
+              <button
+                onClick={handleSaveMode}
+                disabled={saving === "customMode" || !modeName || !modePrompt}
+                className="px-4 py-2 text-sm bg-accent-600 hover:bg-accent-500 disabled:opacity-50 text-white rounded-lg transition-colors"
+              >
+                Save Mode
+              </button>
+              {success === "customMode" && (
+                <span className="text-sm text-idle">✓ Saved</span>
+              )}
+              {modeError && (
+                <span className="text-sm text-red-400">{modeError}</span>
+              )}
+            </div>
+          </div>
+        </section>
+
+        {/* Gemini Model */}
+        <section className="p-6 bg-dark-900 rounded-xl border border-dark-800">
+          <h3 className="text-lg font-medium text-dark-200 mb-4">
+            Gemini Model
+          </h3>
+          <p className="text-sm text-dark-500 mb-4">
+            Pro models give higher quality refinement; flash and lite models
+            are faster and cheaper.
+          </p>
+          <div className="flex items-center gap-3">
+            <select
+              value={config.gemini_model}
+              onChange={(e) => handleGeminiModelChange(e.target.value)}
+              disabled={saving === "geminiModel"}
+              className="w-full px-4 py-2.5 bg-dark-800 border border-dark-700 rounded-lg
+                       text-dark-200
+                       focus:outline-none focus:ring-2 focus:ring-accent-600"
+            >
+              {!geminiModels.includes(config.gemini_model) && (
+                <option value={config.gemini_model}>{config.gemini_model}</option>
+              )}
+              {geminiModels.map((name) => (
+                <option key={name} value={name}>
+                  {name}
+                </option>
+              ))}
+            </select>
+            {success === "geminiModel" && (
+              <span className="flex items-center text-sm text-idle whitespace-nowrap">
+                ✓ Saved
+              </span>
+            )}
+          </div>
+        </section>
+
+        {/* Usage */}
+        {usage && (
+          <section className="p-6 bg-dark-900 rounded-xl border border-dark-800">
+            <h3 className="text-lg font-medium text-dark-200 mb-4">
+              Usage This Month
+            </h3>
+            <div className="grid grid-cols-3 gap-4 text-sm">
+              <div>
+                <p className="text-dark-500">Refinements</p>
+                <p className="text-dark-200 font-medium">{usage.requests}</p>
+              </div>
+              <div>
+                <p className="text-dark-500">Tokens</p>
+                <p className="text-dark-200 font-medium">
+                  {usage.total_tokens.toLocaleString()}
+                </p>
+                <p className="text-xs text-dark-500">
+                  {usage.prompt_tokens.toLocaleString()} in /{" "}
+                  {usage.output_tokens.toLocaleString()} out
+                </p>
+              </div>
+              <div>
+                <p className="text-dark-500">Estimated cost</p>
+                <p className="text-dark-200 font-medium">
+                  {usage.cost_known
+                    ? `$${usage.estimated_cost_usd.toFixed(4)}`
+                    : "Unknown"}
+                </p>
+              </div>
+            </div>
+            <p className="text-xs text-dark-500 mt-4">
+              Estimated at {config.gemini_model} list prices. Recordings
+              made in privacy mode aren't counted.
+            </p>
+          </section>
+        )}
+
+        {/* Processing speed */}
+        {stats && stats.count > 0 && (
+          <section className="p-6 bg-dark-900 rounded-xl border border-dark-800">
+            <h3 className="text-lg font-medium text-dark-200 mb-4">
+              Processing Speed
+            </h3>
+            <div className="grid grid-cols-3 gap-4 text-sm">
+              <div>
+                <p className="text-dark-500">Avg recording</p>
+                <p className="text-dark-200 font-medium">
+                  {(stats.avg_audio_ms / 1000).toFixed(1)}s
+                </p>
+                <p className="text-xs text-dark-500">
+                  {(stats.total_audio_ms / 60000).toFixed(1)} min total
+                </p>
+              </div>
+              <div>
+                <p className="text-dark-500">Avg transcription</p>
+                <p className="text-dark-200 font-medium">
+                  {(stats.avg_whisper_ms / 1000).toFixed(2)}s
+                </p>
+              </div>
+              <div>
+                <p className="text-dark-500">Avg refinement</p>
+                <p className="text-dark-200 font-medium">
+                  {(stats.avg_gemini_ms / 1000).toFixed(2)}s
+                </p>
+              </div>
+            </div>
+            <p className="text-xs text-dark-500 mt-4">
+              Over {stats.count} saved dictations.
+            </p>
+          </section>
+        )}
+
+        {/* General */}
+        <section className="p-6 bg-dark-900 rounded-xl border border-dark-800 space-y-3">
+          <h3 className="text-lg font-medium text-dark-200 mb-4">General</h3>
+          <label className="flex items-center gap-3 text-sm text-dark-200">
+            <input
+              type="checkbox"
+              checked={config?.launch_at_login ?? false}
+              disabled={saving === "launchAtLogin"}
+              onChange={(e) => handleLaunchAtLoginChange(e.target.checked)}
+            />
+            Launch voxflow at login
+          </label>
+          <label className="flex items-center gap-3 text-sm text-dark-200">
+            <input
+              type="checkbox"
+              checked={config?.show_tray_icon ?? true}
+              onChange={(e) => handleTrayIconChange(e.target.checked)}
+            />
+            Show icon in the menu bar
+          </label>
+          <label className="flex items-center gap-3 text-sm text-dark-200">
+            <input
+              type="checkbox"
+              checked={config?.play_sounds ?? false}
+              onChange={(e) =>
+                handleSoundCuesChange(
+                  e.target.checked,
+                  config?.sound_volume ?? 0.5
+                )
+              }
+            />
+            Play a sound when recording starts and stops
+          </label>
+          <label className="flex items-center gap-3 text-sm text-dark-200">
+            <input
+              type="checkbox"
+              checked={config?.notify_on_complete ?? false}
+              onChange={(e) => handleNotifyChange(e.target.checked)}
+            />
+            Notify me when a dictation is ready (while voxflow is in the
+            background)
+          </label>
+          {config?.play_sounds && (
+            <input
+              type="range"
+              min={0.05}
+              max={1}
+              step={0.05}
+              value={config.sound_volume}
+              onChange={(e) =>
+                handleSoundCuesChange(true, Number(e.target.value))
+              }
+              className="w-48 ml-7"
+            />
+          )}
+        </section>
+
+        {/* Backup */}
+        <section className="p-6 bg-dark-900 rounded-xl border border-dark-800">
+          <h3 className="text-lg font-medium text-dark-200 mb-4">Backup</h3>
+          <p className="text-sm text-dark-500 mb-4">
+            Save your settings to a file, or restore them from one.
+          </p>
+          <div className="flex flex-wrap gap-2">
+            <button
+              onClick={() => handleExportConfig(false)}
+              disabled={saving === "export"}
+              className="px-4 py-2 rounded-lg border border-dark-800 hover:bg-dark-800 text-dark-200 transition-colors"
+            >
+              {success === "export" ? "Exported!" : "Export settings"}
+            </button>
+            <button
+              onClick={() => handleExportConfig(true)}
+              disabled={saving === "export"}
+              className="px-4 py-2 rounded-lg border border-dark-800 hover:bg-dark-800 text-dark-200 transition-colors"
+            >
+              Export with API key
+            </button>
+            <button
+              onClick={handleImportConfig}
+              disabled={saving === "import-config"}
+              className="px-4 py-2 rounded-lg border border-dark-800 hover:bg-dark-800 text-dark-200 transition-colors"
+            >
+              {success === "import-config" ? "Imported!" : "Import settings"}
+            </button>
+          </div>
+        </section>
+
+        {/* Diagnostics */}
+        <section className="p-6 bg-dark-900 rounded-xl border border-dark-800">
+          <h3 className="text-lg font-medium text-dark-200 mb-4">
+            Diagnostics
+          </h3>
+          <p className="text-sm text-dark-500 mb-4">
+            Copy status, settings (without your API key), recent errors and
+            the log to attach to a bug report. The log never contains your
+            dictations.
+          </p>
+          <div className="flex gap-2">
+            <button
+              onClick={handleCopyDiagnostics}
+              disabled={saving === "diagnostics"}
+              className="px-4 py-2 rounded-lg border border-dark-800 hover:bg-dark-800 text-dark-200 transition-colors"
+            >
+              {success === "diagnostics" ? "Copied!" : "Copy diagnostics"}
+            </button>
+            <button
+              onClick={handleClearLog}
+              disabled={saving === "clearLog"}
+              className="px-4 py-2 rounded-lg border border-dark-800 hover:bg-dark-800 text-dark-200 transition-colors"
+            >
+              {success === "clearLog" ? "Cleared!" : "Clear log"}
+            </button>
           </div>
         </section>
       </div>
@@ -640,6 +1275,7 @@ export default function SettingsView() {
         onClose={() => setHotkeyModalOpen(false)}
         onSave={handleHotkeySave}
         initialValue={activeHotkeyValue}
+        validate={validateHotkey}
       />
     </div>
   );
@@ -1,14 +1,52 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
+import {main} from '../models';
 import {whisper} from '../models';
 import {history} from '../models';
+import {gemini} from '../models';
+import {audio} from '../models';
+import {config} from '../models';
+
+export function AbortProcessing():Promise<void>;
+
+export function AddReplacement(arg1:string,arg2:string):Promise<void>;
+
+export function AddTag(arg1:number,arg2:string):Promise<void>;
+
+export function ApplyConfig(arg1:main.AppConfigView):Promise<void>;
+
+export function BatchReRefine(arg1:Array<number>):Promise<Array<main.BatchResult>>;
+
+export function BenchmarkModels(arg1:string):Promise<Array<whisper.BenchmarkResult>>;
+
+export function CancelBatch():Promise<void>;
+
+export function CancelBenchmark():Promise<void>;
 
 export function CancelDownload():Promise<void>;
 
+export function CheckAccessibilityPermission():Promise<boolean>;
+
 export function ClearAllHistory():Promise<void>;
 
+export function ClearLog():Promise<void>;
+
+export function CollectDiagnostics():Promise<string>;
+
+export function ConfirmInjection(arg1:boolean):Promise<void>;
+
 export function CopyToClipboard(arg1:string):Promise<void>;
 
+export function CycleMode():Promise<string>;
+
+export function DeleteHistoryByApp(arg1:string,arg2:boolean):Promise<number>;
+
+export function DeleteHistoryByDateRange(arg1:string,arg2:string,arg3:boolean):Promise<number>;
+
+export function DeleteHistoryByMode(arg1:string,arg2:boolean):Promise<number>;
+
+export function DeleteMode(arg1:string):Promise<void>;
+
 export function DeleteModelByName(arg1:string):Promise<void>;
 
 export function DeleteTranscript(arg1:number):Promise<void>;
@@ -17,20 +55,70 @@ export function DownloadModel():Promise<void>;
 
 export function DownloadModelByName(arg1:string):Promise<void>;
 
+export function EmergencyStop():Promise<void>;
+
+export function EmptyTrash():Promise<void>;
+
 export function EnsureWhisperCLI():Promise<void>;
 
+export function ExportConfig(arg1:boolean):Promise<string>;
+
 export function GetAllModels():Promise<Array<whisper.ModelInfo>>;
 
-export function GetConfig():Promise<Record<string, any>>;
+export function GetAppearance():Promise<string>;
+
+export function GetAverageLatency():Promise<main.LatencyBreakdown>;
+
+export function GetConfig():Promise<main.AppConfigView>;
+
+export function GetCurrentState():Promise<string>;
+
+export function GetFavorites(arg1:number):Promise<Array<history.Transcript>>;
+
+export function GetGeminiModels():Promise<Array<string>>;
+
+export function GetHistory(arg1:number,arg2:number):Promise<history.Page>;
+
+export function GetHistoryByApp(arg1:string):Promise<Array<history.Transcript>>;
+
+export function GetHistoryByTag(arg1:string):Promise<Array<history.Transcript>>;
+
+export function GetLaunchAtLogin():Promise<boolean>;
+
+export function GetModelsDir():Promise<string>;
+
+export function GetPrivacyMode():Promise<boolean>;
+
+export function GetProcessingStats():Promise<history.Stats>;
 
-export function GetHistory(arg1:number):Promise<Array<history.Transcript>>;
+export function GetRecordingPath(arg1:number):Promise<string>;
+
+export function GetSessionUsage():Promise<gemini.Usage>;
 
 export function GetStatus():Promise<string>;
 
+export function GetSupportedLanguages():Promise<Array<whisper.Language>>;
+
 export function GetTranscript(arg1:number):Promise<history.Transcript>;
 
+export function GetTranscriptSegments(arg1:number):Promise<Array<history.Segment>>;
+
+export function GetTrash():Promise<Array<history.Transcript>>;
+
+export function GetUsageThisMonth():Promise<main.MonthlyUsage>;
+
+export function HasLastRecording():Promise<boolean>;
+
 export function HideMiniMode():Promise<void>;
 
+export function ImportConfig(arg1:string):Promise<void>;
+
+export function ImportModel(arg1:string,arg2:string):Promise<void>;
+
+export function InjectTranscript(arg1:number):Promise<void>;
+
+export function IsDownloading(arg1:string):Promise<boolean>;
+
 export function IsMiniMode():Promise<boolean>;
 
 export function IsModelDownloaded():Promise<boolean>;
@@ -39,32 +127,200 @@ export function IsModelReady():Promise<boolean>;
 
 export function IsWhisperCLIReady():Promise<boolean>;
 
+export function ListInputDevices():Promise<Array<audio.DeviceInfo>>;
+
+export function ListModes():Promise<Array<main.ModeInfo>>;
+
+export function ListReplacements():Promise<Record<string, string>>;
+
+export function OpenAccessibilitySettings():Promise<void>;
+
 export function OpenHistoryWindow():Promise<void>;
 
 export function OpenSettings():Promise<void>;
 
+export function PickConfigFile():Promise<string>;
+
+export function PickModelFile():Promise<string>;
+
 export function Quit():Promise<void>;
 
+export function RedownloadModel(arg1:string):Promise<void>;
+
+export function RemoveReplacement(arg1:string):Promise<void>;
+
+export function RemoveTag(arg1:number,arg2:string):Promise<void>;
+
+export function RenameMode(arg1:string,arg2:string):Promise<void>;
+
+export function ReorderModes(arg1:Array<string>):Promise<void>;
+
+export function ReprocessByMode(arg1:string,arg2:string):Promise<Array<main.BatchResult>>;
+
+export function RestoreTranscript(arg1:number):Promise<void>;
+
+export function RetranscribeWithLanguage(arg1:number,arg2:string):Promise<history.Transcript>;
+
+export function RetryLastRecording():Promise<void>;
+
 export function RetryWithGemini(arg1:number,arg2:string):Promise<string>;
 
+export function SaveConfigExport(arg1:boolean):Promise<string>;
+
+export function SaveMode(arg1:string,arg2:string):Promise<void>;
+
 export function SearchHistory(arg1:string,arg2:number):Promise<Array<history.Transcript>>;
 
 export function SetAPIKey(arg1:string):Promise<void>;
 
+export function SetAbortHotkey(arg1:string):Promise<void>;
+
+export function SetAppearance(arg1:string):Promise<void>;
+
+export function SetBatchConcurrency(arg1:number):Promise<void>;
+
+export function SetBeamSize(arg1:number):Promise<void>;
+
+export function SetBlankAudioRetry(arg1:number,arg2:number):Promise<void>;
+
+export function SetCaseStyle(arg1:string):Promise<void>;
+
+export function SetChunking(arg1:number,arg2:number):Promise<void>;
+
+export function SetCodeSpokenSymbols(arg1:boolean):Promise<void>;
+
+export function SetConfirmBeforeInject(arg1:boolean):Promise<void>;
+
+export function SetConfirmDelete(arg1:boolean):Promise<void>;
+
+export function SetCustomModes(arg1:Array<config.CustomMode>):Promise<void>;
+
+export function SetCycleModeHotkey(arg1:string):Promise<void>;
+
+export function SetDateTimeFormats(arg1:string,arg2:string):Promise<void>;
+
+export function SetDateTokenStage(arg1:string):Promise<void>;
+
+export function SetDoubleTapWindow(arg1:number):Promise<void>;
+
+export function SetDownloadStallTimeout(arg1:number):Promise<void>;
+
+export function SetEncryptHistory(arg1:boolean):Promise<void>;
+
+export function SetFavorite(arg1:number,arg2:boolean):Promise<void>;
+
+export function SetGeminiModel(arg1:string):Promise<void>;
+
+export function SetGeminiRetry(arg1:number,arg2:number):Promise<void>;
+
+export function SetGeminiTimeout(arg1:number):Promise<void>;
+
 export function SetHandsFreeHotkey(arg1:string):Promise<void>;
 
+export function SetHistoryRetention(arg1:number,arg2:number):Promise<void>;
+
 export function SetHotkey(arg1:string):Promise<void>;
 
+export function SetInjectionMode(arg1:string):Promise<void>;
+
+export function SetInputDevice(arg1:string):Promise<void>;
+
+export function SetInputGain(arg1:number):Promise<void>;
+
+export function SetKeepPillDuringProcessing(arg1:boolean):Promise<void>;
+
+export function SetKeepRecordings(arg1:boolean):Promise<void>;
+
+export function SetKeychainAPIKey(arg1:boolean):Promise<void>;
+
+export function SetLanguage(arg1:string):Promise<void>;
+
+export function SetLaunchAtLogin(arg1:boolean):Promise<void>;
+
+export function SetLineEnding(arg1:string):Promise<void>;
+
+export function SetLocalServerEnabled(arg1:boolean):Promise<void>;
+
+export function SetMaxOutputChars(arg1:string,arg2:number):Promise<void>;
+
+export function SetMaxRecordingDuration(arg1:number):Promise<void>;
+
 export function SetMode(arg1:string):Promise<void>;
 
+export function SetModelsDir(arg1:string):Promise<void>;
+
+export function SetNotifyOnComplete(arg1:boolean):Promise<void>;
+
+export function SetOpenAIEndpoint(arg1:string,arg2:string,arg3:string):Promise<void>;
+
+export function SetPTTMinHold(arg1:number):Promise<void>;
+
+export function SetPasteDelays(arg1:number,arg2:number,arg3:number,arg4:boolean):Promise<void>;
+
+export function SetPasteRetries(arg1:number):Promise<void>;
+
+export function SetPillOpacity(arg1:number):Promise<void>;
+
+export function SetPreRoll(arg1:number):Promise<void>;
+
+export function SetPrivacyClearClipboard(arg1:boolean):Promise<void>;
+
+export function SetPrivacyMode(arg1:boolean):Promise<void>;
+
+export function SetProxy(arg1:string):Promise<void>;
+
 export function SetPushToTalkHotkey(arg1:string):Promise<void>;
 
+export function SetQuickNoteHotkey(arg1:string):Promise<void>;
+
+export function SetQuitWhileBusy(arg1:string):Promise<void>;
+
+export function SetRecordingRetention(arg1:number,arg2:number):Promise<void>;
+
+export function SetRecordingsDir(arg1:string):Promise<void>;
+
+export function SetRefinementBackend(arg1:string):Promise<void>;
+
+export function SetRefinementEnabled(arg1:boolean):Promise<void>;
+
+export function SetReinjectDelay(arg1:number):Promise<void>;
+
+export function SetReplacements(arg1:Record<string, string>):Promise<void>;
+
+export function SetShowTrayIcon(arg1:boolean):Promise<void>;
+
+export function SetSilenceStop(arg1:number,arg2:number):Promise<void>;
+
+export function SetSoundCues(arg1:boolean,arg2:number):Promise<void>;
+
+export function SetToneTagging(arg1:boolean):Promise<void>;
+
+export function SetTranslate(arg1:boolean):Promise<void>;
+
+export function SetTrashRetentionDays(arg1:number):Promise<void>;
+
+export function SetVocabularyPrompt(arg1:string):Promise<void>;
+
 export function SetWhisperModel(arg1:string):Promise<void>;
 
+export function SetWhisperTemperature(arg1:number):Promise<void>;
+
+export function SetWhisperThreads(arg1:number):Promise<void>;
+
 export function ShowMiniMode():Promise<void>;
 
+export function StartQuickNote():Promise<void>;
+
 export function StartRecording():Promise<void>;
 
 export function StopRecording():Promise<void>;
 
+export function TogglePause():Promise<boolean>;
+
 export function ToggleRecording():Promise<string>;
+
+export function UseTestAudio(arg1:string):Promise<void>;
+
+export function ValidateHotkey(arg1:string,arg2:boolean):Promise<void>;
+
+export function VerifyModel(arg1:string):Promise<void>;
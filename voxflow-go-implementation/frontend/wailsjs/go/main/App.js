@@ -2,18 +2,86 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function AbortProcessing() {
+  return window['go']['main']['App']['AbortProcessing']();
+}
+
+export function AddReplacement(arg1, arg2) {
+  return window['go']['main']['App']['AddReplacement'](arg1, arg2);
+}
+
+export function AddTag(arg1, arg2) {
+  return window['go']['main']['App']['AddTag'](arg1, arg2);
+}
+
+export function ApplyConfig(arg1) {
+  return window['go']['main']['App']['ApplyConfig'](arg1);
+}
+
+export function BatchReRefine(arg1) {
+  return window['go']['main']['App']['BatchReRefine'](arg1);
+}
+
+export function BenchmarkModels(arg1) {
+  return window['go']['main']['App']['BenchmarkModels'](arg1);
+}
+
+export function CancelBatch() {
+  return window['go']['main']['App']['CancelBatch']();
+}
+
+export function CancelBenchmark() {
+  return window['go']['main']['App']['CancelBenchmark']();
+}
+
 export function CancelDownload() {
   return window['go']['main']['App']['CancelDownload']();
 }
 
+export function CheckAccessibilityPermission() {
+  return window['go']['main']['App']['CheckAccessibilityPermission']();
+}
+
 export function ClearAllHistory() {
   return window['go']['main']['App']['ClearAllHistory']();
 }
 
+export function ClearLog() {
+  return window['go']['main']['App']['ClearLog']();
+}
+
+export function CollectDiagnostics() {
+  return window['go']['main']['App']['CollectDiagnostics']();
+}
+
+export function ConfirmInjection(arg1) {
+  return window['go']['main']['App']['ConfirmInjection'](arg1);
+}
+
 export function CopyToClipboard(arg1) {
   return window['go']['main']['App']['CopyToClipboard'](arg1);
 }
 
+export function CycleMode() {
+  return window['go']['main']['App']['CycleMode']();
+}
+
+export function DeleteHistoryByApp(arg1, arg2) {
+  return window['go']['main']['App']['DeleteHistoryByApp'](arg1, arg2);
+}
+
+export function DeleteHistoryByDateRange(arg1, arg2, arg3) {
+  return window['go']['main']['App']['DeleteHistoryByDateRange'](arg1, arg2, arg3);
+}
+
+export function DeleteHistoryByMode(arg1, arg2) {
+  return window['go']['main']['App']['DeleteHistoryByMode'](arg1, arg2);
+}
+
+export function DeleteMode(arg1) {
+  return window['go']['main']['App']['DeleteMode'](arg1);
+}
+
 export function DeleteModelByName(arg1) {
   return window['go']['main']['App']['DeleteModelByName'](arg1);
 }
@@ -30,34 +98,134 @@ export function DownloadModelByName(arg1) {
   return window['go']['main']['App']['DownloadModelByName'](arg1);
 }
 
+export function EmergencyStop() {
+  return window['go']['main']['App']['EmergencyStop']();
+}
+
+export function EmptyTrash() {
+  return window['go']['main']['App']['EmptyTrash']();
+}
+
 export function EnsureWhisperCLI() {
   return window['go']['main']['App']['EnsureWhisperCLI']();
 }
 
+export function ExportConfig(arg1) {
+  return window['go']['main']['App']['ExportConfig'](arg1);
+}
+
 export function GetAllModels() {
   return window['go']['main']['App']['GetAllModels']();
 }
 
+export function GetAppearance() {
+  return window['go']['main']['App']['GetAppearance']();
+}
+
+export function GetAverageLatency() {
+  return window['go']['main']['App']['GetAverageLatency']();
+}
+
 export function GetConfig() {
   return window['go']['main']['App']['GetConfig']();
 }
 
-export function GetHistory(arg1) {
-  return window['go']['main']['App']['GetHistory'](arg1);
+export function GetCurrentState() {
+  return window['go']['main']['App']['GetCurrentState']();
+}
+
+export function GetFavorites(arg1) {
+  return window['go']['main']['App']['GetFavorites'](arg1);
+}
+
+export function GetGeminiModels() {
+  return window['go']['main']['App']['GetGeminiModels']();
+}
+
+export function GetHistory(arg1, arg2) {
+  return window['go']['main']['App']['GetHistory'](arg1, arg2);
+}
+
+export function GetHistoryByApp(arg1) {
+  return window['go']['main']['App']['GetHistoryByApp'](arg1);
+}
+
+export function GetHistoryByTag(arg1) {
+  return window['go']['main']['App']['GetHistoryByTag'](arg1);
+}
+
+export function GetLaunchAtLogin() {
+  return window['go']['main']['App']['GetLaunchAtLogin']();
+}
+
+export function GetModelsDir() {
+  return window['go']['main']['App']['GetModelsDir']();
+}
+
+export function GetPrivacyMode() {
+  return window['go']['main']['App']['GetPrivacyMode']();
+}
+
+export function GetProcessingStats() {
+  return window['go']['main']['App']['GetProcessingStats']();
+}
+
+export function GetRecordingPath(arg1) {
+  return window['go']['main']['App']['GetRecordingPath'](arg1);
+}
+
+export function GetSessionUsage() {
+  return window['go']['main']['App']['GetSessionUsage']();
 }
 
 export function GetStatus() {
   return window['go']['main']['App']['GetStatus']();
 }
 
+export function GetSupportedLanguages() {
+  return window['go']['main']['App']['GetSupportedLanguages']();
+}
+
 export function GetTranscript(arg1) {
   return window['go']['main']['App']['GetTranscript'](arg1);
 }
 
+export function GetTranscriptSegments(arg1) {
+  return window['go']['main']['App']['GetTranscriptSegments'](arg1);
+}
+
+export function GetTrash() {
+  return window['go']['main']['App']['GetTrash']();
+}
+
+export function GetUsageThisMonth() {
+  return window['go']['main']['App']['GetUsageThisMonth']();
+}
+
+export function HasLastRecording() {
+  return window['go']['main']['App']['HasLastRecording']();
+}
+
 export function HideMiniMode() {
   return window['go']['main']['App']['HideMiniMode']();
 }
 
+export function ImportConfig(arg1) {
+  return window['go']['main']['App']['ImportConfig'](arg1);
+}
+
+export function ImportModel(arg1, arg2) {
+  return window['go']['main']['App']['ImportModel'](arg1, arg2);
+}
+
+export function InjectTranscript(arg1) {
+  return window['go']['main']['App']['InjectTranscript'](arg1);
+}
+
+export function IsDownloading(arg1) {
+  return window['go']['main']['App']['IsDownloading'](arg1);
+}
+
 export function IsMiniMode() {
   return window['go']['main']['App']['IsMiniMode']();
 }
@@ -74,6 +242,22 @@ export function IsWhisperCLIReady() {
   return window['go']['main']['App']['IsWhisperCLIReady']();
 }
 
+export function ListInputDevices() {
+  return window['go']['main']['App']['ListInputDevices']();
+}
+
+export function ListModes() {
+  return window['go']['main']['App']['ListModes']();
+}
+
+export function ListReplacements() {
+  return window['go']['main']['App']['ListReplacements']();
+}
+
+export function OpenAccessibilitySettings() {
+  return window['go']['main']['App']['OpenAccessibilitySettings']();
+}
+
 export function OpenHistoryWindow() {
   return window['go']['main']['App']['OpenHistoryWindow']();
 }
@@ -82,14 +266,66 @@ export function OpenSettings() {
   return window['go']['main']['App']['OpenSettings']();
 }
 
+export function PickConfigFile() {
+  return window['go']['main']['App']['PickConfigFile']();
+}
+
+export function PickModelFile() {
+  return window['go']['main']['App']['PickModelFile']();
+}
+
 export function Quit() {
   return window['go']['main']['App']['Quit']();
 }
 
+export function RedownloadModel(arg1) {
+  return window['go']['main']['App']['RedownloadModel'](arg1);
+}
+
+export function RemoveReplacement(arg1) {
+  return window['go']['main']['App']['RemoveReplacement'](arg1);
+}
+
+export function RemoveTag(arg1, arg2) {
+  return window['go']['main']['App']['RemoveTag'](arg1, arg2);
+}
+
+export function RenameMode(arg1, arg2) {
+  return window['go']['main']['App']['RenameMode'](arg1, arg2);
+}
+
+export function ReorderModes(arg1) {
+  return window['go']['main']['App']['ReorderModes'](arg1);
+}
+
+export function ReprocessByMode(arg1, arg2) {
+  return window['go']['main']['App']['ReprocessByMode'](arg1, arg2);
+}
+
+export function RestoreTranscript(arg1) {
+  return window['go']['main']['App']['RestoreTranscript'](arg1);
+}
+
+export function RetranscribeWithLanguage(arg1, arg2) {
+  return window['go']['main']['App']['RetranscribeWithLanguage'](arg1, arg2);
+}
+
+export function RetryLastRecording() {
+  return window['go']['main']['App']['RetryLastRecording']();
+}
+
 export function RetryWithGemini(arg1, arg2) {
   return window['go']['main']['App']['RetryWithGemini'](arg1, arg2);
 }
 
+export function SaveConfigExport(arg1) {
+  return window['go']['main']['App']['SaveConfigExport'](arg1);
+}
+
+export function SaveMode(arg1, arg2) {
+  return window['go']['main']['App']['SaveMode'](arg1, arg2);
+}
+
 export function SearchHistory(arg1, arg2) {
   return window['go']['main']['App']['SearchHistory'](arg1, arg2);
 }
@@ -98,30 +334,282 @@ export function SetAPIKey(arg1) {
   return window['go']['main']['App']['SetAPIKey'](arg1);
 }
 
+export function SetAbortHotkey(arg1) {
+  return window['go']['main']['App']['SetAbortHotkey'](arg1);
+}
+
+export function SetAppearance(arg1) {
+  return window['go']['main']['App']['SetAppearance'](arg1);
+}
+
+export function SetBatchConcurrency(arg1) {
+  return window['go']['main']['App']['SetBatchConcurrency'](arg1);
+}
+
+export function SetBeamSize(arg1) {
+  return window['go']['main']['App']['SetBeamSize'](arg1);
+}
+
+export function SetBlankAudioRetry(arg1, arg2) {
+  return window['go']['main']['App']['SetBlankAudioRetry'](arg1, arg2);
+}
+
+export function SetCaseStyle(arg1) {
+  return window['go']['main']['App']['SetCaseStyle'](arg1);
+}
+
+export function SetChunking(arg1, arg2) {
+  return window['go']['main']['App']['SetChunking'](arg1, arg2);
+}
+
+export function SetCodeSpokenSymbols(arg1) {
+  return window['go']['main']['App']['SetCodeSpokenSymbols'](arg1);
+}
+
+export function SetConfirmBeforeInject(arg1) {
+  return window['go']['main']['App']['SetConfirmBeforeInject'](arg1);
+}
+
+export function SetConfirmDelete(arg1) {
+  return window['go']['main']['App']['SetConfirmDelete'](arg1);
+}
+
+export function SetCustomModes(arg1) {
+  return window['go']['main']['App']['SetCustomModes'](arg1);
+}
+
+export function SetCycleModeHotkey(arg1) {
+  return window['go']['main']['App']['SetCycleModeHotkey'](arg1);
+}
+
+export function SetDateTimeFormats(arg1, arg2) {
+  return window['go']['main']['App']['SetDateTimeFormats'](arg1, arg2);
+}
+
+export function SetDateTokenStage(arg1) {
+  return window['go']['main']['App']['SetDateTokenStage'](arg1);
+}
+
+export function SetDoubleTapWindow(arg1) {
+  return window['go']['main']['App']['SetDoubleTapWindow'](arg1);
+}
+
+export function SetDownloadStallTimeout(arg1) {
+  return window['go']['main']['App']['SetDownloadStallTimeout'](arg1);
+}
+
+export function SetEncryptHistory(arg1) {
+  return window['go']['main']['App']['SetEncryptHistory'](arg1);
+}
+
+export function SetFavorite(arg1, arg2) {
+  return window['go']['main']['App']['SetFavorite'](arg1, arg2);
+}
+
+export function SetGeminiModel(arg1) {
+  return window['go']['main']['App']['SetGeminiModel'](arg1);
+}
+
+export function SetGeminiRetry(arg1, arg2) {
+  return window['go']['main']['App']['SetGeminiRetry'](arg1, arg2);
+}
+
+export function SetGeminiTimeout(arg1) {
+  return window['go']['main']['App']['SetGeminiTimeout'](arg1);
+}
+
 export function SetHandsFreeHotkey(arg1) {
   return window['go']['main']['App']['SetHandsFreeHotkey'](arg1);
 }
 
+export function SetHistoryRetention(arg1, arg2) {
+  return window['go']['main']['App']['SetHistoryRetention'](arg1, arg2);
+}
+
 export function SetHotkey(arg1) {
   return window['go']['main']['App']['SetHotkey'](arg1);
 }
 
+export function SetInjectionMode(arg1) {
+  return window['go']['main']['App']['SetInjectionMode'](arg1);
+}
+
+export function SetInputDevice(arg1) {
+  return window['go']['main']['App']['SetInputDevice'](arg1);
+}
+
+export function SetInputGain(arg1) {
+  return window['go']['main']['App']['SetInputGain'](arg1);
+}
+
+export function SetKeepPillDuringProcessing(arg1) {
+  return window['go']['main']['App']['SetKeepPillDuringProcessing'](arg1);
+}
+
+export function SetKeepRecordings(arg1) {
+  return window['go']['main']['App']['SetKeepRecordings'](arg1);
+}
+
+export function SetKeychainAPIKey(arg1) {
+  return window['go']['main']['App']['SetKeychainAPIKey'](arg1);
+}
+
+export function SetLanguage(arg1) {
+  return window['go']['main']['App']['SetLanguage'](arg1);
+}
+
+export function SetLaunchAtLogin(arg1) {
+  return window['go']['main']['App']['SetLaunchAtLogin'](arg1);
+}
+
+export function SetLineEnding(arg1) {
+  return window['go']['main']['App']['SetLineEnding'](arg1);
+}
+
+export function SetLocalServerEnabled(arg1) {
+  return window['go']['main']['App']['SetLocalServerEnabled'](arg1);
+}
+
+export function SetMaxOutputChars(arg1, arg2) {
+  return window['go']['main']['App']['SetMaxOutputChars'](arg1, arg2);
+}
+
+export function SetMaxRecordingDuration(arg1) {
+  return window['go']['main']['App']['SetMaxRecordingDuration'](arg1);
+}
+
 export function SetMode(arg1) {
   return window['go']['main']['App']['SetMode'](arg1);
 }
 
+export function SetModelsDir(arg1) {
+  return window['go']['main']['App']['SetModelsDir'](arg1);
+}
+
+export function SetNotifyOnComplete(arg1) {
+  return window['go']['main']['App']['SetNotifyOnComplete'](arg1);
+}
+
+export function SetOpenAIEndpoint(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SetOpenAIEndpoint'](arg1, arg2, arg3);
+}
+
+export function SetPTTMinHold(arg1) {
+  return window['go']['main']['App']['SetPTTMinHold'](arg1);
+}
+
+export function SetPasteDelays(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['SetPasteDelays'](arg1, arg2, arg3, arg4);
+}
+
+export function SetPasteRetries(arg1) {
+  return window['go']['main']['App']['SetPasteRetries'](arg1);
+}
+
+export function SetPillOpacity(arg1) {
+  return window['go']['main']['App']['SetPillOpacity'](arg1);
+}
+
+export function SetPreRoll(arg1) {
+  return window['go']['main']['App']['SetPreRoll'](arg1);
+}
+
+export function SetPrivacyClearClipboard(arg1) {
+  return window['go']['main']['App']['SetPrivacyClearClipboard'](arg1);
+}
+
+export function SetPrivacyMode(arg1) {
+  return window['go']['main']['App']['SetPrivacyMode'](arg1);
+}
+
+export function SetProxy(arg1) {
+  return window['go']['main']['App']['SetProxy'](arg1);
+}
+
 export function SetPushToTalkHotkey(arg1) {
   return window['go']['main']['App']['SetPushToTalkHotkey'](arg1);
 }
 
+export function SetQuickNoteHotkey(arg1) {
+  return window['go']['main']['App']['SetQuickNoteHotkey'](arg1);
+}
+
+export function SetQuitWhileBusy(arg1) {
+  return window['go']['main']['App']['SetQuitWhileBusy'](arg1);
+}
+
+export function SetRecordingRetention(arg1, arg2) {
+  return window['go']['main']['App']['SetRecordingRetention'](arg1, arg2);
+}
+
+export function SetRecordingsDir(arg1) {
+  return window['go']['main']['App']['SetRecordingsDir'](arg1);
+}
+
+export function SetRefinementBackend(arg1) {
+  return window['go']['main']['App']['SetRefinementBackend'](arg1);
+}
+
+export function SetRefinementEnabled(arg1) {
+  return window['go']['main']['App']['SetRefinementEnabled'](arg1);
+}
+
+export function SetReinjectDelay(arg1) {
+  return window['go']['main']['App']['SetReinjectDelay'](arg1);
+}
+
+export function SetReplacements(arg1) {
+  return window['go']['main']['App']['SetReplacements'](arg1);
+}
+
+export function SetShowTrayIcon(arg1) {
+  return window['go']['main']['App']['SetShowTrayIcon'](arg1);
+}
+
+export function SetSilenceStop(arg1, arg2) {
+  return window['go']['main']['App']['SetSilenceStop'](arg1, arg2);
+}
+
+export function SetSoundCues(arg1, arg2) {
+  return window['go']['main']['App']['SetSoundCues'](arg1, arg2);
+}
+
+export function SetToneTagging(arg1) {
+  return window['go']['main']['App']['SetToneTagging'](arg1);
+}
+
+export function SetTranslate(arg1) {
+  return window['go']['main']['App']['SetTranslate'](arg1);
+}
+
+export function SetTrashRetentionDays(arg1) {
+  return window['go']['main']['App']['SetTrashRetentionDays'](arg1);
+}
+
+export function SetVocabularyPrompt(arg1) {
+  return window['go']['main']['App']['SetVocabularyPrompt'](arg1);
+}
+
 export function SetWhisperModel(arg1) {
   return window['go']['main']['App']['SetWhisperModel'](arg1);
 }
 
+export function SetWhisperTemperature(arg1) {
+  return window['go']['main']['App']['SetWhisperTemperature'](arg1);
+}
+
+export function SetWhisperThreads(arg1) {
+  return window['go']['main']['App']['SetWhisperThreads'](arg1);
+}
+
 export function ShowMiniMode() {
   return window['go']['main']['App']['ShowMiniMode']();
 }
 
+export function StartQuickNote() {
+  return window['go']['main']['App']['StartQuickNote']();
+}
+
 export function StartRecording() {
   return window['go']['main']['App']['StartRecording']();
 }
@@ -130,6 +618,22 @@ export function StopRecording() {
   return window['go']['main']['App']['StopRecording']();
 }
 
+export function TogglePause() {
+  return window['go']['main']['App']['TogglePause']();
+}
+
 export function ToggleRecording() {
   return window['go']['main']['App']['ToggleRecording']();
 }
+
+export function UseTestAudio(arg1) {
+  return window['go']['main']['App']['UseTestAudio'](arg1);
+}
+
+export function ValidateHotkey(arg1, arg2) {
+  return window['go']['main']['App']['ValidateHotkey'](arg1, arg2);
+}
+
+export function VerifyModel(arg1) {
+  return window['go']['main']['App']['VerifyModel'](arg1);
+}
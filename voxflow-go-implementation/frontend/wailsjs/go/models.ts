@@ -1,3 +1,68 @@
+export namespace audio {
+	
+	export class DeviceInfo {
+	    index: number;
+	    name: string;
+	    max_input_channels: number;
+	    default_sample_rate: number;
+	    is_default: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new DeviceInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.index = source["index"];
+	        this.name = source["name"];
+	        this.max_input_channels = source["max_input_channels"];
+	        this.default_sample_rate = source["default_sample_rate"];
+	        this.is_default = source["is_default"];
+	    }
+	}
+
+}
+
+export namespace config {
+	
+	export class CustomMode {
+	    name: string;
+	    prompt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CustomMode(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.prompt = source["prompt"];
+	    }
+	}
+
+}
+
+export namespace gemini {
+	
+	export class Usage {
+	    prompt_tokens: number;
+	    output_tokens: number;
+	    total_tokens: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Usage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.prompt_tokens = source["prompt_tokens"];
+	        this.output_tokens = source["output_tokens"];
+	        this.total_tokens = source["total_tokens"];
+	    }
+	}
+
+}
+
 export namespace history {
 	
 	export class Transcript {
@@ -8,6 +73,19 @@ export namespace history {
 	    raw_text: string;
 	    polished_text: string;
 	    mode: string;
+	    tone: string;
+	    language: string;
+	    translated: boolean;
+	    // Go type: time
+	    deleted_at: any;
+	    prompt_tokens: number;
+	    output_tokens: number;
+	    favorite: boolean;
+	    tags: string[];
+	    audio_ms: number;
+	    whisper_ms: number;
+	    gemini_ms: number;
+	    unreadable: boolean;
 	
 	    static createFrom(source: any = {}) {
 	        return new Transcript(source);
@@ -21,6 +99,18 @@ export namespace history {
 	        this.raw_text = source["raw_text"];
 	        this.polished_text = source["polished_text"];
 	        this.mode = source["mode"];
+	        this.tone = source["tone"];
+	        this.language = source["language"];
+	        this.translated = source["translated"];
+	        this.deleted_at = this.convertValues(source["deleted_at"], null);
+	        this.prompt_tokens = source["prompt_tokens"];
+	        this.output_tokens = source["output_tokens"];
+	        this.favorite = source["favorite"];
+	        this.tags = source["tags"];
+	        this.audio_ms = source["audio_ms"];
+	        this.whisper_ms = source["whisper_ms"];
+	        this.gemini_ms = source["gemini_ms"];
+	        this.unreadable = source["unreadable"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -41,14 +131,376 @@ export namespace history {
 		    return a;
 		}
 	}
+	export class Page {
+	    transcripts: Transcript[];
+	    total: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Page(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.transcripts = this.convertValues(source["transcripts"], Transcript);
+	        this.total = source["total"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Stats {
+	    count: number;
+	    total_audio_ms: number;
+	    total_whisper_ms: number;
+	    total_gemini_ms: number;
+	    avg_audio_ms: number;
+	    avg_whisper_ms: number;
+	    avg_gemini_ms: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Stats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.count = source["count"];
+	        this.total_audio_ms = source["total_audio_ms"];
+	        this.total_whisper_ms = source["total_whisper_ms"];
+	        this.total_gemini_ms = source["total_gemini_ms"];
+	        this.avg_audio_ms = source["avg_audio_ms"];
+	        this.avg_whisper_ms = source["avg_whisper_ms"];
+	        this.avg_gemini_ms = source["avg_gemini_ms"];
+	    }
+	}
+	export class Segment {
+	    start_ms: number;
+	    end_ms: number;
+	    text: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Segment(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.start_ms = source["start_ms"];
+	        this.end_ms = source["end_ms"];
+	        this.text = source["text"];
+	    }
+	}
+
+}
+
+export namespace main {
+	
+	export class AppConfigView {
+	    version: number;
+	    hotkey: string;
+	    hands_free_hotkey: string;
+	    push_to_talk_hotkey: string;
+	    whisper_model: string;
+	    mode: string;
+	    api_key_set: boolean;
+	    line_ending: string;
+	    date_token_stage: string;
+	    date_format: string;
+	    time_format: string;
+	    max_output_chars: Record<string, number>;
+	    local_server_enabled: boolean;
+	    local_server_port: number;
+	    chunk_seconds: number;
+	    chunk_overlap_seconds: number;
+	    privacy_mode: boolean;
+	    privacy_clear_clipboard: boolean;
+	    custom_modes: config.CustomMode[];
+	    appearance: string;
+	    download_stall_seconds: number;
+	    confirm_before_inject: boolean;
+	    beam_size: number;
+	    whisper_temperature: number;
+	    quit_while_busy: string;
+	    case_style: string;
+	    tone_tagging: boolean;
+	    code_spoken_symbols: boolean;
+	    paste_retries: number;
+	    quick_note_hotkey: string;
+	    keep_pill_during_processing: boolean;
+	    input_device: string;
+	    silence_timeout_seconds: number;
+	    silence_threshold_db: number;
+	    batch_concurrency: number;
+	    max_recording_seconds: number;
+	    confirm_delete: boolean;
+	    trash_retention_days: number;
+	    pre_roll_ms: number;
+	    input_gain: number;
+	    keep_recordings: boolean;
+	    recordings_dir: string;
+	    recording_retention_count: number;
+	    recording_retention_days: number;
+	    language: string;
+	    translate: boolean;
+	    whisper_threads: number;
+	    models_dir: string;
+	    vocabulary_prompt: string;
+	    gemini_model: string;
+	    gemini_max_attempts: number;
+	    gemini_retry_seconds: number;
+	    refinement_backend: string;
+	    openai_base_url: string;
+	    openai_model: string;
+	    openai_api_key_set: boolean;
+	    refinement_enabled: boolean;
+	    replacements: Record<string, string>;
+	    proxy: string;
+	    gemini_timeout_seconds: number;
+	    double_tap_window_ms: number;
+	    ptt_min_hold_ms: number;
+	    cycle_mode_hotkey: string;
+	    abort_hotkey: string;
+	    history_retention_days: number;
+	    history_max_entries: number;
+	    encrypt_history: boolean;
+	    reinject_delay_ms: number;
+	    injection_mode: string;
+	    clipboard_settle_ms: number;
+	    post_paste_ms: number;
+	    clipboard_restore_ms: number;
+	    adaptive_paste: boolean;
+	    pill_opacity: number;
+	    keychain_api_key: boolean;
+	    launch_at_login: boolean;
+	    play_sounds: boolean;
+	    sound_volume: number;
+	    notify_on_complete: boolean;
+	    show_tray_icon: boolean;
+	    blank_audio_retries: number;
+	    blank_audio_retry_delay_ms: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new AppConfigView(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.version = source["version"];
+	        this.hotkey = source["hotkey"];
+	        this.hands_free_hotkey = source["hands_free_hotkey"];
+	        this.push_to_talk_hotkey = source["push_to_talk_hotkey"];
+	        this.whisper_model = source["whisper_model"];
+	        this.mode = source["mode"];
+	        this.api_key_set = source["api_key_set"];
+	        this.line_ending = source["line_ending"];
+	        this.date_token_stage = source["date_token_stage"];
+	        this.date_format = source["date_format"];
+	        this.time_format = source["time_format"];
+	        this.max_output_chars = source["max_output_chars"];
+	        this.local_server_enabled = source["local_server_enabled"];
+	        this.local_server_port = source["local_server_port"];
+	        this.chunk_seconds = source["chunk_seconds"];
+	        this.chunk_overlap_seconds = source["chunk_overlap_seconds"];
+	        this.privacy_mode = source["privacy_mode"];
+	        this.privacy_clear_clipboard = source["privacy_clear_clipboard"];
+	        this.custom_modes = this.convertValues(source["custom_modes"], config.CustomMode);
+	        this.appearance = source["appearance"];
+	        this.download_stall_seconds = source["download_stall_seconds"];
+	        this.confirm_before_inject = source["confirm_before_inject"];
+	        this.beam_size = source["beam_size"];
+	        this.whisper_temperature = source["whisper_temperature"];
+	        this.quit_while_busy = source["quit_while_busy"];
+	        this.case_style = source["case_style"];
+	        this.tone_tagging = source["tone_tagging"];
+	        this.code_spoken_symbols = source["code_spoken_symbols"];
+	        this.paste_retries = source["paste_retries"];
+	        this.quick_note_hotkey = source["quick_note_hotkey"];
+	        this.keep_pill_during_processing = source["keep_pill_during_processing"];
+	        this.input_device = source["input_device"];
+	        this.silence_timeout_seconds = source["silence_timeout_seconds"];
+	        this.silence_threshold_db = source["silence_threshold_db"];
+	        this.batch_concurrency = source["batch_concurrency"];
+	        this.max_recording_seconds = source["max_recording_seconds"];
+	        this.confirm_delete = source["confirm_delete"];
+	        this.trash_retention_days = source["trash_retention_days"];
+	        this.pre_roll_ms = source["pre_roll_ms"];
+	        this.input_gain = source["input_gain"];
+	        this.keep_recordings = source["keep_recordings"];
+	        this.recordings_dir = source["recordings_dir"];
+	        this.recording_retention_count = source["recording_retention_count"];
+	        this.recording_retention_days = source["recording_retention_days"];
+	        this.language = source["language"];
+	        this.translate = source["translate"];
+	        this.whisper_threads = source["whisper_threads"];
+	        this.models_dir = source["models_dir"];
+	        this.vocabulary_prompt = source["vocabulary_prompt"];
+	        this.gemini_model = source["gemini_model"];
+	        this.gemini_max_attempts = source["gemini_max_attempts"];
+	        this.gemini_retry_seconds = source["gemini_retry_seconds"];
+	        this.refinement_backend = source["refinement_backend"];
+	        this.openai_base_url = source["openai_base_url"];
+	        this.openai_model = source["openai_model"];
+	        this.openai_api_key_set = source["openai_api_key_set"];
+	        this.refinement_enabled = source["refinement_enabled"];
+	        this.replacements = source["replacements"];
+	        this.proxy = source["proxy"];
+	        this.gemini_timeout_seconds = source["gemini_timeout_seconds"];
+	        this.double_tap_window_ms = source["double_tap_window_ms"];
+	        this.ptt_min_hold_ms = source["ptt_min_hold_ms"];
+	        this.cycle_mode_hotkey = source["cycle_mode_hotkey"];
+	        this.abort_hotkey = source["abort_hotkey"];
+	        this.history_retention_days = source["history_retention_days"];
+	        this.history_max_entries = source["history_max_entries"];
+	        this.encrypt_history = source["encrypt_history"];
+	        this.reinject_delay_ms = source["reinject_delay_ms"];
+	        this.injection_mode = source["injection_mode"];
+	        this.clipboard_settle_ms = source["clipboard_settle_ms"];
+	        this.post_paste_ms = source["post_paste_ms"];
+	        this.clipboard_restore_ms = source["clipboard_restore_ms"];
+	        this.adaptive_paste = source["adaptive_paste"];
+	        this.pill_opacity = source["pill_opacity"];
+	        this.keychain_api_key = source["keychain_api_key"];
+	        this.launch_at_login = source["launch_at_login"];
+	        this.play_sounds = source["play_sounds"];
+	        this.sound_volume = source["sound_volume"];
+	        this.notify_on_complete = source["notify_on_complete"];
+	        this.show_tray_icon = source["show_tray_icon"];
+	        this.blank_audio_retries = source["blank_audio_retries"];
+	        this.blank_audio_retry_delay_ms = source["blank_audio_retry_delay_ms"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class BatchResult {
+	    id: number;
+	    polished: string;
+	    error: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new BatchResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.polished = source["polished"];
+	        this.error = source["error"];
+	    }
+	}
+	export class LatencyBreakdown {
+	    audio_ms: number;
+	    whisper_ms: number;
+	    gemini_ms: number;
+	    total_ms: number;
+	    samples: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new LatencyBreakdown(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.audio_ms = source["audio_ms"];
+	        this.whisper_ms = source["whisper_ms"];
+	        this.gemini_ms = source["gemini_ms"];
+	        this.total_ms = source["total_ms"];
+	        this.samples = source["samples"];
+	    }
+	}
+	export class MonthlyUsage {
+	    requests: number;
+	    prompt_tokens: number;
+	    output_tokens: number;
+	    total_tokens: number;
+	    estimated_cost_usd: number;
+	    cost_known: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new MonthlyUsage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.requests = source["requests"];
+	        this.prompt_tokens = source["prompt_tokens"];
+	        this.output_tokens = source["output_tokens"];
+	        this.total_tokens = source["total_tokens"];
+	        this.estimated_cost_usd = source["estimated_cost_usd"];
+	        this.cost_known = source["cost_known"];
+	    }
+	}
+	export class ModeInfo {
+	    name: string;
+	    prompt: string;
+	    builtin: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ModeInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.prompt = source["prompt"];
+	        this.builtin = source["builtin"];
+	    }
+	}
 
 }
 
 export namespace whisper {
 	
+	export class BenchmarkResult {
+	    model: string;
+	    duration_ms: number;
+	    text: string;
+	    error: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new BenchmarkResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.model = source["model"];
+	        this.duration_ms = source["duration_ms"];
+	        this.text = source["text"];
+	        this.error = source["error"];
+	    }
+	}
 	export class ModelInfo {
 	    name: string;
 	    description: string;
+	    tier: string;
 	    size: number;
 	    downloaded: boolean;
 	    file_path: string;
@@ -61,11 +513,26 @@ export namespace whisper {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.name = source["name"];
 	        this.description = source["description"];
+	        this.tier = source["tier"];
 	        this.size = source["size"];
 	        this.downloaded = source["downloaded"];
 	        this.file_path = source["file_path"];
 	    }
 	}
+	export class Language {
+	    code: string;
+	    name: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Language(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.code = source["code"];
+	        this.name = source["name"];
+	    }
+	}
 
 }
 
@@ -3,6 +3,7 @@ module voxflow
 go 1.24.0
 
 require (
+	github.com/ggerganov/whisper.cpp/bindings/go v0.0.0-20260924082915-d09f61a708f3
 	github.com/gordonklaus/portaudio v0.0.0-20250206071425-98a94950218b
 	github.com/wailsapp/wails/v2 v2.11.0
 	golang.design/x/clipboard v0.7.1
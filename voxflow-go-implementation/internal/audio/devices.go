@@ -0,0 +1,77 @@
+package audio
+
+import (
+	"fmt"
+
+	"github.com/gordonklaus/portaudio"
+)
+
+// DeviceInfo describes an audio input device
+type DeviceInfo struct {
+	Index             int     `json:"index"`
+	Name              string  `json:"name"`
+	MaxInputChannels  int     `json:"max_input_channels"`
+	DefaultSampleRate float64 `json:"default_sample_rate"`
+	IsDefault         bool    `json:"is_default"`
+}
+
+// ListInputDevices returns all devices with at least one input channel.
+// The device list is refreshed first unless a stream is open.
+func (r *Recorder) ListInputDevices() ([]DeviceInfo, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.recording.Load() && r.preRoll == nil {
+		if _, err := r.refreshDefaultDevice(); err != nil {
+			return nil, err
+		}
+	}
+
+	devices, err := portaudio.Devices()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audio devices: %w", err)
+	}
+	defaultDevice, _ := portaudio.DefaultInputDevice()
+
+	var inputs []DeviceInfo
+	for i, d := range devices {
+		if d.MaxInputChannels <= 0 {
+			continue
+		}
+		inputs = append(inputs, DeviceInfo{
+			Index:             i,
+			Name:              d.Name,
+			MaxInputChannels:  d.MaxInputChannels,
+			DefaultSampleRate: d.DefaultSampleRate,
+			IsDefault:         defaultDevice != nil && d.Name == defaultDevice.Name,
+		})
+	}
+	return inputs, nil
+}
+
+// SetInputDevice selects the input device used by Start, by name.
+// An empty name uses the system default.
+func (r *Recorder) SetInputDevice(name string) {
+	r.mu.Lock()
+	changed := r.inputDevice != name
+	r.inputDevice = name
+	r.mu.Unlock()
+
+	if changed {
+		go r.restartPreRoll()
+	}
+}
+
+// findInputDevice returns the input device with the given name, or nil
+func findInputDevice(name string) (*portaudio.DeviceInfo, error) {
+	devices, err := portaudio.Devices()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audio devices: %w", err)
+	}
+	for _, d := range devices {
+		if d.Name == name && d.MaxInputChannels > 0 {
+			return d, nil
+		}
+	}
+	return nil, nil
+}
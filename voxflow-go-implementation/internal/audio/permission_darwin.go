@@ -0,0 +1,30 @@
+//go:build darwin
+
+package audio
+
+/*
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework AVFoundation
+
+#import <AVFoundation/AVFoundation.h>
+
+int microphoneAuthorizationStatus(void) {
+    return (int)[AVCaptureDevice authorizationStatusForMediaType:AVMediaTypeAudio];
+}
+*/
+import "C"
+
+// MicrophonePermission reports whether voxflow may record from the microphone:
+// "granted", "denied", "restricted" or "not requested"
+func MicrophonePermission() string {
+	switch C.microphoneAuthorizationStatus() {
+	case 3: // AVAuthorizationStatusAuthorized
+		return "granted"
+	case 2: // AVAuthorizationStatusDenied
+		return "denied"
+	case 1: // AVAuthorizationStatusRestricted
+		return "restricted"
+	default:
+		return "not requested"
+	}
+}
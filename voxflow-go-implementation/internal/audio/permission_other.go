@@ -0,0 +1,8 @@
+//go:build !darwin
+
+package audio
+
+// MicrophonePermission is only implemented on macOS
+func MicrophonePermission() string {
+	return "unknown"
+}
@@ -0,0 +1,201 @@
+package audio
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gordonklaus/portaudio"
+)
+
+// ringBuffer keeps the most recent samples, overwriting the oldest
+type ringBuffer struct {
+	data   []int16
+	pos    int // Next write position
+	filled int // Number of valid samples
+}
+
+// newRingBuffer creates a ring buffer holding size samples
+func newRingBuffer(size int) *ringBuffer {
+	return &ringBuffer{data: make([]int16, size)}
+}
+
+// write appends samples, dropping the oldest once full
+func (b *ringBuffer) write(samples []int16) {
+	for _, s := range samples {
+		b.data[b.pos] = s
+		b.pos = (b.pos + 1) % len(b.data)
+	}
+	b.filled = min(b.filled+len(samples), len(b.data))
+}
+
+// snapshot returns the buffered samples, oldest first
+func (b *ringBuffer) snapshot() []int16 {
+	out := make([]int16, 0, b.filled)
+	start := (b.pos - b.filled + len(b.data)) % len(b.data)
+	if start+b.filled <= len(b.data) {
+		return append(out, b.data[start:start+b.filled]...)
+	}
+	out = append(out, b.data[start:]...)
+	return append(out, b.data[:b.pos]...)
+}
+
+// preRollCapture is the idle stream feeding the pre-roll ring buffer
+type preRollCapture struct {
+	stream      *portaudio.Stream
+	inputBuffer []int16
+	inputDevice string      // Recorder.inputDevice when the stream was opened
+	ring        *ringBuffer // Guarded by Recorder.mu
+	failed      bool        // Set once a read fails; the stream is not reused
+	stop        chan struct{}
+	done        chan struct{}
+}
+
+// EnablePreRoll keeps the microphone open while idle and prepends the last d
+// of audio to each recording, so speech that starts just before the hotkey
+// isn't clipped. Older audio is discarded continuously. 0 disables pre-roll.
+func (r *Recorder) EnablePreRoll(d time.Duration) error {
+	if d < 0 {
+		return fmt.Errorf("pre-roll must not be negative")
+	}
+
+	r.preRollMu.Lock()
+	defer r.preRollMu.Unlock()
+
+	r.mu.Lock()
+	r.preRollDuration = d
+	r.mu.Unlock()
+
+	r.stopPreRollLocked()
+	return r.startPreRollLocked()
+}
+
+// startPreRoll resumes idle capture if pre-roll is enabled
+func (r *Recorder) startPreRoll() {
+	r.preRollMu.Lock()
+	defer r.preRollMu.Unlock()
+	if err := r.startPreRollLocked(); err != nil {
+		fmt.Printf("[Audio] Failed to start pre-roll: %v\n", err)
+	}
+}
+
+// restartPreRoll reopens idle capture, e.g. after the input device changed
+func (r *Recorder) restartPreRoll() {
+	r.preRollMu.Lock()
+	defer r.preRollMu.Unlock()
+	r.stopPreRollLocked()
+	if err := r.startPreRollLocked(); err != nil {
+		fmt.Printf("[Audio] Failed to restart pre-roll: %v\n", err)
+	}
+}
+
+// stopPreRoll closes idle capture and discards its audio
+func (r *Recorder) stopPreRoll() {
+	r.preRollMu.Lock()
+	defer r.preRollMu.Unlock()
+	r.stopPreRollLocked()
+}
+
+// startPreRollLocked opens the idle stream. Must be called with r.preRollMu held.
+func (r *Recorder) startPreRollLocked() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.preRollDuration <= 0 || r.preRoll != nil || r.recording.Load() {
+		return nil
+	}
+
+	device, err := r.selectDevice()
+	if err != nil {
+		return err
+	}
+	stream, inputBuffer, err := r.openStream(device)
+	if err != nil {
+		return err
+	}
+
+	capture := &preRollCapture{
+		stream:      stream,
+		inputBuffer: inputBuffer,
+		inputDevice: r.inputDevice,
+		ring:        newRingBuffer(max(int(r.preRollDuration.Seconds()*r.sampleRate), 1)),
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+	r.preRoll = capture
+	go r.preRollLoop(capture)
+
+	fmt.Printf("[Audio] Pre-roll capture started (%v) on %s\n", r.preRollDuration, device.Name)
+	return nil
+}
+
+// stopPreRollLocked closes the idle stream. Must be called with r.preRollMu held.
+func (r *Recorder) stopPreRollLocked() {
+	_, stream, _ := r.takePreRoll()
+	if stream != nil {
+		stream.Stop()
+		stream.Close()
+	}
+}
+
+// takePreRoll stops idle capture and returns its buffered audio. Its stream is
+// handed over too, with the buffer its reads fill, so the recording continues
+// without a gap. The stream is nil, and already closed, if its reads failed
+// (e.g. the device was unplugged) or the input device setting has changed since
+// it was opened; the caller must then re-resolve the device. Must be called
+// with r.preRollMu held.
+func (r *Recorder) takePreRoll() ([]int16, *portaudio.Stream, []int16) {
+	r.mu.Lock()
+	capture := r.preRoll
+	r.preRoll = nil
+	r.mu.Unlock()
+
+	if capture == nil {
+		return nil, nil, nil
+	}
+
+	// The loop takes r.mu to write the ring, so wait without holding it
+	close(capture.stop)
+	<-capture.done
+
+	r.mu.Lock()
+	samples := capture.ring.snapshot()
+	failed := capture.failed
+	stale := capture.inputDevice != r.inputDevice
+	r.mu.Unlock()
+
+	if failed {
+		capture.stream.Close()
+		return samples, nil, nil
+	}
+	if stale {
+		capture.stream.Stop()
+		capture.stream.Close()
+		return samples, nil, nil
+	}
+	return samples, capture.stream, capture.inputBuffer
+}
+
+// preRollLoop reads the idle stream into the ring buffer until stopped
+func (r *Recorder) preRollLoop(capture *preRollCapture) {
+	defer close(capture.done)
+
+	for {
+		select {
+		case <-capture.stop:
+			return
+		default:
+		}
+
+		if err := capture.stream.Read(); err != nil {
+			fmt.Printf("[Audio] Pre-roll read failed, pausing pre-roll until the next recording: %v\n", err)
+			r.mu.Lock()
+			capture.failed = true
+			r.mu.Unlock()
+			return
+		}
+
+		r.mu.Lock()
+		capture.ring.write(capture.inputBuffer)
+		r.mu.Unlock()
+	}
+}
@@ -1,9 +1,13 @@
 package audio
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/binary"
+	"errors"
 	"fmt"
+	"io"
+	"math"
 	"os"
 	"path/filepath"
 	"sync"
@@ -19,21 +23,99 @@ const (
 	FramesPerBuffer = 1024
 )
 
+const (
+	maxConsecutiveReadErrors = 10                    // Read errors in a row before the device is considered lost
+	readErrorBackoff         = 20 * time.Millisecond // Pause between failed reads
+)
+
 // Recorder handles audio capture from the microphone
 type Recorder struct {
 	stream      *portaudio.Stream
 	buffer      []int16
 	mu          sync.Mutex
 	recording   atomic.Bool
+	paused      atomic.Bool  // Stream stays open but samples are dropped
+	muteUntil   atomic.Int64 // Unix nanos until which captured samples are zeroed (see MuteFor)
 	stopChan    chan struct{}
 	stoppedChan chan struct{}
 	sampleRate  float64
+	deviceName  string  // Input device used by the last recording
+	inputDevice string  // Selected input device name ("" = system default)
+	gain        float32 // Software amplification applied to captured samples
+
+	// OnDeviceChange is called from Start when the input device differs from the previous recording
+	OnDeviceChange func(previous, current string)
+
+	// OnDeviceMissing is called from Start when the selected input device is
+	// not present and the system default is used instead
+	OnDeviceMissing func(name string)
+
+	// OnDeviceLost is called when the input device fails mid-recording. The
+	// recording has already ended and its audio is discarded; err is the PortAudio error.
+	OnDeviceLost func(err error)
+
+	// OnLevel receives the RMS and peak amplitude (0-1) of each captured buffer.
+	// It runs on its own goroutine so a slow handler can't stall capture;
+	// levels are dropped while it is busy.
+	OnLevel func(rms, peak float32)
+
+	// Silence auto-stop, only active for recordings armed with ArmSilenceStop
+	SilenceTimeout     time.Duration // Trailing silence that ends the recording (0 = never)
+	SilenceThresholdDB float64       // RMS level (dBFS) below which audio counts as silence
+	OnSilence          func()        // Called once when the timeout is reached
+	silenceArmed       bool
+
+	// Pre-roll: idle capture prepended to each recording (see EnablePreRoll)
+	preRollMu       sync.Mutex // Serializes pre-roll start/stop with Start; taken before mu
+	preRollDuration time.Duration
+	preRoll         *preRollCapture
+
+	// maxDuration caps recording length (0 = unlimited, see SetMaxDuration).
+	// Audio past the cap is dropped and OnMaxDuration is called once.
+	maxDuration   time.Duration
+	OnMaxDuration func()
+}
+
+// silenceTracker detects trailing silence once speech has been heard
+type silenceTracker struct {
+	threshold   float32 // Linear RMS
+	timeout     time.Duration
+	heardSpeech bool
+	silentFor   time.Duration
+	fired       bool
+}
+
+// update records one buffer's level and reports whether the timeout was just reached
+func (t *silenceTracker) update(rms float32, d time.Duration) bool {
+	if t.fired {
+		return false
+	}
+	if rms >= t.threshold {
+		t.heardSpeech = true
+		t.silentFor = 0
+		return false
+	}
+	if !t.heardSpeech {
+		return false // Don't stop before the user has started talking
+	}
+	t.silentFor += d
+	if t.silentFor >= t.timeout {
+		t.fired = true
+		return true
+	}
+	return false
+}
+
+// level is one RMS/peak measurement
+type level struct {
+	rms, peak float32
 }
 
 // NewRecorder creates a new audio recorder
 func NewRecorder() *Recorder {
 	return &Recorder{
 		sampleRate: SampleRate,
+		gain:       1,
 		buffer:     make([]int16, 0),
 	}
 }
@@ -45,58 +127,170 @@ func (r *Recorder) Initialize() error {
 
 // Terminate cleans up PortAudio
 func (r *Recorder) Terminate() error {
+	r.stopPreRoll()
 	return portaudio.Terminate()
 }
 
-// Start begins recording audio
-func (r *Recorder) Start() error {
+// refreshDefaultDevice re-initializes PortAudio so its device list reflects
+// the current hardware, then returns the default input device.
+// PortAudio only enumerates devices at initialization time.
+func (r *Recorder) refreshDefaultDevice() (*portaudio.DeviceInfo, error) {
+	portaudio.Terminate()
+	if err := portaudio.Initialize(); err != nil {
+		return nil, fmt.Errorf("failed to initialize audio: %w", err)
+	}
+
+	device, err := portaudio.DefaultInputDevice()
+	if err != nil {
+		return nil, fmt.Errorf("no input device available: %w", err)
+	}
+	return device, nil
+}
+
+// ArmSilenceStop sets whether the next recording stops itself after
+// SilenceTimeout of trailing silence
+func (r *Recorder) ArmSilenceStop(armed bool) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	r.silenceArmed = armed
+}
+
+// GetDeviceName returns the name of the input device used by the last recording
+func (r *Recorder) GetDeviceName() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.deviceName
+}
+
+// selectDevice refreshes the device list and returns the selected input
+// device, falling back to the system default. Must be called with r.mu held.
+func (r *Recorder) selectDevice() (*portaudio.DeviceInfo, error) {
+	// Re-query devices so hotplugged/unplugged microphones are picked up
+	device, err := r.refreshDefaultDevice()
+	if err != nil {
+		return nil, err
+	}
+	if r.inputDevice != "" {
+		selected, err := findInputDevice(r.inputDevice)
+		if err != nil {
+			return nil, err
+		}
+		if selected != nil {
+			device = selected
+		} else if r.OnDeviceMissing != nil {
+			go r.OnDeviceMissing(r.inputDevice)
+		}
+	}
+	if r.deviceName != "" && r.deviceName != device.Name && r.OnDeviceChange != nil {
+		go r.OnDeviceChange(r.deviceName, device.Name)
+	}
+	r.deviceName = device.Name
+	return device, nil
+}
+
+// openStream opens and starts an input stream on device, returning it with
+// the buffer each Read fills
+func (r *Recorder) openStream(device *portaudio.DeviceInfo) (*portaudio.Stream, []int16, error) {
+	inputBuffer := make([]int16, FramesPerBuffer)
+
+	params := portaudio.StreamParameters{
+		Input: portaudio.StreamDeviceParameters{
+			Device:   device,
+			Channels: Channels,
+			Latency:  device.DefaultLowInputLatency,
+		},
+		SampleRate:      r.sampleRate,
+		FramesPerBuffer: FramesPerBuffer,
+	}
+	stream, err := portaudio.OpenStream(params, inputBuffer)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open audio stream: %w", err)
+	}
+	if err := stream.Start(); err != nil {
+		stream.Close()
+		return nil, nil, fmt.Errorf("failed to start audio stream: %w", err)
+	}
+	return stream, inputBuffer, nil
+}
+
+// Start begins recording audio
+func (r *Recorder) Start() error {
+	r.preRollMu.Lock()
+	defer r.preRollMu.Unlock()
 
 	if r.recording.Load() {
 		return fmt.Errorf("already recording")
 	}
 
-	// Clear the buffer
-	r.buffer = make([]int16, 0)
+	// Take over the pre-roll stream, if it's still usable, so capture continues
+	// without losing the onset. Otherwise open a fresh stream on the
+	// re-resolved device the same way a recording without pre-roll does.
+	preRoll, stream, inputBuffer := r.takePreRoll()
 
-	// Create input buffer
-	inputBuffer := make([]int16, FramesPerBuffer)
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
-	// Open default input stream
-	stream, err := portaudio.OpenDefaultStream(
-		Channels,        // input channels
-		0,               // output channels
-		r.sampleRate,    // sample rate
-		FramesPerBuffer, // frames per buffer
-		inputBuffer,     // buffer
-	)
-	if err != nil {
-		return fmt.Errorf("failed to open audio stream: %w", err)
+	if stream == nil {
+		device, err := r.selectDevice()
+		if err != nil {
+			go r.startPreRoll()
+			return err
+		}
+		stream, inputBuffer, err = r.openStream(device)
+		if err != nil {
+			go r.startPreRoll()
+			return err
+		}
 	}
 
+	// Start the buffer with the pre-roll audio captured before the trigger
+	applyGain(preRoll, r.gain)
+	r.buffer = make([]int16, 0, len(preRoll))
+	r.buffer = append(r.buffer, preRoll...)
+
 	r.stream = stream
 	r.stopChan = make(chan struct{})
 	r.stoppedChan = make(chan struct{})
 
-	// Start the stream
-	if err := stream.Start(); err != nil {
-		stream.Close()
-		return fmt.Errorf("failed to start audio stream: %w", err)
+	r.paused.Store(false)
+	r.recording.Store(true)
+
+	// Deliver level measurements off the read loop
+	var levels chan level
+	if r.OnLevel != nil {
+		levels = make(chan level, 1)
+		go r.levelLoop(levels, r.OnLevel, r.stoppedChan)
 	}
 
-	r.recording.Store(true)
+	var silence *silenceTracker
+	if r.silenceArmed && r.SilenceTimeout > 0 && r.OnSilence != nil {
+		silence = &silenceTracker{
+			threshold: float32(math.Pow(10, r.SilenceThresholdDB/20)),
+			timeout:   r.SilenceTimeout,
+		}
+	}
 
 	// Start goroutine to read audio data
-	go r.readLoop(inputBuffer)
+	go r.readLoop(inputBuffer, levels, silence)
 
 	return nil
 }
 
 // readLoop continuously reads audio data from the stream
-func (r *Recorder) readLoop(inputBuffer []int16) {
+func (r *Recorder) readLoop(inputBuffer []int16, levels chan<- level, silence *silenceTracker) {
 	defer close(r.stoppedChan)
 
+	bufferDuration := time.Duration(float64(len(inputBuffer)) / r.sampleRate * float64(time.Second))
+
+	r.mu.Lock()
+	maxDuration := r.maxDuration
+	maxSamples := int(maxDuration.Seconds() * r.sampleRate)
+	onMaxDuration := r.OnMaxDuration
+	r.mu.Unlock()
+	capped := false
+	cappedNotified := false
+	consecutiveErrors := 0
+
 	for {
 		// Check if we should stop
 		select {
@@ -111,6 +305,7 @@ func (r *Recorder) readLoop(inputBuffer []int16) {
 
 		r.mu.Lock()
 		stream := r.stream
+		gain := r.gain
 		r.mu.Unlock()
 
 		if stream == nil {
@@ -119,27 +314,177 @@ func (r *Recorder) readLoop(inputBuffer []int16) {
 
 		// Read from the stream - this is the blocking call
 		err := stream.Read()
+		if err == portaudio.InputOverflowed {
+			err = nil // Buffer was still filled; only older samples were dropped
+		}
 		if err != nil {
 			// Check if we were asked to stop
 			if !r.recording.Load() {
 				return
 			}
-			fmt.Printf("Error reading audio: %v\n", err)
+			consecutiveErrors++
+			if isDeviceLost(err) || consecutiveErrors >= maxConsecutiveReadErrors {
+				r.handleDeviceLost(stream, err)
+				return
+			}
+			fmt.Printf("[Audio] Error reading audio (%d/%d): %v\n", consecutiveErrors, maxConsecutiveReadErrors, err)
+			time.Sleep(readErrorBackoff)
 			continue
 		}
+		consecutiveErrors = 0
+
+		applyGain(inputBuffer, gain)
+		if time.Now().UnixNano() < r.muteUntil.Load() {
+			clear(inputBuffer)
+		}
 
 		// Append to buffer
 		r.mu.Lock()
-		if r.recording.Load() {
+		if r.paused.Load() {
+			r.mu.Unlock()
+			continue
+		}
+		if r.recording.Load() && !capped {
+			samples := inputBuffer
+			if maxSamples > 0 && len(r.buffer)+len(samples) >= maxSamples {
+				// The pre-roll alone may already fill the cap
+				samples = samples[:max(maxSamples-len(r.buffer), 0)]
+				capped = true
+			}
 			// Make a copy to avoid data race
-			samples := make([]int16, len(inputBuffer))
-			copy(samples, inputBuffer)
-			r.buffer = append(r.buffer, samples...)
+			r.buffer = append(r.buffer, append([]int16(nil), samples...)...)
 		}
 		r.mu.Unlock()
+
+		if capped && !cappedNotified {
+			cappedNotified = true // Signal once
+			fmt.Printf("[Audio] Max duration of %v reached, dropping further audio\n", maxDuration)
+			if onMaxDuration != nil {
+				go onMaxDuration()
+			}
+		}
+
+		if levels == nil && silence == nil {
+			continue
+		}
+		rms, peak := measureLevel(inputBuffer)
+		if levels != nil {
+			select {
+			case levels <- level{rms, peak}:
+			default: // Handler still busy with the previous level
+			}
+		}
+		if silence != nil && silence.update(rms, bufferDuration) {
+			fmt.Printf("[Audio] %v of silence, auto-stopping\n", silence.timeout)
+			go r.OnSilence()
+		}
 	}
 }
 
+// isDeviceLost reports whether a read error means the input device is gone
+func isDeviceLost(err error) bool {
+	var hostErr portaudio.UnanticipatedHostError
+	return errors.Is(err, portaudio.DeviceUnavailable) || errors.As(err, &hostErr)
+}
+
+// handleDeviceLost ends a recording whose device stopped delivering audio
+func (r *Recorder) handleDeviceLost(stream *portaudio.Stream, err error) {
+	if !r.recording.CompareAndSwap(true, false) {
+		return // Stop got there first
+	}
+
+	r.mu.Lock()
+	if r.stream == stream {
+		stream.Stop()
+		stream.Close()
+		r.stream = nil
+	}
+	r.buffer = make([]int16, 0)
+	onDeviceLost := r.OnDeviceLost
+	r.mu.Unlock()
+
+	fmt.Printf("[Audio] Input device lost, recording ended: %v\n", err)
+	if onDeviceLost != nil {
+		go onDeviceLost(err)
+	}
+	go r.startPreRoll()
+}
+
+// levelLoop forwards level measurements to onLevel until done is closed
+func (r *Recorder) levelLoop(levels <-chan level, onLevel func(rms, peak float32), done <-chan struct{}) {
+	for {
+		select {
+		case l := <-levels:
+			onLevel(l.rms, l.peak)
+		case <-done:
+			return
+		}
+	}
+}
+
+// MuteFor replaces captured audio with silence for the next d, e.g. while a
+// sound cue plays, so it doesn't end up in the recording
+func (r *Recorder) MuteFor(d time.Duration) {
+	r.muteUntil.Store(time.Now().Add(d).UnixNano())
+}
+
+// SetMaxDuration caps recording length (0 = unlimited). It applies from the
+// next recording.
+func (r *Recorder) SetMaxDuration(d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.maxDuration = d
+}
+
+// MaxDuration returns the recording length cap (0 = unlimited)
+func (r *Recorder) MaxDuration() time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.maxDuration
+}
+
+// SetGain sets the software amplification applied to captured audio (1 = unchanged)
+func (r *Recorder) SetGain(gain float32) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.gain = gain
+}
+
+// applyGain scales samples in place, clamping to the int16 range so loud
+// input saturates instead of wrapping around
+func applyGain(samples []int16, gain float32) {
+	if gain == 1 {
+		return
+	}
+	for i, s := range samples {
+		v := float32(s) * gain
+		samples[i] = int16(max(min(v, math.MaxInt16), math.MinInt16))
+	}
+}
+
+// measureLevel returns the RMS and peak amplitude of samples, normalized to 0-1
+func measureLevel(samples []int16) (float32, float32) {
+	if len(samples) == 0 {
+		return 0, 0
+	}
+
+	var sumSquares float64
+	var peak int32
+	for _, s := range samples {
+		v := int32(s)
+		if v < 0 {
+			v = -v
+		}
+		if v > peak {
+			peak = v
+		}
+		sumSquares += float64(v) * float64(v)
+	}
+
+	rms := math.Sqrt(sumSquares/float64(len(samples))) / 32768
+	return float32(rms), float32(peak) / 32768
+}
+
 // Stop stops recording and returns the path to the WAV file
 func (r *Recorder) Stop() (string, error) {
 	if !r.recording.Load() {
@@ -168,6 +513,9 @@ func (r *Recorder) Stop() (string, error) {
 		r.stream = nil
 	}
 
+	// Resume idle pre-roll capture for the next recording
+	go r.startPreRoll()
+
 	// Save buffer to WAV file
 	return r.saveToWav()
 }
@@ -189,35 +537,65 @@ func (r *Recorder) saveToWav() (string, error) {
 	}
 	defer file.Close()
 
-	// Write WAV header
-	if err := r.writeWavHeader(file, len(r.buffer)); err != nil {
-		return "", fmt.Errorf("failed to write WAV header: %w", err)
+	if err := r.writeWav(file, r.buffer); err != nil {
+		return "", err
 	}
 
-	// Write audio data
-	for _, sample := range r.buffer {
-		if err := binary.Write(file, binary.LittleEndian, sample); err != nil {
-			return "", fmt.Errorf("failed to write audio data: %w", err)
-		}
+	return filepath, nil
+}
+
+// maxWavDataSize is the largest data chunk a RIFF header can describe
+const maxWavDataSize = math.MaxUint32 - 36
+
+// writeWav writes samples to file as a WAV: a placeholder header, the audio,
+// then the real chunk sizes patched in from what was actually written
+func (r *Recorder) writeWav(file *os.File, samples []int16) error {
+	if int64(len(samples))*2 > maxWavDataSize {
+		return fmt.Errorf("recording too large for WAV (%d samples)", len(samples))
 	}
 
-	return filepath, nil
+	if err := r.writeWavHeader(file, 0); err != nil {
+		return fmt.Errorf("failed to write WAV header: %w", err)
+	}
+
+	w := bufio.NewWriter(file)
+	if err := binary.Write(w, binary.LittleEndian, samples); err != nil {
+		return fmt.Errorf("failed to write audio data: %w", err)
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to write audio data: %w", err)
+	}
+
+	end, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return fmt.Errorf("failed to finalize WAV header: %w", err)
+	}
+	if err := patchWavSizes(file, end-wavHeaderSize); err != nil {
+		return fmt.Errorf("failed to finalize WAV header: %w", err)
+	}
+	return nil
 }
 
-// writeWavHeader writes a WAV file header
+// wavHeaderSize is the size of the canonical 44-byte PCM WAV header
+const wavHeaderSize = 44
+
+// writeWavHeader writes a WAV file header describing numSamples samples
 func (r *Recorder) writeWavHeader(file *os.File, numSamples int) error {
 	// WAV file format constants
 	bitsPerSample := 16
 	byteRate := int(r.sampleRate) * Channels * bitsPerSample / 8
 	blockAlign := Channels * bitsPerSample / 8
-	dataSize := numSamples * 2 // 2 bytes per sample (int16)
+	dataSize := int64(numSamples) * 2 // 2 bytes per sample (int16)
+	if dataSize > maxWavDataSize {
+		return fmt.Errorf("data size %d exceeds WAV limit", dataSize)
+	}
 	fileSize := 36 + dataSize
 
 	header := bytes.NewBuffer(nil)
 
 	// RIFF header
 	header.WriteString("RIFF")
-	binary.Write(header, binary.LittleEndian, int32(fileSize))
+	binary.Write(header, binary.LittleEndian, uint32(fileSize))
 	header.WriteString("WAVE")
 
 	// fmt subchunk
@@ -232,12 +610,28 @@ func (r *Recorder) writeWavHeader(file *os.File, numSamples int) error {
 
 	// data subchunk
 	header.WriteString("data")
-	binary.Write(header, binary.LittleEndian, int32(dataSize))
+	binary.Write(header, binary.LittleEndian, uint32(dataSize))
 
 	_, err := file.Write(header.Bytes())
 	return err
 }
 
+// patchWavSizes rewrites the RIFF and data chunk sizes for dataSize bytes of audio
+func patchWavSizes(file *os.File, dataSize int64) error {
+	if dataSize < 0 || dataSize > maxWavDataSize {
+		return fmt.Errorf("data size %d exceeds WAV limit", dataSize)
+	}
+
+	var size [4]byte
+	binary.LittleEndian.PutUint32(size[:], uint32(36+dataSize))
+	if _, err := file.WriteAt(size[:], 4); err != nil {
+		return err
+	}
+	binary.LittleEndian.PutUint32(size[:], uint32(dataSize))
+	_, err := file.WriteAt(size[:], 40)
+	return err
+}
+
 // GetDuration returns the duration of the recorded audio
 func (r *Recorder) GetDuration() time.Duration {
 	r.mu.Lock()
@@ -247,6 +641,34 @@ func (r *Recorder) GetDuration() time.Duration {
 	return time.Duration(seconds * float64(time.Second))
 }
 
+// Pause stops capturing audio without ending the recording; the stream stays
+// open so Resume picks up immediately
+func (r *Recorder) Pause() error {
+	if !r.recording.Load() {
+		return fmt.Errorf("cannot pause: not recording")
+	}
+	if !r.paused.CompareAndSwap(false, true) {
+		return fmt.Errorf("already paused")
+	}
+	return nil
+}
+
+// Resume continues capturing audio after Pause
+func (r *Recorder) Resume() error {
+	if !r.recording.Load() {
+		return fmt.Errorf("cannot resume: not recording")
+	}
+	if !r.paused.CompareAndSwap(true, false) {
+		return fmt.Errorf("not paused")
+	}
+	return nil
+}
+
+// IsPaused returns whether a recording is in progress but paused
+func (r *Recorder) IsPaused() bool {
+	return r.recording.Load() && r.paused.Load()
+}
+
 // IsRecording returns whether the recorder is currently recording
 func (r *Recorder) IsRecording() bool {
 	return r.recording.Load()
@@ -0,0 +1,125 @@
+package audio
+
+import (
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestApplyGain(t *testing.T) {
+	tests := []struct {
+		name    string
+		gain    float32
+		samples []int16
+		want    []int16
+	}{
+		{"unity", 1, []int16{-32768, -1, 0, 1, 32767}, []int16{-32768, -1, 0, 1, 32767}},
+		{"double", 2, []int16{-1000, 0, 1000}, []int16{-2000, 0, 2000}},
+		{"half", 0.5, []int16{-1000, 0, 1000}, []int16{-500, 0, 500}},
+		{"clamps at the top", 2, []int16{20000, 32767}, []int16{32767, 32767}},
+		{"clamps at the bottom", 2, []int16{-20000, -32768}, []int16{-32768, -32768}},
+		{"extreme gain", 10, []int16{-32768, -4000, 3277, 32767}, []int16{-32768, -32768, 32767, 32767}},
+		{"extreme gain keeps small samples", 10, []int16{-3, 3}, []int16{-30, 30}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slices.Clone(tt.samples)
+			applyGain(got, tt.gain)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("applyGain(%v, %v) = %v, want %v", tt.samples, tt.gain, got, tt.want)
+			}
+		})
+	}
+}
+
+// readWavSizes returns the RIFF and data chunk sizes from a WAV header
+func readWavSizes(t *testing.T, path string) (riffSize, dataSize uint32) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) < wavHeaderSize {
+		t.Fatalf("file is %d bytes, shorter than a WAV header", len(data))
+	}
+	if string(data[0:4]) != "RIFF" || string(data[8:12]) != "WAVE" || string(data[36:40]) != "data" {
+		t.Fatalf("malformed WAV header: %q", data[:wavHeaderSize])
+	}
+	return binary.LittleEndian.Uint32(data[4:8]), binary.LittleEndian.Uint32(data[40:44])
+}
+
+func TestWriteWav(t *testing.T) {
+	tests := []struct {
+		name       string
+		numSamples int
+	}{
+		{"one sample", 1},
+		{"one buffer", FramesPerBuffer},
+		{"one second", SampleRate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "test.wav")
+			if err := writeTestWav(path, make([]int16, tt.numSamples)); err != nil {
+				t.Fatal(err)
+			}
+
+			riffSize, dataSize := readWavSizes(t, path)
+			wantData := uint32(tt.numSamples * 2)
+			if dataSize != wantData || riffSize != 36+wantData {
+				t.Errorf("sizes = (%d, %d), want (%d, %d)", riffSize, dataSize, 36+wantData, wantData)
+			}
+			info, err := os.Stat(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if info.Size() != int64(wavHeaderSize)+int64(wantData) {
+				t.Errorf("file size = %d, want %d", info.Size(), int64(wavHeaderSize)+int64(wantData))
+			}
+		})
+	}
+}
+
+func TestPatchWavSizes(t *testing.T) {
+	tests := []struct {
+		name     string
+		dataSize int64
+		wantErr  bool
+	}{
+		{"empty", 0, false},
+		{"small", 2, false},
+		{"just over the int32 boundary", math.MaxInt32 + 2, false},
+		{"largest describable", maxWavDataSize, false},
+		{"too large", maxWavDataSize + 1, true},
+		{"negative", -1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "test.wav")
+			file, err := os.Create(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer file.Close()
+			r := &Recorder{sampleRate: SampleRate}
+			if err := r.writeWavHeader(file, 0); err != nil {
+				t.Fatal(err)
+			}
+
+			err = patchWavSizes(file, tt.dataSize)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("patchWavSizes(%d) error = %v, wantErr %v", tt.dataSize, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			riffSize, dataSize := readWavSizes(t, path)
+			if int64(dataSize) != tt.dataSize || int64(riffSize) != 36+tt.dataSize {
+				t.Errorf("sizes = (%d, %d), want (%d, %d)", riffSize, dataSize, 36+tt.dataSize, tt.dataSize)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package audio
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"os/exec"
+	"time"
+)
+
+// Test audio helpers. These produce 16kHz mono 16-bit WAV files so the
+// pipeline can be exercised without a microphone (CI, demos, debugging).
+
+// GenerateSilence writes a WAV file containing duration of silence
+func GenerateSilence(path string, duration time.Duration) error {
+	return writeTestWav(path, make([]int16, samplesFor(duration)))
+}
+
+// GenerateTone writes a WAV file containing a sine tone at freqHz
+func GenerateTone(path string, freqHz float64, duration time.Duration) error {
+	samples := make([]int16, samplesFor(duration))
+	for i := range samples {
+		t := float64(i) / SampleRate
+		samples[i] = int16(0.3 * math.MaxInt16 * math.Sin(2*math.Pi*freqHz*t))
+	}
+	return writeTestWav(path, samples)
+}
+
+// GenerateTestWav speaks text into a WAV file using the macOS `say` command
+func GenerateTestWav(text string, path string) error {
+	if text == "" {
+		return fmt.Errorf("text is required")
+	}
+	cmd := exec.Command("say",
+		"-o", path,
+		"--file-format=WAVE",
+		fmt.Sprintf("--data-format=LEI16@%d", SampleRate),
+		text,
+	)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("say failed: %w: %s", err, out)
+	}
+	return nil
+}
+
+// WavDuration returns the duration of a PCM WAV file. It walks the RIFF chunks
+// for "fmt " and "data", so files with extra chunks (like the ones `say`
+// writes) are measured correctly.
+func WavDuration(path string) (time.Duration, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return 0, err
+	}
+	return wavDuration(file, info.Size())
+}
+
+// wavDuration reads the WAV header from r, a file of size bytes
+func wavDuration(r io.Reader, size int64) (time.Duration, error) {
+	var riff [12]byte
+	if _, err := io.ReadFull(r, riff[:]); err != nil || string(riff[0:4]) != "RIFF" || string(riff[8:12]) != "WAVE" {
+		return 0, errors.New("not a WAV file")
+	}
+
+	offset := int64(len(riff))
+	var sampleRate, blockAlign uint32
+	for {
+		var chunk [8]byte
+		if _, err := io.ReadFull(r, chunk[:]); err != nil {
+			return 0, errors.New("WAV file has no data chunk")
+		}
+		offset += int64(len(chunk))
+		id := string(chunk[0:4])
+		chunkSize := int64(binary.LittleEndian.Uint32(chunk[4:8]))
+
+		switch id {
+		case "fmt ":
+			if chunkSize < 16 {
+				return 0, errors.New("WAV fmt chunk is too short")
+			}
+			var format [16]byte
+			if _, err := io.ReadFull(r, format[:]); err != nil {
+				return 0, errors.New("WAV fmt chunk is truncated")
+			}
+			sampleRate = binary.LittleEndian.Uint32(format[4:8])
+			blockAlign = uint32(binary.LittleEndian.Uint16(format[12:14]))
+			if sampleRate == 0 || blockAlign == 0 {
+				return 0, errors.New("WAV fmt chunk is invalid")
+			}
+			if _, err := io.CopyN(io.Discard, r, chunkSize-16+chunkSize%2); err != nil {
+				return 0, errors.New("WAV fmt chunk is truncated")
+			}
+		case "data":
+			if blockAlign == 0 {
+				return 0, errors.New("WAV data chunk comes before the fmt chunk")
+			}
+			// An unpatched header (still recording, or a crash) understates
+			// or overstates the data, so trust the file size over it
+			if remaining := size - offset; chunkSize == 0 || chunkSize > remaining {
+				chunkSize = remaining
+			}
+			frames := chunkSize / int64(blockAlign)
+			return time.Duration(float64(frames) / float64(sampleRate) * float64(time.Second)), nil
+		default:
+			if _, err := io.CopyN(io.Discard, r, chunkSize+chunkSize%2); err != nil {
+				return 0, errors.New("WAV file has no data chunk")
+			}
+		}
+		offset += chunkSize + chunkSize%2
+	}
+}
+
+// samplesFor returns the number of samples in duration at SampleRate
+func samplesFor(duration time.Duration) int {
+	return int(duration.Seconds() * SampleRate)
+}
+
+// writeTestWav writes samples to path as a WAV file
+func writeTestWav(path string, samples []int16) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create WAV file: %w", err)
+	}
+	defer file.Close()
+
+	r := &Recorder{sampleRate: SampleRate}
+	return r.writeWav(file, samples)
+}
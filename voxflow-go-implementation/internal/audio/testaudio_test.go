@@ -0,0 +1,93 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// buildWav assembles a RIFF/WAVE file from chunks given as id, payload pairs
+func buildWav(chunks ...any) []byte {
+	var body bytes.Buffer
+	body.WriteString("WAVE")
+	for i := 0; i < len(chunks); i += 2 {
+		payload := chunks[i+1].([]byte)
+		body.WriteString(chunks[i].(string))
+		binary.Write(&body, binary.LittleEndian, uint32(len(payload)))
+		body.Write(payload)
+		if len(payload)%2 == 1 {
+			body.WriteByte(0)
+		}
+	}
+	var out bytes.Buffer
+	out.WriteString("RIFF")
+	binary.Write(&out, binary.LittleEndian, uint32(body.Len()))
+	out.Write(body.Bytes())
+	return out.Bytes()
+}
+
+// fmtChunk returns a PCM fmt chunk payload
+func fmtChunk(channels uint16, sampleRate uint32, bits uint16) []byte {
+	var b bytes.Buffer
+	blockAlign := channels * bits / 8
+	binary.Write(&b, binary.LittleEndian, uint16(1))
+	binary.Write(&b, binary.LittleEndian, channels)
+	binary.Write(&b, binary.LittleEndian, sampleRate)
+	binary.Write(&b, binary.LittleEndian, sampleRate*uint32(blockAlign))
+	binary.Write(&b, binary.LittleEndian, blockAlign)
+	binary.Write(&b, binary.LittleEndian, bits)
+	return b.Bytes()
+}
+
+func TestWavDuration(t *testing.T) {
+	oneSecond := make([]byte, SampleRate*2)
+
+	unpatched := buildWav("fmt ", fmtChunk(1, SampleRate, 16), "data", []byte{})
+	unpatched = append(unpatched, oneSecond...)
+
+	tests := []struct {
+		name    string
+		data    []byte
+		want    time.Duration
+		wantErr bool
+	}{
+		{"plain", buildWav("fmt ", fmtChunk(1, SampleRate, 16), "data", oneSecond), time.Second, false},
+		{"extra chunks", buildWav("fmt ", fmtChunk(1, SampleRate, 16), "FLLR", make([]byte, 4044), "data", oneSecond), time.Second, false},
+		{"odd chunk padding", buildWav("fmt ", fmtChunk(1, SampleRate, 16), "LIST", []byte("abc"), "data", oneSecond), time.Second, false},
+		{"stereo 44.1kHz", buildWav("fmt ", fmtChunk(2, 44100, 16), "data", make([]byte, 44100*4/2)), 500 * time.Millisecond, false},
+		{"unpatched data size", unpatched, time.Second, false},
+		{"not RIFF", append([]byte("RIFX"), make([]byte, 60)...), 0, true},
+		{"not WAVE", append([]byte("RIFF\x00\x00\x00\x00AVI "), make([]byte, 60)...), 0, true},
+		{"no fmt chunk", buildWav("data", oneSecond), 0, true},
+		{"no data chunk", buildWav("fmt ", fmtChunk(1, SampleRate, 16)), 0, true},
+		{"short fmt chunk", buildWav("fmt ", []byte{1, 0, 1, 0}, "data", oneSecond), 0, true},
+		{"too short", []byte("RIFF"), 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := wavDuration(bytes.NewReader(tt.data), int64(len(tt.data)))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("wavDuration error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("wavDuration = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWavDurationGenerated(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tone.wav")
+	if err := GenerateTone(path, 440, 1500*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	got, err := WavDuration(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != 1500*time.Millisecond {
+		t.Errorf("WavDuration = %v, want 1.5s", got)
+	}
+}
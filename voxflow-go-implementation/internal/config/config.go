@@ -2,22 +2,114 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"sync"
+
+	"voxflow/internal/keychain"
 )
 
+// CustomMode is a user-defined refinement mode with its own prompt
+type CustomMode struct {
+	Name   string `json:"name"`
+	Prompt string `json:"prompt"`
+}
+
 // Config holds the application configuration
 type Config struct {
-	GeminiAPIKey     string `json:"gemini_api_key"`
-	HandsFreeHotkey  string `json:"hands_free_hotkey"`   // e.g., "cmd+shift+space"
-	PushToTalkHotkey string `json:"push_to_talk_hotkey"` // e.g., "cmd+shift+p"
-	Hotkey           string `json:"hotkey,omitempty"`    // Legacy field, kept for migration
-	WhisperModel     string `json:"whisper_model"`       // tiny, base, small
-	Mode             string `json:"mode"`                // casual, formal
-	MiniModeX        int    `json:"mini_mode_x"`         // Saved X position of mini pill
-	MiniModeY        int    `json:"mini_mode_y"`         // Saved Y position of mini pill
-	mu               sync.RWMutex
+	Version                  int               `json:"version"`                    // Schema version, upgraded by migrate on load
+	GeminiAPIKey             string            `json:"gemini_api_key"`             // Only used when the keychain isn't
+	HandsFreeHotkey          string            `json:"hands_free_hotkey"`          // e.g., "cmd+shift+space"
+	PushToTalkHotkey         string            `json:"push_to_talk_hotkey"`        // e.g., "cmd+shift+p"
+	Hotkey                   string            `json:"hotkey,omitempty"`           // Legacy field, kept for migration
+	WhisperModel             string            `json:"whisper_model"`              // tiny, base, small
+	Mode                     string            `json:"mode"`                       // casual, formal
+	MiniModeX                int               `json:"mini_mode_x"`                // Saved X position of mini pill
+	MiniModeY                int               `json:"mini_mode_y"`                // Saved Y position of mini pill
+	LineEnding               string            `json:"line_ending"`                // lf, crlf
+	DateTokenStage           string            `json:"date_token_stage"`           // before, after, off (relative to refinement)
+	DateFormat               string            `json:"date_format"`                // Go layout for "insert date"
+	TimeFormat               string            `json:"time_format"`                // Go layout for "insert time"
+	MaxOutputChars           map[string]int    `json:"max_output_chars,omitempty"` // Per-mode output limit (0 = unlimited)
+	LocalServerEnabled       bool              `json:"local_server_enabled"`       // Expose /status and /events on localhost
+	LocalServerPort          int               `json:"local_server_port"`
+	ChunkSeconds             int               `json:"chunk_seconds"`               // Split recordings longer than this for whisper (0 = never)
+	ChunkOverlapSecs         int               `json:"chunk_overlap_seconds"`       // Overlap between chunks
+	PrivacyClearClipboard    bool              `json:"privacy_clear_clipboard"`     // Clear clipboard after injection in privacy mode
+	CustomModes              []CustomMode      `json:"custom_modes,omitempty"`      // User-defined modes, in display order
+	Appearance               string            `json:"appearance"`                  // system, light, dark
+	DownloadStallSeconds     int               `json:"download_stall_seconds"`      // Retry a model download after this long without data (0 = never)
+	ConfirmBeforeInject      bool              `json:"confirm_before_inject"`       // Ask before pasting at the cursor
+	EmergencyStopHotkey      string            `json:"emergency_stop_hotkey"`       // Always-on hotkey that halts everything
+	BeamSize                 int               `json:"beam_size"`                   // whisper beam size (larger = more accurate, slower)
+	WhisperTemperature       float64           `json:"whisper_temperature"`         // whisper sampling temperature (0-1)
+	QuitWhileBusy            string            `json:"quit_while_busy"`             // Quitting during recording/processing: ask, finish, discard
+	CaseStyle                string            `json:"case_style"`                  // Casing applied to polished text: asis, sentence, title, upper, lower
+	ToneTagging              bool              `json:"tone_tagging"`                // Classify tone during refinement (extra tokens)
+	CodeSpokenSymbols        bool              `json:"code_spoken_symbols"`         // Convert spoken symbol names locally in code mode
+	PasteRetries             int               `json:"paste_retries"`               // Retries when the paste keystroke can't be posted
+	QuickNoteHotkey          string            `json:"quick_note_hotkey"`           // Records to history only, no clipboard or paste (empty = disabled)
+	KeepPillDuringProcessing bool              `json:"keep_pill_during_processing"` // Keep the mini indicator on screen while processing
+	InputDevice              string            `json:"input_device"`                // Microphone name ("" = system default)
+	SilenceTimeoutSecs       float64           `json:"silence_timeout_seconds"`     // Hands-free auto-stop after this much trailing silence (0 = off)
+	SilenceThresholdDB       float64           `json:"silence_threshold_db"`        // Level below which audio counts as silence
+	BatchConcurrency         int               `json:"batch_concurrency"`           // Parallel Gemini requests for batch re-refine
+	MaxRecordingSecs         int               `json:"max_recording_seconds"`       // Recording stops after this long (0 = unlimited)
+	ConfirmDelete            bool              `json:"confirm_delete"`              // Ask before deleting history entries
+	TrashRetentionDays       int               `json:"trash_retention_days"`        // Days deleted transcripts stay restorable
+	PreRollMs                int               `json:"pre_roll_ms"`                 // Audio kept from before the hotkey (0 = off; keeps the mic open while idle)
+	InputGain                float64           `json:"input_gain"`                  // Software amplification for quiet microphones (1 = unchanged)
+	KeepRecordings           bool              `json:"keep_recordings"`             // Archive each recording next to its transcript
+	RecordingsDir            string            `json:"recordings_dir"`              // Where archived recordings go ("" = ~/.voxflow/recordings)
+	RecordingRetentionCount  int               `json:"recording_retention_count"`   // Keep at most this many recordings (0 = no limit)
+	RecordingRetentionDays   int               `json:"recording_retention_days"`    // Delete recordings older than this (0 = no limit)
+	Language                 string            `json:"language"`                    // Whisper transcription language code, or "auto" to detect
+	Translate                bool              `json:"translate"`                   // Have whisper translate speech to English
+	WhisperThreads           int               `json:"whisper_threads"`             // CPU threads for whisper-cli
+	ModelsDir                string            `json:"models_dir"`                  // Where Whisper models are stored ("" = ~/.voxflow/models)
+	VocabularyPrompt         string            `json:"vocabulary_prompt"`           // Terms passed to whisper as an initial prompt to bias decoding
+	GeminiModel              string            `json:"gemini_model"`                // Gemini model used for refinement
+	GeminiMaxAttempts        int               `json:"gemini_max_attempts"`         // Tries per Gemini request when rate limited or failing
+	GeminiRetrySeconds       int               `json:"gemini_retry_seconds"`        // Total time a Gemini request may take including retries
+	RefinementBackend        string            `json:"refinement_backend"`          // Service used for refinement: gemini or openai
+	OpenAIBaseURL            string            `json:"openai_base_url"`             // OpenAI-compatible API root ("" = Ollama on localhost)
+	OpenAIModel              string            `json:"openai_model"`                // Model name for the OpenAI-compatible backend
+	OpenAIAPIKey             string            `json:"openai_api_key,omitempty"`    // API key for the OpenAI-compatible backend, if it needs one. Only used when the keychain isn't
+	RefinementEnabled        bool              `json:"refinement_enabled"`          // Refine transcriptions; off uses the raw Whisper output
+	Replacements             map[string]string `json:"replacements,omitempty"`      // Find -> replace applied to polished text ("re:" keys are regexes)
+	Proxy                    string            `json:"proxy"`                       // Outbound proxy for Gemini, e.g. http://proxy:8080 (empty = HTTPS_PROXY env)
+	GeminiTimeoutSeconds     int               `json:"gemini_timeout_seconds"`      // Limit for a single Gemini request
+	DoubleTapWindowMs        int               `json:"double_tap_window_ms"`        // Longest gap between the taps of a "2x:" hotkey
+	PTTMinHoldMs             int               `json:"ptt_min_hold_ms"`             // Shorter push-to-talk presses are discarded (0 = off)
+	CycleModeHotkey          string            `json:"cycle_mode_hotkey"`           // Switches to the next refinement mode (empty = disabled)
+	HistoryRetentionDays     int               `json:"history_retention_days"`      // Delete transcripts older than this, except favorites (0 = keep forever)
+	HistoryMaxEntries        int               `json:"history_max_entries"`         // Keep at most this many non-favorite transcripts (0 = no limit)
+	EncryptHistory           bool              `json:"encrypt_history"`             // Encrypt transcript text in history.db with a key kept in the keychain
+	ReinjectDelayMs          int               `json:"reinject_delay_ms"`           // Wait after handing focus back before pasting from history
+	InjectionMode            string            `json:"injection_mode"`              // paste (clipboard + Cmd+V) or type (keystrokes)
+	ClipboardSettleMs        int               `json:"clipboard_settle_ms"`         // Wait after writing the clipboard before pasting
+	PostPasteMs              int               `json:"post_paste_ms"`               // Wait after pasting
+	ClipboardRestoreMs       int               `json:"clipboard_restore_ms"`        // Further wait before restoring the previous clipboard
+	AdaptivePaste            bool              `json:"adaptive_paste"`              // Confirm the clipboard write landed instead of waiting clipboard_settle_ms
+	WindowX                  int               `json:"window_x"`                    // Saved position of the full app window
+	WindowY                  int               `json:"window_y"`                    // (relative to the screen it was on)
+	WindowWidth              int               `json:"window_width"`                // Saved size of the full app window
+	WindowHeight             int               `json:"window_height"`               // (0 = default)
+	PillOpacity              float64           `json:"pill_opacity"`                // Opacity of the mini pill (0.3-1)
+	KeychainAPIKey           bool              `json:"keychain_api_key"`            // Keep the API keys in the macOS keychain instead of this file
+	LaunchAtLogin            bool              `json:"launch_at_login"`             // Start voxflow when the user logs in
+	PlaySounds               bool              `json:"play_sounds"`                 // Play a cue when recording starts and stops
+	SoundVolume              float64           `json:"sound_volume"`                // Volume of the cues (0-1)
+	NotifyOnComplete         bool              `json:"notify_on_complete"`          // Post a notification when processing finishes or fails
+	ShowTrayIcon             bool              `json:"show_tray_icon"`              // Show a menu bar icon with the recording state and quick actions
+	BlankAudioRetries        int               `json:"blank_audio_retries"`         // Extra transcription attempts when no speech is detected
+	BlankAudioRetryDelayMs   int               `json:"blank_audio_retry_delay_ms"`  // Wait between those attempts
+	keychainAPIKey           string            // Gemini API key read from the keychain
+	keychainOpenAIKey        string            // OpenAI-compatible backend API key read from the keychain
+	mu                       sync.RWMutex
 }
 
 var (
@@ -51,10 +143,54 @@ func GetConfigPath() (string, error) {
 func GetInstance() *Config {
 	once.Do(func() {
 		instance = &Config{
-			HandsFreeHotkey:  "cmd+shift+space",
-			PushToTalkHotkey: "cmd+shift+p",
-			WhisperModel:     "base",
-			Mode:             "casual",
+			Version:                  CurrentVersion,
+			HandsFreeHotkey:          "cmd+shift+space",
+			PushToTalkHotkey:         "cmd+shift+p",
+			WhisperModel:             "base",
+			Mode:                     "casual",
+			LineEnding:               "lf",
+			DateTokenStage:           "before",
+			DateFormat:               "January 2, 2006",
+			TimeFormat:               "3:04 PM",
+			LocalServerPort:          9876,
+			ChunkSeconds:             300,
+			ChunkOverlapSecs:         2,
+			Appearance:               "dark",
+			DownloadStallSeconds:     30,
+			EmergencyStopHotkey:      "ctrl+alt+cmd+escape",
+			BeamSize:                 5,
+			QuitWhileBusy:            "ask",
+			CaseStyle:                "asis",
+			PasteRetries:             1,
+			KeepPillDuringProcessing: true,
+			SilenceTimeoutSecs:       2.5,
+			SilenceThresholdDB:       -45,
+			BatchConcurrency:         2,
+			MaxRecordingSecs:         300,
+			ConfirmDelete:            true,
+			TrashRetentionDays:       30,
+			InputGain:                1,
+			Language:                 "auto",
+			WhisperThreads:           runtime.NumCPU(),
+			GeminiModel:              "gemini-2.0-flash",
+			GeminiMaxAttempts:        3,
+			GeminiRetrySeconds:       20,
+			RefinementBackend:        "gemini",
+			RefinementEnabled:        true,
+			GeminiTimeoutSeconds:     30,
+			DoubleTapWindowMs:        300,
+			PTTMinHoldMs:             200,
+			ReinjectDelayMs:          300,
+			InjectionMode:            "paste",
+			ClipboardSettleMs:        50,
+			PostPasteMs:              100,
+			ClipboardRestoreMs:       200,
+			PillOpacity:              1,
+			KeychainAPIKey:           true,
+			SoundVolume:              0.5,
+			ShowTrayIcon:             true,
+			BlankAudioRetries:        2,
+			BlankAudioRetryDelayMs:   500,
 		}
 		instance.Load()
 	})
@@ -79,15 +215,13 @@ func (c *Config) Load() error {
 		return err
 	}
 
+	// Files from before versioning have no version field
+	c.Version = 0
 	err = json.Unmarshal(data, c)
 	if err != nil {
 		return err
 	}
-
-	// Migration: If legacy Hotkey exists but HandsFreeHotkey is empty, use legacy
-	if c.Hotkey != "" && c.HandsFreeHotkey == "" {
-		c.HandsFreeHotkey = c.Hotkey
-	}
+	migrated := c.migrate(c.Version)
 
 	// Ensure defaults
 	if c.HandsFreeHotkey == "" {
@@ -102,20 +236,199 @@ func (c *Config) Load() error {
 	if c.Mode == "" {
 		c.Mode = "casual"
 	}
-
-	// Check environment variable first for API key
-	if apiKey := os.Getenv("GEMINI_API_KEY"); apiKey != "" {
-		c.GeminiAPIKey = apiKey
+	if c.LineEnding == "" {
+		c.LineEnding = "lf"
+	}
+	if c.DateTokenStage == "" {
+		c.DateTokenStage = "before"
+	}
+	if c.DateFormat == "" {
+		c.DateFormat = "January 2, 2006"
+	}
+	if c.TimeFormat == "" {
+		c.TimeFormat = "3:04 PM"
+	}
+	if c.LocalServerPort == 0 {
+		c.LocalServerPort = 9876
+	}
+	if c.Appearance == "" {
+		c.Appearance = "dark"
+	}
+	if c.EmergencyStopHotkey == "" {
+		c.EmergencyStopHotkey = "ctrl+alt+cmd+escape"
+	}
+	if c.BeamSize == 0 {
+		c.BeamSize = 5
+	}
+	if c.QuitWhileBusy == "" {
+		c.QuitWhileBusy = "ask"
+	}
+	if c.CaseStyle == "" {
+		c.CaseStyle = "asis"
+	}
+	if c.SilenceThresholdDB == 0 {
+		c.SilenceThresholdDB = -45
+	}
+	if c.BatchConcurrency < 1 {
+		c.BatchConcurrency = 2
+	}
+	if c.TrashRetentionDays < 1 {
+		c.TrashRetentionDays = 30
+	}
+	if c.InputGain <= 0 {
+		c.InputGain = 1
+	}
+	if c.Language == "" {
+		c.Language = "auto"
+	}
+	if c.WhisperThreads < 1 {
+		c.WhisperThreads = runtime.NumCPU()
+	}
+	if c.GeminiModel == "" {
+		c.GeminiModel = "gemini-2.0-flash"
+	}
+	if c.GeminiMaxAttempts < 1 {
+		c.GeminiMaxAttempts = 3
+	}
+	if c.GeminiRetrySeconds < 1 {
+		c.GeminiRetrySeconds = 20
+	}
+	if c.RefinementBackend == "" {
+		c.RefinementBackend = "gemini"
+	}
+	if c.GeminiTimeoutSeconds < 1 {
+		c.GeminiTimeoutSeconds = 30
+	}
+	if c.DoubleTapWindowMs <= 0 {
+		c.DoubleTapWindowMs = 300
+	}
+	if c.InjectionMode == "" {
+		c.InjectionMode = "paste"
+	}
+	// Keep a hand-edited opacity in the range the settings allow, so the pill
+	// can't become invisible and unclickable
+	c.PillOpacity = min(max(c.PillOpacity, 0.3), 1)
+	if c.SoundVolume <= 0 {
+		c.SoundVolume = 0.5
+	}
+	if c.BlankAudioRetries < 0 {
+		c.BlankAudioRetries = 0
+	}
+	if c.BlankAudioRetryDelayMs < 0 {
+		c.BlankAudioRetryDelayMs = 0
 	}
 
+	// Don't leave a key moved to the keychain in the file
+	movedAPIKey := c.loadKeychainAPIKey()
+	if migrated || movedAPIKey {
+		return c.write()
+	}
 	return nil
 }
 
+// Keychain accounts holding the API keys
+const (
+	apiKeyAccount       = "gemini-api-key"
+	openAIAPIKeyAccount = "openai-api-key"
+)
+
+// apiKeySlot is an API key that lives in the keychain when KeychainAPIKey is
+// set, or in the config file otherwise
+type apiKeySlot struct {
+	account string
+	file    *string // Field saved to config.json
+	cached  *string // Key read from the keychain
+}
+
+// geminiKeySlot is the Gemini API key
+func (c *Config) geminiKeySlot() apiKeySlot {
+	return apiKeySlot{apiKeyAccount, &c.GeminiAPIKey, &c.keychainAPIKey}
+}
+
+// openAIKeySlot is the OpenAI-compatible backend's API key
+func (c *Config) openAIKeySlot() apiKeySlot {
+	return apiKeySlot{openAIAPIKeyAccount, &c.OpenAIAPIKey, &c.keychainOpenAIKey}
+}
+
+// apiKeySlots returns the keys kept in the keychain
+func (c *Config) apiKeySlots() []apiKeySlot {
+	return []apiKeySlot{c.geminiKeySlot(), c.openAIKeySlot()}
+}
+
+// loadKeychainAPIKey reads the API keys from the keychain, first moving
+// plain-text keys from the config file there. Returns true if it moved one.
+// The caller must hold c.mu for writing.
+func (c *Config) loadKeychainAPIKey() bool {
+	if !c.KeychainAPIKey {
+		return false
+	}
+	moved := false
+	for _, slot := range c.apiKeySlots() {
+		if *slot.file != "" {
+			if err := keychain.Set(slot.account, *slot.file); err != nil {
+				fmt.Printf("[Config] Keeping %s in config file, keychain unavailable: %v\n", slot.account, err)
+				continue
+			}
+			*slot.cached = *slot.file
+			*slot.file = ""
+			fmt.Printf("[Config] Moved %s from config file to keychain\n", slot.account)
+			moved = true
+			continue
+		}
+		key, err := keychain.Get(slot.account)
+		if err != nil {
+			if !errors.Is(err, keychain.ErrNotFound) {
+				fmt.Printf("[Config] Failed to read %s: %v\n", slot.account, err)
+			}
+			continue
+		}
+		*slot.cached = key
+	}
+	return moved
+}
+
+// setAPIKey stores key in slot, in the keychain if enabled and available,
+// otherwise in the config file. The caller must hold c.mu for writing.
+func (c *Config) setAPIKey(slot apiKeySlot, key string) {
+	if c.KeychainAPIKey && key != "" && key == *slot.cached {
+		return // Already there; settings saves pass the current key back
+	}
+	if c.KeychainAPIKey {
+		var err error
+		if key == "" {
+			err = keychain.Delete(slot.account)
+		} else {
+			err = keychain.Set(slot.account, key)
+		}
+		if err == nil {
+			*slot.cached = key
+			*slot.file = ""
+			return
+		}
+		fmt.Printf("[Config] Storing %s in config file, keychain unavailable: %v\n", slot.account, err)
+	}
+	*slot.cached = ""
+	*slot.file = key
+}
+
+// key returns the key in slot, from the keychain if it's there
+func (slot apiKeySlot) key() string {
+	if *slot.cached != "" {
+		return *slot.cached
+	}
+	return *slot.file
+}
+
 // Save writes the config to disk
 func (c *Config) Save() error {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
+	return c.write()
+}
 
+// write saves the config to disk, readable only by the user since it may
+// hold API keys. The caller must hold c.mu.
+func (c *Config) write() error {
 	configPath, err := GetConfigPath()
 	if err != nil {
 		return err
@@ -126,7 +439,11 @@ func (c *Config) Save() error {
 		return err
 	}
 
-	return os.WriteFile(configPath, data, 0644)
+	if err := os.WriteFile(configPath, data, 0600); err != nil {
+		return err
+	}
+	// WriteFile keeps the mode of an existing file
+	return os.Chmod(configPath, 0600)
 }
 
 // GetGeminiAPIKey returns the Gemini API key
@@ -138,14 +455,59 @@ func (c *Config) GetGeminiAPIKey() string {
 	}
 	c.mu.RLock()
 	defer c.mu.RUnlock()
-	return c.GeminiAPIKey
+	return c.geminiKeySlot().key()
 }
 
-// SetGeminiAPIKey sets the Gemini API key
+// SetGeminiAPIKey sets the Gemini API key, in the keychain if enabled and
+// available, otherwise in the config file
 func (c *Config) SetGeminiAPIKey(key string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	c.GeminiAPIKey = key
+	c.setAPIKey(c.geminiKeySlot(), key)
+}
+
+// GetKeychainAPIKey returns whether the API keys are kept in the keychain
+func (c *Config) GetKeychainAPIKey() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.KeychainAPIKey
+}
+
+// SetKeychainAPIKey moves the API keys into the keychain or back into the
+// config file
+func (c *Config) SetKeychainAPIKey(enabled bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if enabled == c.KeychainAPIKey {
+		return nil
+	}
+	// Touch the keychain for every key before moving any, so a failure
+	// leaves all of them where they were
+	slots := c.apiKeySlots()
+	for _, slot := range slots {
+		var err error
+		if enabled && *slot.file != "" {
+			err = keychain.Set(slot.account, *slot.file)
+		} else if !enabled {
+			err = keychain.Delete(slot.account)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	for _, slot := range slots {
+		if enabled {
+			*slot.cached = *slot.file
+			*slot.file = ""
+		} else {
+			if *slot.cached != "" {
+				*slot.file = *slot.cached
+			}
+			*slot.cached = ""
+		}
+	}
+	c.KeychainAPIKey = enabled
+	return nil
 }
 
 // GetHandsFreeHotkey returns the hands-free hotkey
@@ -234,3 +596,984 @@ func (c *Config) SetMiniModePosition(x, y int) {
 	c.MiniModeX = x
 	c.MiniModeY = y
 }
+
+// GetLineEnding returns the line ending used for clipboard text
+func (c *Config) GetLineEnding() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.LineEnding
+}
+
+// SetLineEnding sets the line ending used for clipboard text
+func (c *Config) SetLineEnding(ending string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.LineEnding = ending
+}
+
+// GetDateTokenStage returns when date/time voice tokens are expanded (before, after, off)
+func (c *Config) GetDateTokenStage() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.DateTokenStage
+}
+
+// SetDateTokenStage sets when date/time voice tokens are expanded
+func (c *Config) SetDateTokenStage(stage string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.DateTokenStage = stage
+}
+
+// GetDateTimeFormats returns the layouts used for expanded date and time tokens
+func (c *Config) GetDateTimeFormats() (string, string) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.DateFormat, c.TimeFormat
+}
+
+// SetDateTimeFormats sets the layouts used for expanded date and time tokens
+func (c *Config) SetDateTimeFormats(dateFormat, timeFormat string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.DateFormat = dateFormat
+	c.TimeFormat = timeFormat
+}
+
+// GetMaxOutputChars returns the output character limit for a mode (0 = unlimited)
+func (c *Config) GetMaxOutputChars(mode string) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.MaxOutputChars[mode]
+}
+
+// SetMaxOutputChars sets the output character limit for a mode (0 removes it)
+func (c *Config) SetMaxOutputChars(mode string, maxChars int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if maxChars <= 0 {
+		delete(c.MaxOutputChars, mode)
+		return
+	}
+	if c.MaxOutputChars == nil {
+		c.MaxOutputChars = make(map[string]int)
+	}
+	c.MaxOutputChars[mode] = maxChars
+}
+
+// GetLocalServer returns whether the local HTTP server is enabled and its port
+func (c *Config) GetLocalServer() (bool, int) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.LocalServerEnabled, c.LocalServerPort
+}
+
+// SetLocalServerEnabled enables or disables the local HTTP server
+func (c *Config) SetLocalServerEnabled(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.LocalServerEnabled = enabled
+}
+
+// GetChunking returns the whisper chunk length and overlap in seconds
+func (c *Config) GetChunking() (int, int) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ChunkSeconds, c.ChunkOverlapSecs
+}
+
+// SetChunking sets the whisper chunk length and overlap in seconds
+func (c *Config) SetChunking(chunkSeconds, overlapSeconds int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ChunkSeconds = chunkSeconds
+	c.ChunkOverlapSecs = overlapSeconds
+}
+
+// GetPrivacyClearClipboard returns whether privacy mode clears the clipboard after injection
+func (c *Config) GetPrivacyClearClipboard() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.PrivacyClearClipboard
+}
+
+// SetPrivacyClearClipboard sets whether privacy mode clears the clipboard after injection
+func (c *Config) SetPrivacyClearClipboard(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.PrivacyClearClipboard = enabled
+}
+
+// GetCustomModes returns a copy of the user-defined modes in display order
+func (c *Config) GetCustomModes() []CustomMode {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	modes := make([]CustomMode, len(c.CustomModes))
+	copy(modes, c.CustomModes)
+	return modes
+}
+
+// SetCustomModes replaces all user-defined modes
+func (c *Config) SetCustomModes(modes []CustomMode) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.CustomModes = make([]CustomMode, len(modes))
+	copy(c.CustomModes, modes)
+}
+
+// SaveCustomMode adds a user-defined mode, or updates its prompt if it exists
+func (c *Config) SaveCustomMode(name, prompt string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, m := range c.CustomModes {
+		if m.Name == name {
+			c.CustomModes[i].Prompt = prompt
+			return
+		}
+	}
+	c.CustomModes = append(c.CustomModes, CustomMode{Name: name, Prompt: prompt})
+}
+
+// DeleteCustomMode removes a user-defined mode and its per-mode settings.
+// If it was the active mode, the mode falls back to casual.
+func (c *Config) DeleteCustomMode(name string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, m := range c.CustomModes {
+		if m.Name == name {
+			c.CustomModes = append(c.CustomModes[:i], c.CustomModes[i+1:]...)
+			delete(c.MaxOutputChars, name)
+			if c.Mode == name {
+				c.Mode = "casual"
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("custom mode not found: %s", name)
+}
+
+// ReorderCustomModes reorders the user-defined modes. order must contain
+// every custom mode name exactly once.
+func (c *Config) ReorderCustomModes(order []string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(order) != len(c.CustomModes) {
+		return fmt.Errorf("order must list all %d custom modes", len(c.CustomModes))
+	}
+
+	byName := make(map[string]CustomMode, len(c.CustomModes))
+	for _, m := range c.CustomModes {
+		byName[m.Name] = m
+	}
+
+	reordered := make([]CustomMode, 0, len(order))
+	for _, name := range order {
+		m, ok := byName[name]
+		if !ok {
+			return fmt.Errorf("unknown or duplicate mode: %s", name)
+		}
+		reordered = append(reordered, m)
+		delete(byName, name)
+	}
+
+	c.CustomModes = reordered
+	return nil
+}
+
+// RenameCustomMode renames a user-defined mode and updates every setting that refers to it
+func (c *Config) RenameCustomMode(oldName, newName string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	index := -1
+	for i, m := range c.CustomModes {
+		if m.Name == newName {
+			return fmt.Errorf("a mode named %s already exists", newName)
+		}
+		if m.Name == oldName {
+			index = i
+		}
+	}
+	if index < 0 {
+		return fmt.Errorf("custom mode not found: %s", oldName)
+	}
+
+	c.CustomModes[index].Name = newName
+	if c.Mode == oldName {
+		c.Mode = newName
+	}
+	if limit, ok := c.MaxOutputChars[oldName]; ok {
+		delete(c.MaxOutputChars, oldName)
+		c.MaxOutputChars[newName] = limit
+	}
+	return nil
+}
+
+// GetAppearance returns the app appearance (system, light, dark)
+func (c *Config) GetAppearance() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.Appearance
+}
+
+// SetAppearance sets the app appearance
+func (c *Config) SetAppearance(appearance string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Appearance = appearance
+}
+
+// GetDownloadStallSeconds returns how long a model download may stall before retrying
+func (c *Config) GetDownloadStallSeconds() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.DownloadStallSeconds
+}
+
+// SetDownloadStallSeconds sets how long a model download may stall before retrying
+func (c *Config) SetDownloadStallSeconds(seconds int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.DownloadStallSeconds = seconds
+}
+
+// GetConfirmBeforeInject returns whether injection requires confirmation
+func (c *Config) GetConfirmBeforeInject() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ConfirmBeforeInject
+}
+
+// SetConfirmBeforeInject sets whether injection requires confirmation
+func (c *Config) SetConfirmBeforeInject(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ConfirmBeforeInject = enabled
+}
+
+// GetEmergencyStopHotkey returns the emergency stop hotkey
+func (c *Config) GetEmergencyStopHotkey() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.EmergencyStopHotkey
+}
+
+// SetEmergencyStopHotkey sets the emergency stop hotkey
+func (c *Config) SetEmergencyStopHotkey(hotkey string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.EmergencyStopHotkey = hotkey
+}
+
+// GetWhisperDecoding returns the whisper beam size and temperature
+func (c *Config) GetWhisperDecoding() (int, float64) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.BeamSize, c.WhisperTemperature
+}
+
+// SetBeamSize sets the whisper beam size
+func (c *Config) SetBeamSize(beamSize int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.BeamSize = beamSize
+}
+
+// SetWhisperTemperature sets the whisper sampling temperature
+func (c *Config) SetWhisperTemperature(temperature float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.WhisperTemperature = temperature
+}
+
+// GetQuitWhileBusy returns the policy for quitting during recording or processing
+func (c *Config) GetQuitWhileBusy() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.QuitWhileBusy
+}
+
+// SetQuitWhileBusy sets the policy for quitting during recording or processing
+func (c *Config) SetQuitWhileBusy(policy string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.QuitWhileBusy = policy
+}
+
+// GetCaseStyle returns the casing applied to polished text
+func (c *Config) GetCaseStyle() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.CaseStyle
+}
+
+// SetCaseStyle sets the casing applied to polished text
+func (c *Config) SetCaseStyle(style string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.CaseStyle = style
+}
+
+// GetToneTagging returns whether refinement also classifies tone
+func (c *Config) GetToneTagging() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ToneTagging
+}
+
+// SetToneTagging sets whether refinement also classifies tone
+func (c *Config) SetToneTagging(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ToneTagging = enabled
+}
+
+// GetCodeSpokenSymbols returns whether spoken symbols are converted locally in code mode
+func (c *Config) GetCodeSpokenSymbols() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.CodeSpokenSymbols
+}
+
+// SetCodeSpokenSymbols sets whether spoken symbols are converted locally in code mode
+func (c *Config) SetCodeSpokenSymbols(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.CodeSpokenSymbols = enabled
+}
+
+// GetPasteRetries returns how many times a failed paste is retried
+func (c *Config) GetPasteRetries() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.PasteRetries
+}
+
+// SetPasteRetries sets how many times a failed paste is retried
+func (c *Config) SetPasteRetries(retries int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.PasteRetries = retries
+}
+
+// GetQuickNoteHotkey returns the quick-note hotkey
+func (c *Config) GetQuickNoteHotkey() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.QuickNoteHotkey
+}
+
+// SetQuickNoteHotkey sets the quick-note hotkey
+func (c *Config) SetQuickNoteHotkey(hotkey string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.QuickNoteHotkey = hotkey
+}
+
+// GetKeepPillDuringProcessing returns whether the mini indicator stays pinned while processing
+func (c *Config) GetKeepPillDuringProcessing() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.KeepPillDuringProcessing
+}
+
+// SetKeepPillDuringProcessing sets whether the mini indicator stays pinned while processing
+func (c *Config) SetKeepPillDuringProcessing(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.KeepPillDuringProcessing = enabled
+}
+
+// GetInputDevice returns the selected microphone name
+func (c *Config) GetInputDevice() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.InputDevice
+}
+
+// SetInputDevice sets the selected microphone name
+func (c *Config) SetInputDevice(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.InputDevice = name
+}
+
+// GetSilenceStop returns the hands-free silence timeout (seconds) and threshold (dBFS)
+func (c *Config) GetSilenceStop() (float64, float64) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.SilenceTimeoutSecs, c.SilenceThresholdDB
+}
+
+// SetSilenceStop sets the hands-free silence timeout (seconds) and threshold (dBFS)
+func (c *Config) SetSilenceStop(timeoutSecs, thresholdDB float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.SilenceTimeoutSecs = timeoutSecs
+	c.SilenceThresholdDB = thresholdDB
+}
+
+// GetBatchConcurrency returns how many items batch operations process in parallel
+func (c *Config) GetBatchConcurrency() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.BatchConcurrency
+}
+
+// SetBatchConcurrency sets how many items batch operations process in parallel
+func (c *Config) SetBatchConcurrency(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.BatchConcurrency = n
+}
+
+// GetMaxRecordingSecs returns the recording length cap in seconds (0 = unlimited)
+func (c *Config) GetMaxRecordingSecs() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.MaxRecordingSecs
+}
+
+// SetMaxRecordingSecs sets the recording length cap in seconds (0 = unlimited)
+func (c *Config) SetMaxRecordingSecs(secs int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MaxRecordingSecs = secs
+}
+
+// GetConfirmDelete returns whether the UI asks before deleting history entries
+func (c *Config) GetConfirmDelete() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ConfirmDelete
+}
+
+// SetConfirmDelete sets whether the UI asks before deleting history entries
+func (c *Config) SetConfirmDelete(confirm bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ConfirmDelete = confirm
+}
+
+// GetTrashRetentionDays returns how many days deleted transcripts stay restorable
+func (c *Config) GetTrashRetentionDays() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.TrashRetentionDays
+}
+
+// SetTrashRetentionDays sets how many days deleted transcripts stay restorable
+func (c *Config) SetTrashRetentionDays(days int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.TrashRetentionDays = days
+}
+
+// GetPreRollMs returns how much audio from before the hotkey is kept, in milliseconds
+func (c *Config) GetPreRollMs() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.PreRollMs
+}
+
+// SetPreRollMs sets how much audio from before the hotkey is kept, in milliseconds
+func (c *Config) SetPreRollMs(ms int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.PreRollMs = ms
+}
+
+// GetInputGain returns the software amplification applied to the microphone
+func (c *Config) GetInputGain() float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.InputGain
+}
+
+// SetInputGain sets the software amplification applied to the microphone
+func (c *Config) SetInputGain(gain float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.InputGain = gain
+}
+
+// GetKeepRecordings returns whether recordings are archived with their transcripts
+func (c *Config) GetKeepRecordings() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.KeepRecordings
+}
+
+// SetKeepRecordings sets whether recordings are archived with their transcripts
+func (c *Config) SetKeepRecordings(keep bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.KeepRecordings = keep
+}
+
+// GetRecordingsDir returns the configured recordings directory ("" = default)
+func (c *Config) GetRecordingsDir() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.RecordingsDir
+}
+
+// SetRecordingsDir sets the recordings directory ("" = default)
+func (c *Config) SetRecordingsDir(dir string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.RecordingsDir = dir
+}
+
+// GetRecordingRetention returns the maximum number and age in days of kept recordings (0 = no limit)
+func (c *Config) GetRecordingRetention() (int, int) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.RecordingRetentionCount, c.RecordingRetentionDays
+}
+
+// SetRecordingRetention sets the maximum number and age in days of kept recordings (0 = no limit)
+func (c *Config) SetRecordingRetention(count, days int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.RecordingRetentionCount = count
+	c.RecordingRetentionDays = days
+}
+
+// GetLanguage returns the transcription language code ("auto" = detect)
+func (c *Config) GetLanguage() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.Language
+}
+
+// SetLanguage sets the transcription language code ("auto" = detect)
+func (c *Config) SetLanguage(code string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Language = code
+}
+
+// GetTranslate returns whether whisper translates speech to English
+func (c *Config) GetTranslate() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.Translate
+}
+
+// SetTranslate sets whether whisper translates speech to English
+func (c *Config) SetTranslate(translate bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Translate = translate
+}
+
+// GetWhisperThreads returns how many CPU threads whisper-cli uses
+func (c *Config) GetWhisperThreads() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.WhisperThreads
+}
+
+// SetWhisperThreads sets how many CPU threads whisper-cli uses
+func (c *Config) SetWhisperThreads(threads int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.WhisperThreads = threads
+}
+
+// GetModelsDir returns the configured models directory ("" = default)
+func (c *Config) GetModelsDir() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ModelsDir
+}
+
+// SetModelsDir sets the models directory ("" = default)
+func (c *Config) SetModelsDir(dir string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ModelsDir = dir
+}
+
+// GetVocabularyPrompt returns the terms whisper is biased towards
+func (c *Config) GetVocabularyPrompt() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.VocabularyPrompt
+}
+
+// SetVocabularyPrompt sets the terms whisper is biased towards
+func (c *Config) SetVocabularyPrompt(prompt string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.VocabularyPrompt = prompt
+}
+
+// GetGeminiModel returns the Gemini model used for refinement
+func (c *Config) GetGeminiModel() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.GeminiModel
+}
+
+// SetGeminiModel sets the Gemini model used for refinement
+func (c *Config) SetGeminiModel(model string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.GeminiModel = model
+}
+
+// GetGeminiRetry returns the attempts per Gemini request and their total time budget in seconds
+func (c *Config) GetGeminiRetry() (int, int) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.GeminiMaxAttempts, c.GeminiRetrySeconds
+}
+
+// SetGeminiRetry sets the attempts per Gemini request and their total time budget in seconds
+func (c *Config) SetGeminiRetry(attempts, seconds int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.GeminiMaxAttempts = attempts
+	c.GeminiRetrySeconds = seconds
+}
+
+// GetRefinementBackend returns the service used for refinement (gemini, openai)
+func (c *Config) GetRefinementBackend() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.RefinementBackend
+}
+
+// SetRefinementBackend sets the service used for refinement (gemini, openai)
+func (c *Config) SetRefinementBackend(backend string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.RefinementBackend = backend
+}
+
+// GetOpenAIEndpoint returns the OpenAI-compatible backend's base URL, model and API key
+func (c *Config) GetOpenAIEndpoint() (string, string, string) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.OpenAIBaseURL, c.OpenAIModel, c.openAIKeySlot().key()
+}
+
+// SetOpenAIEndpoint sets the OpenAI-compatible backend's base URL, model and
+// API key. The key goes in the keychain if enabled and available.
+func (c *Config) SetOpenAIEndpoint(baseURL, model, apiKey string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.OpenAIBaseURL = baseURL
+	c.OpenAIModel = model
+	c.setAPIKey(c.openAIKeySlot(), apiKey)
+}
+
+// GetRefinementEnabled returns whether transcriptions are refined
+func (c *Config) GetRefinementEnabled() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.RefinementEnabled
+}
+
+// SetRefinementEnabled sets whether transcriptions are refined
+func (c *Config) SetRefinementEnabled(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.RefinementEnabled = enabled
+}
+
+// GetReplacements returns a copy of the text replacement dictionary
+func (c *Config) GetReplacements() map[string]string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	replacements := make(map[string]string, len(c.Replacements))
+	for from, to := range c.Replacements {
+		replacements[from] = to
+	}
+	return replacements
+}
+
+// SetReplacements replaces the whole text replacement dictionary
+func (c *Config) SetReplacements(replacements map[string]string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Replacements = make(map[string]string, len(replacements))
+	for from, to := range replacements {
+		c.Replacements[from] = to
+	}
+}
+
+// AddReplacement adds or updates a text replacement
+func (c *Config) AddReplacement(from, to string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.Replacements == nil {
+		c.Replacements = make(map[string]string)
+	}
+	c.Replacements[from] = to
+}
+
+// RemoveReplacement removes a text replacement
+func (c *Config) RemoveReplacement(from string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.Replacements[from]; !ok {
+		return fmt.Errorf("replacement not found: %s", from)
+	}
+	delete(c.Replacements, from)
+	return nil
+}
+
+// GetProxy returns the outbound proxy URL for Gemini requests
+func (c *Config) GetProxy() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.Proxy
+}
+
+// SetProxy sets the outbound proxy URL for Gemini requests
+func (c *Config) SetProxy(proxyURL string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Proxy = proxyURL
+}
+
+// GetGeminiTimeoutSeconds returns how long a single Gemini request may take
+func (c *Config) GetGeminiTimeoutSeconds() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.GeminiTimeoutSeconds
+}
+
+// SetGeminiTimeoutSeconds sets how long a single Gemini request may take
+func (c *Config) SetGeminiTimeoutSeconds(seconds int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.GeminiTimeoutSeconds = seconds
+}
+
+// GetDoubleTapWindowMs returns the longest gap between the taps of a double-tap hotkey
+func (c *Config) GetDoubleTapWindowMs() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.DoubleTapWindowMs
+}
+
+// SetDoubleTapWindowMs sets the longest gap between the taps of a double-tap hotkey
+func (c *Config) SetDoubleTapWindowMs(ms int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.DoubleTapWindowMs = ms
+}
+
+// GetPTTMinHoldMs returns how long push-to-talk must be held to count
+func (c *Config) GetPTTMinHoldMs() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.PTTMinHoldMs
+}
+
+// SetPTTMinHoldMs sets how long push-to-talk must be held to count
+func (c *Config) SetPTTMinHoldMs(ms int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.PTTMinHoldMs = ms
+}
+
+// GetCycleModeHotkey returns the hotkey that switches to the next mode
+func (c *Config) GetCycleModeHotkey() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.CycleModeHotkey
+}
+
+// SetCycleModeHotkey sets the hotkey that switches to the next mode
+func (c *Config) SetCycleModeHotkey(hotkey string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.CycleModeHotkey = hotkey
+}
+
+// GetHistoryRetention returns the maximum age in days and number of kept transcripts (0 = no limit)
+func (c *Config) GetHistoryRetention() (int, int) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.HistoryRetentionDays, c.HistoryMaxEntries
+}
+
+// SetHistoryRetention sets the maximum age in days and number of kept transcripts (0 = no limit)
+func (c *Config) SetHistoryRetention(days, maxEntries int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.HistoryRetentionDays = days
+	c.HistoryMaxEntries = maxEntries
+}
+
+// GetEncryptHistory returns whether transcript text is encrypted at rest
+func (c *Config) GetEncryptHistory() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.EncryptHistory
+}
+
+// SetEncryptHistory sets whether transcript text is encrypted at rest
+func (c *Config) SetEncryptHistory(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.EncryptHistory = enabled
+}
+
+// GetReinjectDelayMs returns how long to wait for focus to return before pasting from history
+func (c *Config) GetReinjectDelayMs() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ReinjectDelayMs
+}
+
+// SetReinjectDelayMs sets how long to wait for focus to return before pasting from history
+func (c *Config) SetReinjectDelayMs(ms int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ReinjectDelayMs = ms
+}
+
+// GetInjectionMode returns how text is injected (paste or type)
+func (c *Config) GetInjectionMode() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.InjectionMode
+}
+
+// SetInjectionMode sets how text is injected (paste or type)
+func (c *Config) SetInjectionMode(mode string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.InjectionMode = mode
+}
+
+// GetPasteDelays returns the clipboard settle, post-paste and restore waits in
+// milliseconds, and whether the settle wait is adaptive
+func (c *Config) GetPasteDelays() (settleMs, postPasteMs, restoreMs int, adaptive bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ClipboardSettleMs, c.PostPasteMs, c.ClipboardRestoreMs, c.AdaptivePaste
+}
+
+// SetPasteDelays sets the clipboard settle, post-paste and restore waits in
+// milliseconds, and whether the settle wait is adaptive
+func (c *Config) SetPasteDelays(settleMs, postPasteMs, restoreMs int, adaptive bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ClipboardSettleMs = settleMs
+	c.PostPasteMs = postPasteMs
+	c.ClipboardRestoreMs = restoreMs
+	c.AdaptivePaste = adaptive
+}
+
+// GetWindowGeometry returns the saved full app window position and size
+func (c *Config) GetWindowGeometry() (x, y, width, height int) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.WindowX, c.WindowY, c.WindowWidth, c.WindowHeight
+}
+
+// SetWindowGeometry sets the saved full app window position and size
+func (c *Config) SetWindowGeometry(x, y, width, height int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.WindowX = x
+	c.WindowY = y
+	c.WindowWidth = width
+	c.WindowHeight = height
+}
+
+// GetPillOpacity returns the opacity of the mini pill
+func (c *Config) GetPillOpacity() float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.PillOpacity
+}
+
+// SetPillOpacity sets the opacity of the mini pill
+func (c *Config) SetPillOpacity(opacity float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.PillOpacity = opacity
+}
+
+// GetLaunchAtLogin returns whether voxflow should start at login
+func (c *Config) GetLaunchAtLogin() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.LaunchAtLogin
+}
+
+// SetLaunchAtLogin sets whether voxflow should start at login
+func (c *Config) SetLaunchAtLogin(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.LaunchAtLogin = enabled
+}
+
+// GetSoundCues returns whether start/stop cues play, and their volume
+func (c *Config) GetSoundCues() (bool, float64) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.PlaySounds, c.SoundVolume
+}
+
+// SetSoundCues sets whether start/stop cues play, and their volume
+func (c *Config) SetSoundCues(enabled bool, volume float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.PlaySounds = enabled
+	c.SoundVolume = volume
+}
+
+// GetNotifyOnComplete returns whether a notification is posted when processing ends
+func (c *Config) GetNotifyOnComplete() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.NotifyOnComplete
+}
+
+// SetNotifyOnComplete sets whether a notification is posted when processing ends
+func (c *Config) SetNotifyOnComplete(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.NotifyOnComplete = enabled
+}
+
+// GetShowTrayIcon returns whether the menu bar icon is shown
+func (c *Config) GetShowTrayIcon() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ShowTrayIcon
+}
+
+// SetShowTrayIcon sets whether the menu bar icon is shown
+func (c *Config) SetShowTrayIcon(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ShowTrayIcon = enabled
+}
+
+// GetBlankAudioRetry returns how many times transcription is retried when no
+// speech is detected, and the wait between attempts in milliseconds
+func (c *Config) GetBlankAudioRetry() (retries, delayMs int) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.BlankAudioRetries, c.BlankAudioRetryDelayMs
+}
+
+// SetBlankAudioRetry sets how many times transcription is retried when no
+// speech is detected, and the wait between attempts in milliseconds
+func (c *Config) SetBlankAudioRetry(retries, delayMs int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.BlankAudioRetries = retries
+	c.BlankAudioRetryDelayMs = delayMs
+}
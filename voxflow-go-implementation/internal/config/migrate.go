@@ -0,0 +1,40 @@
+package config
+
+import "fmt"
+
+// migrations upgrade a config loaded from disk one schema version at a time:
+// migrations[i] takes it from version i to i+1. Append a step and the
+// version moves with it; never reorder or remove old ones.
+var migrations = []func(c *Config){
+	migrateLegacyHotkey, // 0 -> 1
+}
+
+// CurrentVersion is the schema version this build writes
+var CurrentVersion = len(migrations)
+
+// migrate applies the migrations after version from in order and bumps
+// c.Version. Returns whether anything ran. The caller must hold c.mu for writing.
+func (c *Config) migrate(from int) bool {
+	if from > CurrentVersion {
+		fmt.Printf("[Config] config.json is version %d, newer than this build (%d); unknown settings are ignored\n", from, CurrentVersion)
+		return false
+	}
+	if from < 0 {
+		from = 0
+	}
+	for v := from; v < CurrentVersion; v++ {
+		migrations[v](c)
+		fmt.Printf("[Config] Migrated config from version %d to %d\n", v, v+1)
+	}
+	c.Version = CurrentVersion
+	return from < CurrentVersion
+}
+
+// migrateLegacyHotkey moves the single hotkey of early versions to the
+// hands-free hotkey
+func migrateLegacyHotkey(c *Config) {
+	if c.Hotkey != "" && c.HandsFreeHotkey == "" {
+		c.HandsFreeHotkey = c.Hotkey
+	}
+	c.Hotkey = ""
+}
@@ -2,7 +2,9 @@ package gemini
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -11,22 +13,64 @@ import (
 )
 
 const (
-	baseURL = "https://generativelanguage.googleapis.com/v1/models/gemini-2.0-flash:generateContent"
+	baseURL = "https://generativelanguage.googleapis.com/v1/models/"
+
+	// DefaultModel is used when no model is configured
+	DefaultModel = "gemini-2.0-flash"
+
+	// DefaultTimeout limits each request unless configured otherwise
+	DefaultTimeout = 30 * time.Second
 )
 
+// Models are the Gemini models offered in settings; SetModel accepts others too
+var Models = []string{
+	"gemini-2.0-flash",
+	"gemini-2.0-flash-lite",
+	"gemini-2.5-flash",
+	"gemini-2.5-flash-lite",
+	"gemini-2.5-pro",
+}
+
+// RawMode skips refinement and uses the transcription as is
+const RawMode = "raw"
+
+// BuiltinModes are the refinement modes with prompts defined in buildSystemPrompt,
+// plus RawMode
+var BuiltinModes = []string{"casual", "formal", "code", RawMode}
+
+// IsBuiltinMode returns whether mode is one of the built-in modes
+func IsBuiltinMode(mode string) bool {
+	for _, m := range BuiltinModes {
+		if m == mode {
+			return true
+		}
+	}
+	return false
+}
+
 // Client handles communication with the Gemini API
 type Client struct {
-	apiKey     string
-	httpClient *http.Client
+	UsageCounter
+	apiKey       string
+	model        string
+	maxAttempts  int           // Tries per request, including the first
+	maxRetryTime time.Duration // Total time budget per request, including backoff
+	timeout      time.Duration // Limit for each individual request
+	httpClient   *http.Client
+	proxyErr     error             // Set by SetProxy when the configured proxy is invalid
+	toneTagging  bool              // Ask refinement to also classify the tone
+	customModes  map[string]string // User-defined mode name -> prompt
 }
 
 // NewClient creates a new Gemini client
 func NewClient(apiKey string) *Client {
 	return &Client{
-		apiKey: apiKey,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		apiKey:       apiKey,
+		model:        DefaultModel,
+		maxAttempts:  DefaultMaxAttempts,
+		maxRetryTime: DefaultMaxRetryTime,
+		timeout:      DefaultTimeout,
+		httpClient:   &http.Client{},
 	}
 }
 
@@ -35,6 +79,48 @@ func (c *Client) SetAPIKey(apiKey string) {
 	c.apiKey = apiKey
 }
 
+// SetModel sets the Gemini model used for refinement ("" = DefaultModel)
+func (c *Client) SetModel(name string) {
+	if name == "" {
+		name = DefaultModel
+	}
+	c.model = name
+}
+
+// SetTimeout sets how long a single request may take (0 = DefaultTimeout)
+func (c *Client) SetTimeout(timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	c.timeout = timeout
+}
+
+// endpoint returns the generateContent URL for the configured model
+func (c *Client) endpoint() string {
+	return fmt.Sprintf("%s%s:generateContent?key=%s", baseURL, c.model, c.apiKey)
+}
+
+// SetToneTagging sets whether refinement also classifies the speaker's tone
+func (c *Client) SetToneTagging(enabled bool) {
+	c.toneTagging = enabled
+}
+
+// SetCustomModes sets the prompts of user-defined modes, keyed by mode name
+func (c *Client) SetCustomModes(prompts map[string]string) {
+	c.customModes = prompts
+}
+
+// Tones is the set of tone tags refinement may return
+var Tones = []string{"neutral", "positive", "negative", "excited"}
+
+// toneInstructions extends the output format when tone tagging is enabled
+const toneInstructions = `
+
+=== TONE TAGGING ===
+Also classify the overall tone of the speaker as exactly one of: neutral, positive, negative, excited.
+Add it to the JSON output as a "tone" field:
+{"text": "your refined text here", "refused": false, "tone": "neutral"}`
+
 // Request represents a Gemini API request
 type Request struct {
 	Contents         []Content        `json:"contents"`
@@ -60,8 +146,9 @@ type GenerationConfig struct {
 
 // Response represents a Gemini API response
 type Response struct {
-	Candidates []Candidate `json:"candidates"`
-	Error      *APIError   `json:"error,omitempty"`
+	Candidates    []Candidate    `json:"candidates"`
+	UsageMetadata *UsageMetadata `json:"usageMetadata,omitempty"`
+	Error         *APIError      `json:"error,omitempty"`
 }
 
 // Candidate represents a generated candidate
@@ -80,17 +167,28 @@ type APIError struct {
 type RefineResponse struct {
 	Text    string `json:"text"`
 	Refused bool   `json:"refused"`
+	Tone    string `json:"tone,omitempty"` // Only set when tone tagging is enabled
 }
 
 // RefineText sends raw transcription to Gemini for refinement
 func (c *Client) RefineText(rawText string, mode string) (string, error) {
-	fmt.Printf("[Gemini] Refining text: %s\n", rawText)
+	text, _, _, err := c.RefineTextWithTone(context.Background(), rawText, mode)
+	return text, err
+}
+
+// RefineTextWithTone refines the transcription and, if tone tagging is
+// enabled, also returns the classified tone ("" if unavailable) and the
+// tokens billed for the request. Cancelling ctx aborts the request,
+// including any retries.
+func (c *Client) RefineTextWithTone(ctx context.Context, rawText string, mode string) (string, string, Usage, error) {
+	// Lengths only: the log ends up on disk and in diagnostics
+	fmt.Printf("[Gemini] Refining text (%d chars)\n", len(rawText))
 	if c.apiKey == "" {
-		return "", fmt.Errorf("API key not set")
+		return "", "", Usage{}, fmt.Errorf("API key not set")
 	}
 
 	// Build the system prompt based on mode
-	systemPrompt := buildSystemPrompt(mode)
+	systemPrompt := SystemPrompt(mode, c.customModes, c.toneTagging)
 
 	// Create the request
 	req := Request{
@@ -107,62 +205,50 @@ func (c *Client) RefineText(rawText string, mode string) (string, error) {
 		},
 	}
 
-	// Marshal request
-	reqBody, err := json.Marshal(req)
+	result, usage, err := c.generate(ctx, req)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %w", err)
+		return "", "", usage, err
 	}
 
-	// Build URL with API key
-	url := fmt.Sprintf("%s?key=%s", baseURL, c.apiKey)
+	fmt.Printf("[Gemini] Raw output (%d chars)\n", len(result))
 
-	// Make HTTP request
-	httpReq, err := http.NewRequest("POST", url, bytes.NewReader(reqBody))
-	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
-	}
-	httpReq.Header.Set("Content-Type", "application/json")
-
-	// Send request
-	resp, err := c.httpClient.Do(httpReq)
-	if err != nil {
-		return "", fmt.Errorf("failed to send request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Read response
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("failed to read response: %w", err)
-	}
-
-	// Parse response
-	var geminiResp Response
-	if err := json.Unmarshal(respBody, &geminiResp); err != nil {
-		return "", fmt.Errorf("failed to parse response: %w", err)
-	}
+	text, tone := ParseRefineOutput(result, rawText)
+	return text, tone, usage, nil
+}
 
-	// Check for API error
-	if geminiResp.Error != nil {
-		return "", fmt.Errorf("API error: %s (code: %d)", geminiResp.Error.Message, geminiResp.Error.Code)
+// SystemPrompt returns the refinement instructions for mode, looking it up in
+// customModes before the built-ins, including the tone tagging extension if
+// enabled. Shared by all refinement backends.
+func SystemPrompt(mode string, customModes map[string]string, toneTagging bool) string {
+	var prompt string
+	if custom, ok := customModes[mode]; ok {
+		prompt = strings.TrimSpace(custom) + "\n\n" + outputFormatInstructions
+	} else {
+		prompt = buildSystemPrompt(mode)
 	}
-
-	// Extract the refined text
-	if len(geminiResp.Candidates) == 0 || geminiResp.Candidates[0].Content == nil {
-		return "", fmt.Errorf("no response generated")
+	if toneTagging {
+		prompt += toneInstructions
 	}
+	return prompt
+}
 
-	if len(geminiResp.Candidates[0].Content.Parts) == 0 {
-		return "", fmt.Errorf("empty response")
-	}
+// InstructionPrompt returns the prompt for applying a custom instruction to text
+func InstructionPrompt(text, instruction string) string {
+	return fmt.Sprintf(`Apply the following instruction to the text:
+Instruction: %s
 
-	result := geminiResp.Candidates[0].Content.Parts[0].Text
+Text:
+%s
 
-	// Debug logging
-	fmt.Printf("[Gemini] Raw output (%d chars):\n%s\n", len(result), result)
+Return ONLY the modified text, nothing else.`, instruction, text)
+}
 
+// ParseRefineOutput extracts the refined text and tone from a model's reply
+// to SystemPrompt. Refusals return rawText; replies that aren't the expected
+// JSON are used as plain text.
+func ParseRefineOutput(result, rawText string) (string, string) {
 	// Clean up result - remove markdown code blocks if present
-	cleanResult := result
+	cleanResult := strings.TrimSpace(result)
 	if strings.HasPrefix(cleanResult, "```json") {
 		cleanResult = strings.TrimPrefix(cleanResult, "```json")
 		cleanResult = strings.TrimSuffix(strings.TrimSpace(cleanResult), "```")
@@ -178,18 +264,69 @@ func (c *Client) RefineText(rawText string, mode string) (string, error) {
 	if err := json.Unmarshal([]byte(cleanResult), &refineResp); err == nil {
 		// Successfully parsed JSON
 		if refineResp.Refused {
-			fmt.Printf("[Gemini] Content was refused, using raw text instead\n")
-			return rawText, nil
+			fmt.Printf("[Refine] Content was refused, using raw text instead\n")
+			return rawText, ""
 		}
-		// Return the text (even if empty - that's what Gemini gave us)
-		return refineResp.Text, nil
+		// Return the text (even if empty - that's what the model gave us)
+		return refineResp.Text, normalizeTone(refineResp.Tone)
 	}
 
-	// If JSON parsing failed, Gemini returned plain text (old behavior)
-	fmt.Printf("[Gemini] Warning: Response was not valid JSON, using as plain text\n")
-	return cleanResult, nil
+	// If JSON parsing failed, the model returned plain text (old behavior)
+	fmt.Printf("[Refine] Warning: Response was not valid JSON, using as plain text\n")
+	return cleanResult, ""
 }
 
+// normalizeTone returns tone if it is a known tag, otherwise ""
+func normalizeTone(tone string) string {
+	tone = strings.ToLower(strings.TrimSpace(tone))
+	for _, t := range Tones {
+		if t == tone {
+			return tone
+		}
+	}
+	return ""
+}
+
+// outputFormatInstructions is the JSON output contract shared by all modes
+const outputFormatInstructions = `=== OUTPUT FORMAT (CRITICAL) ===
+You MUST respond with valid JSON in this exact format:
+{"text": "your refined text here", "refused": false}
+
+If the content contains something you cannot process due to ethical guidelines:
+{"text": "", "refused": true}
+
+Rules:
+1. ALWAYS output valid JSON, nothing else
+2. The "text" field contains the refined transcription
+3. Set "refused" to true ONLY if you cannot process the content
+4. NO markdown, NO code blocks, NO explanations
+5. Preserve the speaker's intent and meaning
+6. When in doubt, keep the original phrasing`
+
+// codeModePrompt is a minimal-editing prompt for dictating code and identifiers
+const codeModePrompt = `You are a voice-to-text assistant for software developers. The speaker is dictating code, identifiers, commands or technical text. Edit as little as possible.
+
+=== CODE MODE RULES ===
+- Transcribe verbatim. Do NOT rephrase, summarize, or "improve" wording
+- Do NOT insert punctuation, spaces or capitalization into identifiers, file names, paths or URLs
+- Preserve symbols exactly as given: { } [ ] ( ) < > = + - * / \ | & ^ % $ # @ ! ~ _ . , ; : ' " and backticks
+- Preserve casing of identifiers (camelCase, PascalCase, snake_case, SCREAMING_CASE, kebab-case)
+- Only remove obvious filler words (um, uh, er) and fix clear speech-to-text mishearings of technical terms
+- Do NOT format as bullet points or add markdown
+
+=== SPOKEN SYMBOLS ===
+Convert spoken symbol names to the symbol:
+- "open brace" / "close brace" → { }
+- "open bracket" / "close bracket" → [ ]
+- "open paren" / "close paren" → ( )
+- "equals" → =, "double equals" → ==, "not equals" → !=
+- "dot" → . (no surrounding spaces, e.g. "foo dot bar" → foo.bar)
+- "underscore" → _, "dash" → -, "plus" → +, "star" → *, "slash" → /
+- "arrow" → ->, "fat arrow" → =>, "colon" → :, "semicolon" → ;
+- "camel case [words]" → camelCaseWords, "snake case [words]" → snake_case_words
+
+` + outputFormatInstructions
+
 // buildSystemPrompt creates the appropriate prompt based on mode
 func buildSystemPrompt(mode string) string {
 	baseInstructions := `You are an expert voice-to-text refinement assistant. Transform raw speech transcriptions into clean, polished text.
@@ -261,22 +398,12 @@ Numbered indicators (convert to bullets):
 - URLs: Format properly (www dot example dot com → www.example.com)
 - Abbreviations: Preserve common ones (etc, vs, Mr, Mrs, Dr)
 
-=== OUTPUT FORMAT (CRITICAL) ===
-You MUST respond with valid JSON in this exact format:
-{"text": "your refined text here", "refused": false}
-
-If the content contains something you cannot process due to ethical guidelines:
-{"text": "", "refused": true}
-
-Rules:
-1. ALWAYS output valid JSON, nothing else
-2. The "text" field contains the refined transcription
-3. Set "refused" to true ONLY if you cannot process the content
-4. NO markdown, NO code blocks, NO explanations
-5. Preserve the speaker's intent and meaning
-6. When in doubt, keep the original phrasing`
+` + outputFormatInstructions
 
 	switch mode {
+	case "code":
+		return codeModePrompt
+
 	case "formal":
 		return baseInstructions + `
 
@@ -307,19 +434,11 @@ func (c *Client) RetryWithInstruction(text string, instruction string) (string,
 		return "", fmt.Errorf("API key not set")
 	}
 
-	prompt := fmt.Sprintf(`Apply the following instruction to the text:
-Instruction: %s
-
-Text:
-%s
-
-Return ONLY the modified text, nothing else.`, instruction, text)
-
 	req := Request{
 		Contents: []Content{
 			{
 				Parts: []Part{
-					{Text: prompt},
+					{Text: InstructionPrompt(text, instruction)},
 				},
 			},
 		},
@@ -329,46 +448,113 @@ Return ONLY the modified text, nothing else.`, instruction, text)
 		},
 	}
 
+	text, _, err := c.generate(context.Background(), req)
+	return text, err
+}
+
+// generate sends req to the configured model and returns the first candidate's
+// text with the tokens billed across all attempts. Rate limits and server
+// errors are retried with backoff; once retries run out the error wraps
+// ErrUnavailable. Cancelling ctx stops at once.
+func (c *Client) generate(ctx context.Context, req Request) (string, Usage, error) {
+	var usage Usage
 	reqBody, err := json.Marshal(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %w", err)
+		return "", usage, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	url := fmt.Sprintf("%s?key=%s", baseURL, c.apiKey)
+	deadline := time.Now().Add(c.maxRetryTime)
+	for attempt := 1; ; attempt++ {
+		text, billed, retryAfter, err := c.send(ctx, reqBody)
+		usage.PromptTokens += billed.PromptTokens
+		usage.OutputTokens += billed.OutputTokens
+		usage.TotalTokens += billed.TotalTokens
+		if err == nil {
+			return text, usage, nil
+		}
+		var retryable *retryableError
+		if !errors.As(err, &retryable) {
+			return "", usage, err
+		}
 
-	httpReq, err := http.NewRequest("POST", url, bytes.NewReader(reqBody))
+		delay := backoffDelay(attempt, retryAfter)
+		if attempt >= c.maxAttempts || time.Now().Add(delay).After(deadline) {
+			return "", usage, fmt.Errorf("%w after %d attempts: %v", ErrUnavailable, attempt, err)
+		}
+		fmt.Printf("[Gemini] %v, retrying in %v (attempt %d/%d)\n", err, delay.Round(time.Millisecond), attempt+1, c.maxAttempts)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return "", usage, ctx.Err()
+		}
+	}
+}
+
+// send makes a single generateContent request, returning the reply text and
+// the tokens billed for it. For retryable failures it also returns the
+// server's Retry-After delay (0 if none).
+func (c *Client) send(ctx context.Context, reqBody []byte) (string, Usage, time.Duration, error) {
+	var usage Usage
+	if c.proxyErr != nil {
+		return "", usage, 0, c.proxyErr
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.endpoint(), bytes.NewReader(reqBody))
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return "", usage, 0, fmt.Errorf("failed to create request: %w", err)
 	}
 	httpReq.Header.Set("Content-Type", "application/json")
 
 	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
-		return "", fmt.Errorf("failed to send request: %w", err)
+		return "", usage, 0, c.transportError("failed to send request", err)
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response: %w", err)
+		return "", usage, 0, c.transportError("failed to read response", err)
 	}
 
 	var geminiResp Response
-	if err := json.Unmarshal(respBody, &geminiResp); err != nil {
-		return "", fmt.Errorf("failed to parse response: %w", err)
+	parseErr := json.Unmarshal(respBody, &geminiResp)
+
+	if isRetryableStatus(resp.StatusCode) {
+		message := http.StatusText(resp.StatusCode)
+		if parseErr == nil && geminiResp.Error != nil {
+			message = geminiResp.Error.Message
+		}
+		return "", usage, parseRetryAfter(resp.Header.Get("Retry-After")), &retryableError{resp.StatusCode, message}
+	}
+
+	if parseErr != nil {
+		return "", usage, 0, fmt.Errorf("failed to parse response: %w", parseErr)
 	}
 
+	// Check for API error
 	if geminiResp.Error != nil {
-		return "", fmt.Errorf("API error: %s", geminiResp.Error.Message)
+		return "", usage, 0, fmt.Errorf("API error: %s (code: %d)", geminiResp.Error.Message, geminiResp.Error.Code)
+	}
+
+	// Tokens are billed even if no usable candidate comes back
+	if m := geminiResp.UsageMetadata; m != nil {
+		usage = Usage{
+			PromptTokens: m.PromptTokenCount,
+			OutputTokens: m.CandidatesTokenCount,
+			TotalTokens:  m.TotalTokenCount,
+		}
+		c.AddUsage(usage)
 	}
 
 	if len(geminiResp.Candidates) == 0 || geminiResp.Candidates[0].Content == nil {
-		return "", fmt.Errorf("no response generated")
+		return "", usage, 0, fmt.Errorf("no response generated")
 	}
 
 	if len(geminiResp.Candidates[0].Content.Parts) == 0 {
-		return "", fmt.Errorf("empty response")
+		return "", usage, 0, fmt.Errorf("empty response")
 	}
 
-	return geminiResp.Candidates[0].Content.Parts[0].Text, nil
+	return geminiResp.Candidates[0].Content.Parts[0].Text, usage, 0, nil
 }
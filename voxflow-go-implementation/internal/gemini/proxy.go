@@ -0,0 +1,44 @@
+package gemini
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// ParseProxyURL validates an outbound proxy URL such as "http://proxy.corp:8080"
+func ParseProxyURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL %q: %w", raw, err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("invalid proxy URL %q: scheme must be http, https or socks5", raw)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %q: missing host", raw)
+	}
+	return u, nil
+}
+
+// SetProxy routes requests through proxyURL ("" = use HTTPS_PROXY/HTTP_PROXY
+// from the environment). If the URL is invalid, the error is returned and
+// requests fail with it rather than bypassing the proxy.
+func (c *Client) SetProxy(proxyURL string) error {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	c.proxyErr = nil
+	if proxyURL == "" {
+		transport.Proxy = http.ProxyFromEnvironment
+	} else {
+		u, err := ParseProxyURL(proxyURL)
+		if err != nil {
+			c.proxyErr = err
+			return err
+		}
+		transport.Proxy = http.ProxyURL(u)
+	}
+	c.httpClient.Transport = transport
+	return nil
+}
@@ -0,0 +1,107 @@
+package gemini
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultMaxAttempts is how many times a request is tried by default
+	DefaultMaxAttempts = 3
+
+	// DefaultMaxRetryTime caps the total time spent on one request, including retries
+	DefaultMaxRetryTime = 20 * time.Second
+
+	// retryBaseDelay is the backoff before the first retry; it doubles each time
+	retryBaseDelay = 500 * time.Millisecond
+
+	// maxRetryDelay caps a single backoff, including server-requested ones
+	maxRetryDelay = 10 * time.Second
+)
+
+// ErrUnavailable means the refinement service kept rate limiting, failing or
+// couldn't be reached. Other backends wrap it too, so callers can fall back
+// to the raw text.
+var ErrUnavailable = errors.New("refinement service unavailable")
+
+// ErrTimeout means a request took longer than the configured timeout
+var ErrTimeout = errors.New("refinement request timed out")
+
+// ErrNetwork means a request failed before the service replied, e.g. because
+// the network is down or a proxy refused the connection
+var ErrNetwork = errors.New("couldn't reach the refinement service")
+
+// transportError classifies a failure to send a request or read its reply.
+// Timeouts and network errors also wrap ErrUnavailable, so callers fall back
+// to the raw text as they do for the OpenAI-compatible backend.
+func (c *Client) transportError(action string, err error) error {
+	if errors.Is(err, context.Canceled) {
+		return fmt.Errorf("%s: %w", action, context.Canceled)
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("%w after %v (%w)", ErrTimeout, c.timeout, ErrUnavailable)
+	}
+	return fmt.Errorf("%w: %s: %v (%w)", ErrNetwork, action, err, ErrUnavailable)
+}
+
+// retryableError is a rate limit or server error worth retrying
+type retryableError struct {
+	status  int
+	message string
+}
+
+func (e *retryableError) Error() string {
+	return fmt.Sprintf("API error: %s (code: %d)", e.message, e.status)
+}
+
+// isRetryableStatus reports whether an HTTP status is a transient failure
+func isRetryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusServiceUnavailable:
+		return true
+	}
+	return false
+}
+
+// SetRetry sets how many attempts a request gets and the total time it may
+// take including backoff. Values below 1 fall back to the defaults.
+func (c *Client) SetRetry(maxAttempts int, maxTotal time.Duration) {
+	if maxAttempts < 1 {
+		maxAttempts = DefaultMaxAttempts
+	}
+	if maxTotal <= 0 {
+		maxTotal = DefaultMaxRetryTime
+	}
+	c.maxAttempts = maxAttempts
+	c.maxRetryTime = maxTotal
+}
+
+// backoffDelay returns how long to wait before retrying after the given
+// attempt: the server's Retry-After if set, otherwise exponential backoff
+// with jitter so concurrent requests don't retry in lockstep
+func backoffDelay(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return min(retryAfter, maxRetryDelay)
+	}
+	delay := min(retryBaseDelay<<(attempt-1), maxRetryDelay)
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return max(time.Until(t), 0)
+	}
+	return 0
+}
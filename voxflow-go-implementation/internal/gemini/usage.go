@@ -0,0 +1,59 @@
+package gemini
+
+import "sync"
+
+// Usage is a count of tokens billed for refinement requests
+type Usage struct {
+	PromptTokens int `json:"prompt_tokens"`
+	OutputTokens int `json:"output_tokens"`
+	TotalTokens  int `json:"total_tokens"`
+}
+
+// UsageMetadata is the token accounting in a Gemini response
+type UsageMetadata struct {
+	PromptTokenCount     int `json:"promptTokenCount"`
+	CandidatesTokenCount int `json:"candidatesTokenCount"`
+	TotalTokenCount      int `json:"totalTokenCount"`
+}
+
+// UsageCounter accumulates token usage across requests. Embedded by the
+// refinement clients.
+type UsageCounter struct {
+	mu    sync.Mutex
+	total Usage
+}
+
+// AddUsage records the usage of one request
+func (u *UsageCounter) AddUsage(usage Usage) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.total.PromptTokens += usage.PromptTokens
+	u.total.OutputTokens += usage.OutputTokens
+	u.total.TotalTokens += usage.TotalTokens
+}
+
+// GetUsage returns the tokens used since the client was created
+func (u *UsageCounter) GetUsage() Usage {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.total
+}
+
+// modelPricing is the list price in USD per million prompt and output tokens
+var modelPricing = map[string]struct{ prompt, output float64 }{
+	"gemini-2.0-flash":      {0.10, 0.40},
+	"gemini-2.0-flash-lite": {0.075, 0.30},
+	"gemini-2.5-flash":      {0.30, 2.50},
+	"gemini-2.5-flash-lite": {0.10, 0.40},
+	"gemini-2.5-pro":        {1.25, 10.00},
+}
+
+// EstimateCost returns the approximate cost in USD of usage on model, and
+// false if the model's price is unknown
+func EstimateCost(model string, usage Usage) (float64, bool) {
+	price, ok := modelPricing[model]
+	if !ok {
+		return 0, false
+	}
+	return (float64(usage.PromptTokens)*price.prompt + float64(usage.OutputTokens)*price.output) / 1e6, true
+}
@@ -0,0 +1,279 @@
+package history
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// KeySize is the length in bytes of the history encryption key (AES-256)
+const KeySize = 32
+
+// encryptedPrefix marks a text column value sealed with the history key
+const encryptedPrefix = "enc:v1:"
+
+// ErrEncrypted is returned when reading encrypted text without the key
+var ErrEncrypted = errors.New("transcript is encrypted and no key is loaded")
+
+// ErrKeyRequired is returned when saving while encryption is on but its key
+// couldn't be loaded, rather than storing the text unencrypted
+var ErrKeyRequired = errors.New("history encryption key is not available, transcript not saved")
+
+// UnreadablePlaceholder stands in for the text of transcripts that can't be decrypted
+const UnreadablePlaceholder = "[Encrypted transcript — the key for it isn't available]"
+
+// GenerateKey returns a new random history encryption key
+func GenerateKey() ([]byte, error) {
+	key := make([]byte, KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+	return key, nil
+}
+
+// newAEAD returns the AES-GCM cipher for key
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("history key must be %d bytes, got %d", KeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts text if encryption is enabled. The caller must hold s.mu.
+func (s *Service) seal(text string) (string, error) {
+	if s.aead == nil && s.keyRequired && text != "" {
+		return "", ErrKeyRequired
+	}
+	if s.aead == nil || text == "" || strings.HasPrefix(text, encryptedPrefix) {
+		return text, nil
+	}
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to encrypt transcript: %w", err)
+	}
+	sealed := s.aead.Seal(nonce, nonce, []byte(text), nil)
+	return encryptedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// open decrypts text if it was sealed, and returns plain text as is. The
+// caller must hold s.mu.
+func (s *Service) open(text string) (string, error) {
+	encoded, ok := strings.CutPrefix(text, encryptedPrefix)
+	if !ok {
+		return text, nil
+	}
+	if s.aead == nil {
+		return "", ErrEncrypted
+	}
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(data) < s.aead.NonceSize() {
+		return "", fmt.Errorf("corrupt encrypted transcript")
+	}
+	nonce, ciphertext := data[:s.aead.NonceSize()], data[s.aead.NonceSize():]
+	plain, err := s.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt transcript (wrong key?): %w", err)
+	}
+	return string(plain), nil
+}
+
+// sealAll seals each text, stopping at the first error. The caller must hold s.mu.
+func (s *Service) sealAll(texts ...string) ([]any, error) {
+	sealed := make([]any, len(texts))
+	for i, text := range texts {
+		v, err := s.seal(text)
+		if err != nil {
+			return nil, err
+		}
+		sealed[i] = v
+	}
+	return sealed, nil
+}
+
+// Encrypted reports whether new transcripts are encrypted
+func (s *Service) Encrypted() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.aead != nil
+}
+
+// RequireKey marks encryption as on while its key is unavailable: saving text
+// fails with ErrKeyRequired until EnableEncryption or DisableEncryption
+func (s *Service) RequireKey() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.aead == nil {
+		s.keyRequired = true
+	}
+}
+
+// KeyRequired reports whether saving is blocked waiting for the encryption key
+func (s *Service) KeyRequired() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.keyRequired
+}
+
+// HasEncryptedRows reports whether any transcript text is encrypted
+func (s *Service) HasEncryptedRows() (bool, error) {
+	var found bool
+	pattern := encryptedPrefix + "%"
+	err := s.db.QueryRow(
+		"SELECT EXISTS(SELECT 1 FROM transcripts WHERE raw_text LIKE ? OR polished_text LIKE ? OR segments_json LIKE ?)",
+		pattern, pattern, pattern,
+	).Scan(&found)
+	if err != nil {
+		return false, fmt.Errorf("failed to check for encrypted transcripts: %w", err)
+	}
+	return found, nil
+}
+
+// EnableEncryption encrypts the text of all transcripts with key, including
+// those saved before, and of every transcript saved from now on
+func (s *Service) EnableEncryption(key []byte) error {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	previous := s.aead
+	s.aead = aead
+	if err := s.rewriteText(s.seal); err != nil {
+		s.aead = previous
+		return err
+	}
+	s.keyRequired = false
+	return s.compact()
+}
+
+// DisableEncryption decrypts all transcripts with key and stores new ones in
+// plain text. key may be nil if no transcript was ever encrypted.
+func (s *Service) DisableEncryption(key []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	previous := s.aead
+	s.aead = nil
+	if key != nil {
+		aead, err := newAEAD(key)
+		if err != nil {
+			s.aead = previous
+			return err
+		}
+		s.aead = aead
+	}
+	if err := s.rewriteText(s.open); err != nil {
+		s.aead = previous
+		return err
+	}
+	s.aead = nil
+	s.keyRequired = false
+	return s.compact()
+}
+
+// rewriteText passes the text columns of every row through fn in a single
+// transaction. The caller must hold s.mu.
+func (s *Service) rewriteText(fn func(string) (string, error)) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query("SELECT id, raw_text, polished_text, segments_json FROM transcripts")
+	if err != nil {
+		return err
+	}
+	type textRow struct {
+		id                 int64
+		raw, polished, seg string
+	}
+	var all []textRow
+	for rows.Next() {
+		var r textRow
+		var polished, seg sql.NullString
+		if err := rows.Scan(&r.id, &r.raw, &polished, &seg); err != nil {
+			rows.Close()
+			return err
+		}
+		r.polished, r.seg = polished.String, seg.String
+		all = append(all, r)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, r := range all {
+		raw, err := fn(r.raw)
+		if err != nil {
+			return fmt.Errorf("transcript %d: %w", r.id, err)
+		}
+		polished, err := fn(r.polished)
+		if err != nil {
+			return fmt.Errorf("transcript %d: %w", r.id, err)
+		}
+		seg, err := fn(r.seg)
+		if err != nil {
+			return fmt.Errorf("transcript %d: %w", r.id, err)
+		}
+		if _, err := tx.Exec(
+			"UPDATE transcripts SET raw_text = ?, polished_text = ?, segments_json = NULLIF(?, '') WHERE id = ?",
+			raw, polished, seg, r.id,
+		); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// compact rewrites the database file so replaced text doesn't linger in free pages
+func (s *Service) compact() error {
+	if _, err := s.db.Exec("VACUUM"); err != nil {
+		return fmt.Errorf("failed to compact database: %w", err)
+	}
+	return nil
+}
+
+// searchEncrypted is Search for an encrypted database, where SQL can't see
+// the text: it decrypts every candidate and matches in Go
+func (s *Service) searchEncrypted(query string, limit int, favoritesOnly bool) ([]*Transcript, error) {
+	sqlQuery := "SELECT " + transcriptColumns + " FROM transcripts WHERE deleted_at IS NULL"
+	if favoritesOnly {
+		sqlQuery += " AND favorite = 1"
+	}
+	sqlQuery += " ORDER BY timestamp DESC"
+
+	rows, err := s.db.Query(sqlQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	needle := strings.ToLower(query)
+	var transcripts []*Transcript
+	for rows.Next() {
+		t, err := s.scanTranscript(rows)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.Contains(strings.ToLower(t.RawText), needle) && !strings.Contains(strings.ToLower(t.PolishedText), needle) {
+			continue
+		}
+		transcripts = append(transcripts, t)
+		if limit > 0 && len(transcripts) == limit {
+			break
+		}
+	}
+	return transcripts, rows.Err()
+}
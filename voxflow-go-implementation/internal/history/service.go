@@ -1,10 +1,14 @@
 package history
 
 import (
+	"crypto/cipher"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 	"time"
 
 	_ "modernc.org/sqlite"
@@ -12,17 +16,107 @@ import (
 
 // Transcript represents a saved transcription
 type Transcript struct {
-	ID           int64     `json:"id"`
-	Timestamp    time.Time `json:"timestamp"`
-	AppName      string    `json:"app_name"`
-	RawText      string    `json:"raw_text"`
-	PolishedText string    `json:"polished_text"`
-	Mode         string    `json:"mode"`
+	ID           int64      `json:"id"`
+	Timestamp    time.Time  `json:"timestamp"`
+	AppName      string     `json:"app_name"`
+	RawText      string     `json:"raw_text"`
+	PolishedText string     `json:"polished_text"`
+	Mode         string     `json:"mode"`
+	Tone         string     `json:"tone"`                 // Optional tone tag: neutral, positive, negative, excited
+	Language     string     `json:"language"`             // Transcription language, if known
+	Translated   bool       `json:"translated"`           // Whisper translated the speech to English
+	DeletedAt    *time.Time `json:"deleted_at,omitempty"` // Set while the transcript is in the trash
+	PromptTokens int        `json:"prompt_tokens"`        // Refinement tokens billed for this transcript
+	OutputTokens int        `json:"output_tokens"`
+	Favorite     bool       `json:"favorite"` // Pinned by the user
+	Tags         []string   `json:"tags"`
+	AudioMs      int64      `json:"audio_ms"`             // Length of the recording
+	WhisperMs    int64      `json:"whisper_ms"`           // Time spent transcribing
+	GeminiMs     int64      `json:"gemini_ms"`            // Time spent refining
+	Unreadable   bool       `json:"unreadable,omitempty"` // Encrypted with a key that isn't loaded; the text is UnreadablePlaceholder
+}
+
+// Segment is a timestamped span of a transcript, as reported by whisper
+type Segment struct {
+	StartMs int64  `json:"start_ms"`
+	EndMs   int64  `json:"end_ms"`
+	Text    string `json:"text"`
+}
+
+// transcriptColumns is the column list scanned by scanTranscript
+const transcriptColumns = "id, timestamp, app_name, raw_text, polished_text, mode, tone, language, translated, deleted_at, prompt_tokens, output_tokens, favorite, tags, audio_ms, whisper_ms, gemini_ms"
+
+// sqliteTimeFormat is the layout of CURRENT_TIMESTAMP values (UTC)
+const sqliteTimeFormat = "2006-01-02 15:04:05"
+
+// parseSQLiteTime parses a DATETIME value, which the driver may return
+// either as stored or converted to RFC 3339
+func parseSQLiteTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse(sqliteTimeFormat, value)
+}
+
+// sqlLimit returns the bound value for a LIMIT placeholder: limit itself, or
+// -1 (SQLite for "no limit") when limit is zero or negative
+func sqlLimit(limit int) int {
+	if limit <= 0 {
+		return -1
+	}
+	return limit
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanTranscript reads a row selected with transcriptColumns, decrypting its text
+func (s *Service) scanTranscript(row rowScanner) (*Transcript, error) {
+	t := &Transcript{}
+	var appName, polishedText, mode, tone, language, deletedAt, tags sql.NullString
+	var timestamp string
+	var translated sql.NullBool
+
+	if err := row.Scan(&t.ID, &timestamp, &appName, &t.RawText, &polishedText, &mode, &tone, &language, &translated, &deletedAt, &t.PromptTokens, &t.OutputTokens, &t.Favorite, &tags, &t.AudioMs, &t.WhisperMs, &t.GeminiMs); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	raw, err := s.open(t.RawText)
+	if err == nil {
+		t.PolishedText, err = s.open(polishedText.String)
+	}
+	s.mu.RUnlock()
+	if err != nil {
+		// One unreadable row shouldn't hide the rest of the history
+		raw, t.PolishedText = UnreadablePlaceholder, UnreadablePlaceholder
+		t.Unreadable = true
+	}
+	t.RawText = raw
+
+	t.Timestamp, _ = parseSQLiteTime(timestamp)
+	t.AppName = appName.String
+	t.Mode = mode.String
+	t.Tone = tone.String
+	t.Language = language.String
+	t.Translated = translated.Bool
+	t.Tags = decodeTags(tags.String)
+	if deletedAt.Valid {
+		if at, err := parseSQLiteTime(deletedAt.String); err == nil {
+			t.DeletedAt = &at
+		}
+	}
+	return t, nil
 }
 
 // Service handles transcript storage and retrieval
 type Service struct {
-	db *sql.DB
+	db          *sql.DB
+	mu          sync.RWMutex // Guards aead and keyRequired; held for writing while re-encrypting rows
+	aead        cipher.AEAD  // Encrypts transcript text when set
+	keyRequired bool         // Encryption is on but the key isn't loaded, so text can't be saved
 }
 
 // NewService creates a new history service
@@ -70,17 +164,82 @@ func (s *Service) initDB() error {
 		mode TEXT
 	);
 	CREATE INDEX IF NOT EXISTS idx_timestamp ON transcripts(timestamp DESC);
+	CREATE INDEX IF NOT EXISTS idx_app_name ON transcripts(app_name);
 	`
-	_, err := s.db.Exec(query)
-	return err
+	if _, err := s.db.Exec(query); err != nil {
+		return err
+	}
+	return s.migrate()
+}
+
+// migrate adds columns introduced after the initial schema
+func (s *Service) migrate() error {
+	columns := []struct{ name, def string }{
+		{"tone", "TEXT"},
+		{"language", "TEXT"},
+		{"deleted_at", "DATETIME"},
+		{"translated", "INTEGER NOT NULL DEFAULT 0"},
+		{"segments_json", "TEXT"},
+		{"prompt_tokens", "INTEGER NOT NULL DEFAULT 0"},
+		{"output_tokens", "INTEGER NOT NULL DEFAULT 0"},
+		{"favorite", "INTEGER NOT NULL DEFAULT 0"},
+		{"tags", "TEXT"},
+		{"audio_ms", "INTEGER NOT NULL DEFAULT 0"},
+		{"whisper_ms", "INTEGER NOT NULL DEFAULT 0"},
+		{"gemini_ms", "INTEGER NOT NULL DEFAULT 0"},
+		{"refine_backend", "TEXT"},
+		{"refine_model", "TEXT"},
+	}
+	for _, col := range columns {
+		exists, err := s.hasColumn(col.name)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+		if _, err := s.db.Exec(fmt.Sprintf("ALTER TABLE transcripts ADD COLUMN %s %s", col.name, col.def)); err != nil {
+			return fmt.Errorf("failed to add column %s: %w", col.name, err)
+		}
+	}
+	return nil
+}
+
+// hasColumn reports whether the transcripts table has the named column
+func (s *Service) hasColumn(name string) (bool, error) {
+	rows, err := s.db.Query("PRAGMA table_info(transcripts)")
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var cid, notNull, pk int
+		var colName, colType string
+		var dflt sql.NullString
+		if err := rows.Scan(&cid, &colName, &colType, &notNull, &dflt, &pk); err != nil {
+			return false, err
+		}
+		if colName == name {
+			return true, nil
+		}
+	}
+	return false, rows.Err()
 }
 
 // Save saves a new transcript
 func (s *Service) Save(appName, rawText, polishedText, mode string) (*Transcript, error) {
+	s.mu.RLock()
+	text, err := s.sealAll(rawText, polishedText)
+	if err != nil {
+		s.mu.RUnlock()
+		return nil, err
+	}
 	result, err := s.db.Exec(
 		"INSERT INTO transcripts (app_name, raw_text, polished_text, mode) VALUES (?, ?, ?, ?)",
-		appName, rawText, polishedText, mode,
+		appName, text[0], text[1], mode,
 	)
+	s.mu.RUnlock()
 	if err != nil {
 		return nil, fmt.Errorf("failed to save transcript: %w", err)
 	}
@@ -96,15 +255,11 @@ func (s *Service) Save(appName, rawText, polishedText, mode string) (*Transcript
 // GetByID retrieves a transcript by ID
 func (s *Service) GetByID(id int64) (*Transcript, error) {
 	row := s.db.QueryRow(
-		"SELECT id, timestamp, app_name, raw_text, polished_text, mode FROM transcripts WHERE id = ?",
+		"SELECT "+transcriptColumns+" FROM transcripts WHERE id = ?",
 		id,
 	)
 
-	t := &Transcript{}
-	var appName, polishedText, mode sql.NullString
-	var timestamp string
-
-	err := row.Scan(&t.ID, &timestamp, &appName, &t.RawText, &polishedText, &mode)
+	t, err := s.scanTranscript(row)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("transcript not found")
@@ -112,22 +267,18 @@ func (s *Service) GetByID(id int64) (*Transcript, error) {
 		return nil, err
 	}
 
-	t.Timestamp, _ = time.Parse("2006-01-02 15:04:05", timestamp)
-	t.AppName = appName.String
-	t.PolishedText = polishedText.String
-	t.Mode = mode.String
-
 	return t, nil
 }
 
-// GetAll retrieves all transcripts ordered by timestamp desc
-func (s *Service) GetAll(limit int) ([]*Transcript, error) {
-	query := "SELECT id, timestamp, app_name, raw_text, polished_text, mode FROM transcripts ORDER BY timestamp DESC"
-	if limit > 0 {
-		query += fmt.Sprintf(" LIMIT %d", limit)
+// GetAll retrieves all transcripts ordered by timestamp desc, optionally only favorites
+func (s *Service) GetAll(limit int, favoritesOnly bool) ([]*Transcript, error) {
+	query := "SELECT " + transcriptColumns + " FROM transcripts WHERE deleted_at IS NULL"
+	if favoritesOnly {
+		query += " AND favorite = 1"
 	}
+	query += " ORDER BY timestamp DESC LIMIT ?"
 
-	rows, err := s.db.Query(query)
+	rows, err := s.db.Query(query, sqlLimit(limit))
 	if err != nil {
 		return nil, err
 	}
@@ -135,40 +286,71 @@ func (s *Service) GetAll(limit int) ([]*Transcript, error) {
 
 	var transcripts []*Transcript
 	for rows.Next() {
-		t := &Transcript{}
-		var appName, polishedText, mode sql.NullString
-		var timestamp string
-
-		err := rows.Scan(&t.ID, &timestamp, &appName, &t.RawText, &polishedText, &mode)
+		t, err := s.scanTranscript(rows)
 		if err != nil {
 			return nil, err
 		}
-
-		t.Timestamp, _ = time.Parse("2006-01-02 15:04:05", timestamp)
-		t.AppName = appName.String
-		t.PolishedText = polishedText.String
-		t.Mode = mode.String
-
 		transcripts = append(transcripts, t)
 	}
 
 	return transcripts, nil
 }
 
-// Search searches transcripts by text content
-func (s *Service) Search(query string, limit int) ([]*Transcript, error) {
+// Page is one page of history plus the total number of transcripts
+type Page struct {
+	Transcripts []*Transcript `json:"transcripts"`
+	Total       int           `json:"total"`
+}
+
+// GetPage retrieves limit transcripts starting at offset, ordered by
+// timestamp desc, along with the total count for paging
+func (s *Service) GetPage(offset, limit int) (*Page, error) {
+	if offset < 0 {
+		offset = 0
+	}
+
+	total, err := s.GetCount()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(
+		"SELECT "+transcriptColumns+" FROM transcripts WHERE deleted_at IS NULL ORDER BY timestamp DESC, id DESC LIMIT ? OFFSET ?",
+		sqlLimit(limit), offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	page := &Page{Transcripts: []*Transcript{}, Total: total}
+	for rows.Next() {
+		t, err := s.scanTranscript(rows)
+		if err != nil {
+			return nil, err
+		}
+		page.Transcripts = append(page.Transcripts, t)
+	}
+	return page, rows.Err()
+}
+
+// Search searches transcripts by text content, optionally only favorites
+func (s *Service) Search(query string, limit int, favoritesOnly bool) ([]*Transcript, error) {
+	if s.Encrypted() {
+		return s.searchEncrypted(query, limit, favoritesOnly)
+	}
 	searchQuery := "%" + query + "%"
 	sqlQuery := `
-		SELECT id, timestamp, app_name, raw_text, polished_text, mode 
+		SELECT ` + transcriptColumns + `
 		FROM transcripts 
-		WHERE raw_text LIKE ? OR polished_text LIKE ?
-		ORDER BY timestamp DESC
+		WHERE deleted_at IS NULL AND (raw_text LIKE ? OR polished_text LIKE ?)
 	`
-	if limit > 0 {
-		sqlQuery += fmt.Sprintf(" LIMIT %d", limit)
+	if favoritesOnly {
+		sqlQuery += " AND favorite = 1"
 	}
+	sqlQuery += " ORDER BY timestamp DESC LIMIT ?"
 
-	rows, err := s.db.Query(sqlQuery, searchQuery, searchQuery)
+	rows, err := s.db.Query(sqlQuery, searchQuery, searchQuery, sqlLimit(limit))
 	if err != nil {
 		return nil, err
 	}
@@ -176,47 +358,379 @@ func (s *Service) Search(query string, limit int) ([]*Transcript, error) {
 
 	var transcripts []*Transcript
 	for rows.Next() {
-		t := &Transcript{}
-		var appName, polishedText, mode sql.NullString
-		var timestamp string
-
-		err := rows.Scan(&t.ID, &timestamp, &appName, &t.RawText, &polishedText, &mode)
+		t, err := s.scanTranscript(rows)
 		if err != nil {
 			return nil, err
 		}
-
-		t.Timestamp, _ = time.Parse("2006-01-02 15:04:05", timestamp)
-		t.AppName = appName.String
-		t.PolishedText = polishedText.String
-		t.Mode = mode.String
-
 		transcripts = append(transcripts, t)
 	}
 
 	return transcripts, nil
 }
 
+// GetByApp returns transcripts dictated into the named app, newest first,
+// excluding the trash
+func (s *Service) GetByApp(appName string) ([]*Transcript, error) {
+	appName = strings.TrimSpace(appName)
+	if appName == "" {
+		return nil, fmt.Errorf("app name cannot be empty")
+	}
+	rows, err := s.db.Query(`
+		SELECT `+transcriptColumns+`
+		FROM transcripts
+		WHERE deleted_at IS NULL AND app_name = ? COLLATE NOCASE
+		ORDER BY timestamp DESC`, appName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transcripts []*Transcript
+	for rows.Next() {
+		t, err := s.scanTranscript(rows)
+		if err != nil {
+			return nil, err
+		}
+		transcripts = append(transcripts, t)
+	}
+	return transcripts, rows.Err()
+}
+
 // UpdatePolishedText updates the polished text for a transcript
 func (s *Service) UpdatePolishedText(id int64, polishedText string) error {
-	_, err := s.db.Exec(
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	sealed, err := s.seal(polishedText)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(
 		"UPDATE transcripts SET polished_text = ? WHERE id = ?",
-		polishedText, id,
+		sealed, id,
+	)
+	return err
+}
+
+// UpdateTranscription replaces a transcript's raw and polished text after
+// re-transcribing it in the given language
+func (s *Service) UpdateTranscription(id int64, rawText, polishedText, language string) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	text, err := s.sealAll(rawText, polishedText)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(
+		"UPDATE transcripts SET raw_text = ?, polished_text = ?, language = ? WHERE id = ?",
+		text[0], text[1], language, id,
+	)
+	return err
+}
+
+// UpdateMode sets the refinement mode recorded for a transcript
+func (s *Service) UpdateMode(id int64, mode string) error {
+	_, err := s.db.Exec(
+		"UPDATE transcripts SET mode = ? WHERE id = ?",
+		mode, id,
+	)
+	return err
+}
+
+// SetTone sets the tone tag for a transcript
+func (s *Service) SetTone(id int64, tone string) error {
+	_, err := s.db.Exec(
+		"UPDATE transcripts SET tone = ? WHERE id = ?",
+		tone, id,
 	)
 	return err
 }
 
-// Delete deletes a transcript by ID
+// SetTranslated marks a transcript as translated to English by whisper
+func (s *Service) SetTranslated(id int64, translated bool) error {
+	_, err := s.db.Exec(
+		"UPDATE transcripts SET translated = ? WHERE id = ?",
+		translated, id,
+	)
+	return err
+}
+
+// SetTokenUsage records the refinement tokens billed for a transcript and the
+// backend and model that billed them
+func (s *Service) SetTokenUsage(id int64, backend, model string, promptTokens, outputTokens int) error {
+	_, err := s.db.Exec(
+		"UPDATE transcripts SET prompt_tokens = ?, output_tokens = ?, refine_backend = ?, refine_model = ? WHERE id = ?",
+		promptTokens, outputTokens, backend, model, id,
+	)
+	return err
+}
+
+// SetMetrics stores how long the recording was and how long each pipeline stage took
+func (s *Service) SetMetrics(id int64, audio, whisper, gemini time.Duration) error {
+	_, err := s.db.Exec(
+		"UPDATE transcripts SET audio_ms = ?, whisper_ms = ?, gemini_ms = ? WHERE id = ?",
+		audio.Milliseconds(), whisper.Milliseconds(), gemini.Milliseconds(), id,
+	)
+	return err
+}
+
+// Stats summarizes the processing metrics of all transcripts that have them
+type Stats struct {
+	Count          int     `json:"count"`
+	TotalAudioMs   int64   `json:"total_audio_ms"`
+	TotalWhisperMs int64   `json:"total_whisper_ms"`
+	TotalGeminiMs  int64   `json:"total_gemini_ms"`
+	AvgAudioMs     float64 `json:"avg_audio_ms"`
+	AvgWhisperMs   float64 `json:"avg_whisper_ms"`
+	AvgGeminiMs    float64 `json:"avg_gemini_ms"`
+}
+
+// GetStats returns totals and averages of the processing metrics, including
+// transcripts in the trash. Transcripts saved before metrics were recorded
+// are left out.
+func (s *Service) GetStats() (*Stats, error) {
+	stats := &Stats{}
+	err := s.db.QueryRow(
+		`SELECT COUNT(*), COALESCE(SUM(audio_ms), 0), COALESCE(SUM(whisper_ms), 0), COALESCE(SUM(gemini_ms), 0)
+		FROM transcripts WHERE audio_ms > 0`,
+	).Scan(&stats.Count, &stats.TotalAudioMs, &stats.TotalWhisperMs, &stats.TotalGeminiMs)
+	if err != nil {
+		return nil, err
+	}
+	if stats.Count > 0 {
+		n := float64(stats.Count)
+		stats.AvgAudioMs = float64(stats.TotalAudioMs) / n
+		stats.AvgWhisperMs = float64(stats.TotalWhisperMs) / n
+		stats.AvgGeminiMs = float64(stats.TotalGeminiMs) / n
+	}
+	return stats, nil
+}
+
+// TokenUsage is the refinement token total of one backend and model over a period
+type TokenUsage struct {
+	Backend      string `json:"backend"` // "" for transcripts saved before it was recorded
+	Model        string `json:"model"`
+	Requests     int    `json:"requests"` // Transcripts that used any tokens
+	PromptTokens int    `json:"prompt_tokens"`
+	OutputTokens int    `json:"output_tokens"`
+}
+
+// GetTokenUsageSince sums the tokens of transcripts created since the given
+// time per backend and model, including those in the trash
+func (s *Service) GetTokenUsageSince(since time.Time) ([]TokenUsage, error) {
+	rows, err := s.db.Query(
+		`SELECT COALESCE(refine_backend, ''), COALESCE(refine_model, ''), COUNT(*),
+			COALESCE(SUM(prompt_tokens), 0), COALESCE(SUM(output_tokens), 0)
+		FROM transcripts WHERE timestamp >= ? AND (prompt_tokens > 0 OR output_tokens > 0)
+		GROUP BY 1, 2 ORDER BY 1, 2`,
+		since.UTC().Format(sqliteTimeFormat),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var usage []TokenUsage
+	for rows.Next() {
+		var u TokenUsage
+		if err := rows.Scan(&u.Backend, &u.Model, &u.Requests, &u.PromptTokens, &u.OutputTokens); err != nil {
+			return nil, err
+		}
+		usage = append(usage, u)
+	}
+	return usage, rows.Err()
+}
+
+// SetSegments stores the timestamped segments of a transcript
+func (s *Service) SetSegments(id int64, segments []Segment) error {
+	data, err := json.Marshal(segments)
+	if err != nil {
+		return fmt.Errorf("failed to encode segments: %w", err)
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	sealed, err := s.seal(string(data))
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec("UPDATE transcripts SET segments_json = ? WHERE id = ?", sealed, id)
+	return err
+}
+
+// GetSegments returns the timestamped segments of a transcript, or nil if
+// none were stored
+func (s *Service) GetSegments(id int64) ([]Segment, error) {
+	var data sql.NullString
+	err := s.db.QueryRow("SELECT segments_json FROM transcripts WHERE id = ?", id).Scan(&data)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("transcript not found")
+		}
+		return nil, err
+	}
+	if !data.Valid || data.String == "" {
+		return nil, nil
+	}
+	s.mu.RLock()
+	plain, err := s.open(data.String)
+	s.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	var segments []Segment
+	if err := json.Unmarshal([]byte(plain), &segments); err != nil {
+		return nil, fmt.Errorf("failed to decode segments: %w", err)
+	}
+	return segments, nil
+}
+
+// Delete moves a transcript to the trash; it is removed for good by PurgeDeleted
 func (s *Service) Delete(id int64) error {
-	_, err := s.db.Exec("DELETE FROM transcripts WHERE id = ?", id)
+	_, err := s.db.Exec("UPDATE transcripts SET deleted_at = CURRENT_TIMESTAMP WHERE id = ? AND deleted_at IS NULL", id)
 	return err
 }
 
-// DeleteAll deletes all transcripts
+// DeleteAll moves all transcripts to the trash
 func (s *Service) DeleteAll() error {
-	_, err := s.db.Exec("DELETE FROM transcripts")
+	_, err := s.db.Exec("UPDATE transcripts SET deleted_at = CURRENT_TIMESTAMP WHERE deleted_at IS NULL")
 	return err
 }
 
+// DeleteByDateRange moves transcripts created in [start, end) to the trash,
+// keeping favorites if asked, and returns how many were moved
+func (s *Service) DeleteByDateRange(start, end time.Time, keepFavorites bool) (int64, error) {
+	if !end.After(start) {
+		return 0, fmt.Errorf("end must be after start")
+	}
+	return s.deleteWhere("timestamp >= ? AND timestamp < ?", keepFavorites,
+		start.UTC().Format(sqliteTimeFormat), end.UTC().Format(sqliteTimeFormat))
+}
+
+// DeleteByMode moves transcripts refined in mode to the trash, keeping
+// favorites if asked, and returns how many were moved
+func (s *Service) DeleteByMode(mode string, keepFavorites bool) (int64, error) {
+	return s.deleteWhere("mode = ?", keepFavorites, mode)
+}
+
+// DeleteByApp moves transcripts dictated into the named app to the trash,
+// keeping favorites if asked, and returns how many were moved
+func (s *Service) DeleteByApp(appName string, keepFavorites bool) (int64, error) {
+	appName = strings.TrimSpace(appName)
+	if appName == "" {
+		return 0, fmt.Errorf("app name cannot be empty")
+	}
+	return s.deleteWhere("app_name = ? COLLATE NOCASE", keepFavorites, appName)
+}
+
+// deleteWhere moves the transcripts matching cond to the trash
+func (s *Service) deleteWhere(cond string, keepFavorites bool, args ...any) (int64, error) {
+	query := "UPDATE transcripts SET deleted_at = CURRENT_TIMESTAMP WHERE deleted_at IS NULL AND " + cond
+	if keepFavorites {
+		query += " AND favorite = 0"
+	}
+	result, err := s.db.Exec(query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// Restore takes a transcript back out of the trash
+func (s *Service) Restore(id int64) error {
+	result, err := s.db.Exec("UPDATE transcripts SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL", id)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("transcript %d is not in the trash", id)
+	}
+	return nil
+}
+
+// GetTrash returns soft-deleted transcripts, most recently deleted first
+func (s *Service) GetTrash() ([]*Transcript, error) {
+	rows, err := s.db.Query("SELECT " + transcriptColumns + " FROM transcripts WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transcripts []*Transcript
+	for rows.Next() {
+		t, err := s.scanTranscript(rows)
+		if err != nil {
+			return nil, err
+		}
+		transcripts = append(transcripts, t)
+	}
+
+	return transcripts, nil
+}
+
+// PurgeDeleted permanently removes transcripts that have been in the trash
+// for longer than olderThan, returning the IDs removed
+func (s *Service) PurgeDeleted(olderThan time.Duration) ([]int64, error) {
+	cutoff := time.Now().UTC().Add(-olderThan).Format(sqliteTimeFormat)
+	rows, err := s.db.Query("DELETE FROM transcripts WHERE deleted_at IS NOT NULL AND deleted_at <= ? RETURNING id", cutoff)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// PruneOlderThan permanently removes transcripts created more than days ago,
+// except favorites, returning the IDs removed. days <= 0 removes nothing.
+func (s *Service) PruneOlderThan(days int) ([]int64, error) {
+	if days <= 0 {
+		return nil, nil
+	}
+	cutoff := time.Now().UTC().AddDate(0, 0, -days).Format(sqliteTimeFormat)
+	return s.deleteReturningIDs("DELETE FROM transcripts WHERE favorite = 0 AND timestamp < ? RETURNING id", cutoff)
+}
+
+// PruneToCount permanently removes the oldest transcripts beyond the newest
+// maxEntries, returning the IDs removed. Favorites are never removed and
+// don't count towards maxEntries. maxEntries <= 0 removes nothing.
+func (s *Service) PruneToCount(maxEntries int) ([]int64, error) {
+	if maxEntries <= 0 {
+		return nil, nil
+	}
+	return s.deleteReturningIDs(`
+		DELETE FROM transcripts WHERE id IN (
+			SELECT id FROM transcripts WHERE favorite = 0 AND deleted_at IS NULL
+			ORDER BY timestamp DESC, id DESC LIMIT -1 OFFSET ?
+		) RETURNING id`, maxEntries)
+}
+
+// deleteReturningIDs runs a DELETE ... RETURNING id statement
+func (s *Service) deleteReturningIDs(query string, args ...any) ([]int64, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
 // Close closes the database connection
 func (s *Service) Close() error {
 	if s.db != nil {
@@ -225,9 +739,9 @@ func (s *Service) Close() error {
 	return nil
 }
 
-// GetCount returns the total number of transcripts
+// GetCount returns the total number of transcripts, excluding the trash
 func (s *Service) GetCount() (int, error) {
 	var count int
-	err := s.db.QueryRow("SELECT COUNT(*) FROM transcripts").Scan(&count)
+	err := s.db.QueryRow("SELECT COUNT(*) FROM transcripts WHERE deleted_at IS NULL").Scan(&count)
 	return count, err
 }
@@ -0,0 +1,157 @@
+package history
+
+import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+// newTestService returns a service backed by a fresh database holding count
+// transcripts ("note 1" to "note N")
+func newTestService(t *testing.T, count int) *Service {
+	t.Helper()
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "history.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &Service{db: db}
+	t.Cleanup(func() { s.Close() })
+	if err := s.initDB(); err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i <= count; i++ {
+		if _, err := s.Save("Notes", fmt.Sprintf("note %d", i), fmt.Sprintf("Note %d.", i), "casual"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return s
+}
+
+func TestSQLLimit(t *testing.T) {
+	tests := []struct {
+		limit, want int
+	}{
+		{-10, -1},
+		{-1, -1},
+		{0, -1},
+		{1, 1},
+		{50, 50},
+	}
+	for _, tt := range tests {
+		if got := sqlLimit(tt.limit); got != tt.want {
+			t.Errorf("sqlLimit(%d) = %d, want %d", tt.limit, got, tt.want)
+		}
+	}
+}
+
+func TestQueryLimits(t *testing.T) {
+	s := newTestService(t, 5)
+
+	tests := []struct {
+		name  string
+		limit int
+		want  int
+	}{
+		{"negative means no limit", -3, 5},
+		{"zero means no limit", 0, 5},
+		{"limit", 2, 2},
+		{"limit above count", 10, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			all, err := s.GetAll(tt.limit, false)
+			if err != nil {
+				t.Fatalf("GetAll(%d): %v", tt.limit, err)
+			}
+			if len(all) != tt.want {
+				t.Errorf("GetAll(%d) returned %d transcripts, want %d", tt.limit, len(all), tt.want)
+			}
+
+			found, err := s.Search("note", tt.limit, false)
+			if err != nil {
+				t.Fatalf("Search(%d): %v", tt.limit, err)
+			}
+			if len(found) != tt.want {
+				t.Errorf("Search(%d) returned %d transcripts, want %d", tt.limit, len(found), tt.want)
+			}
+
+			page, err := s.GetPage(0, tt.limit)
+			if err != nil {
+				t.Fatalf("GetPage(0, %d): %v", tt.limit, err)
+			}
+			if len(page.Transcripts) != tt.want || page.Total != 5 {
+				t.Errorf("GetPage(0, %d) = %d items of %d, want %d of 5", tt.limit, len(page.Transcripts), page.Total, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetPageOffset(t *testing.T) {
+	s := newTestService(t, 5)
+
+	tests := []struct {
+		offset, limit int
+		want          int
+	}{
+		{-1, 2, 2},
+		{0, 2, 2},
+		{4, 2, 1},
+		{5, 2, 0},
+		{3, 0, 2},
+	}
+	for _, tt := range tests {
+		page, err := s.GetPage(tt.offset, tt.limit)
+		if err != nil {
+			t.Fatalf("GetPage(%d, %d): %v", tt.offset, tt.limit, err)
+		}
+		if len(page.Transcripts) != tt.want {
+			t.Errorf("GetPage(%d, %d) returned %d items, want %d", tt.offset, tt.limit, len(page.Transcripts), tt.want)
+		}
+	}
+}
+
+func TestGetTokenUsageSince(t *testing.T) {
+	s := newTestService(t, 5)
+
+	// Transcripts 1-4 used tokens; 5 didn't. 4 predates recording the backend.
+	for _, u := range []struct {
+		id             int64
+		backend, model string
+		prompt, output int
+	}{
+		{1, "gemini", "gemini-2.0-flash", 100, 10},
+		{2, "gemini", "gemini-2.0-flash", 200, 20},
+		{3, "local", "llama3", 300, 30},
+	} {
+		if err := s.SetTokenUsage(u.id, u.backend, u.model, u.prompt, u.output); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := s.db.Exec("UPDATE transcripts SET prompt_tokens = 400, output_tokens = 40 WHERE id = 4"); err != nil {
+		t.Fatal(err)
+	}
+
+	usage, err := s.GetTokenUsageSince(time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []TokenUsage{
+		{Backend: "", Model: "", Requests: 1, PromptTokens: 400, OutputTokens: 40},
+		{Backend: "gemini", Model: "gemini-2.0-flash", Requests: 2, PromptTokens: 300, OutputTokens: 30},
+		{Backend: "local", Model: "llama3", Requests: 1, PromptTokens: 300, OutputTokens: 30},
+	}
+	if !slices.Equal(usage, want) {
+		t.Errorf("GetTokenUsageSince = %+v, want %+v", usage, want)
+	}
+
+	usage, err = s.GetTokenUsageSince(time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(usage) != 0 {
+		t.Errorf("GetTokenUsageSince(future) = %+v, want none", usage)
+	}
+}
@@ -0,0 +1,133 @@
+package history
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// decodeTags parses the tags column, a JSON array of strings
+func decodeTags(data string) []string {
+	if data == "" {
+		return nil
+	}
+	var tags []string
+	if err := json.Unmarshal([]byte(data), &tags); err != nil {
+		fmt.Printf("[History] Ignoring malformed tags %q: %v\n", data, err)
+		return nil
+	}
+	return tags
+}
+
+// normalizeTag trims a tag; tags are matched case-insensitively
+func normalizeTag(tag string) (string, error) {
+	tag = strings.TrimSpace(tag)
+	if tag == "" {
+		return "", fmt.Errorf("tag cannot be empty")
+	}
+	return tag, nil
+}
+
+// SetFavorite pins or unpins a transcript
+func (s *Service) SetFavorite(id int64, favorite bool) error {
+	result, err := s.db.Exec("UPDATE transcripts SET favorite = ? WHERE id = ?", favorite, id)
+	if err != nil {
+		return err
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("transcript not found")
+	}
+	return nil
+}
+
+// getTags returns the tags of a transcript
+func (s *Service) getTags(id int64) ([]string, error) {
+	var data sql.NullString
+	err := s.db.QueryRow("SELECT tags FROM transcripts WHERE id = ?", id).Scan(&data)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("transcript not found")
+		}
+		return nil, err
+	}
+	return decodeTags(data.String), nil
+}
+
+// setTags stores the tags of a transcript
+func (s *Service) setTags(id int64, tags []string) error {
+	data, err := json.Marshal(tags)
+	if err != nil {
+		return fmt.Errorf("failed to encode tags: %w", err)
+	}
+	_, err = s.db.Exec("UPDATE transcripts SET tags = ? WHERE id = ?", string(data), id)
+	return err
+}
+
+// AddTag adds a tag to a transcript. Adding a tag it already has is a no-op.
+func (s *Service) AddTag(id int64, tag string) error {
+	tag, err := normalizeTag(tag)
+	if err != nil {
+		return err
+	}
+	tags, err := s.getTags(id)
+	if err != nil {
+		return err
+	}
+	for _, t := range tags {
+		if strings.EqualFold(t, tag) {
+			return nil
+		}
+	}
+	return s.setTags(id, append(tags, tag))
+}
+
+// RemoveTag removes a tag from a transcript
+func (s *Service) RemoveTag(id int64, tag string) error {
+	tag, err := normalizeTag(tag)
+	if err != nil {
+		return err
+	}
+	tags, err := s.getTags(id)
+	if err != nil {
+		return err
+	}
+	kept := make([]string, 0, len(tags))
+	for _, t := range tags {
+		if !strings.EqualFold(t, tag) {
+			kept = append(kept, t)
+		}
+	}
+	if len(kept) == len(tags) {
+		return fmt.Errorf("transcript has no tag %s", tag)
+	}
+	return s.setTags(id, kept)
+}
+
+// GetByTag returns transcripts with the given tag, newest first, excluding the trash
+func (s *Service) GetByTag(tag string) ([]*Transcript, error) {
+	tag, err := normalizeTag(tag)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.db.Query(`
+		SELECT `+transcriptColumns+`
+		FROM transcripts
+		WHERE deleted_at IS NULL AND tags IS NOT NULL
+			AND EXISTS (SELECT 1 FROM json_each(transcripts.tags) WHERE lower(value) = lower(?))
+		ORDER BY timestamp DESC`, tag)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transcripts []*Transcript
+	for rows.Next() {
+		t, err := s.scanTranscript(rows)
+		if err != nil {
+			return nil, err
+		}
+		transcripts = append(transcripts, t)
+	}
+	return transcripts, rows.Err()
+}
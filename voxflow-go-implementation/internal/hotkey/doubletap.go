@@ -0,0 +1,168 @@
+package hotkey
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DoubleTapPrefix marks a hotkey triggered by tapping a lone modifier twice, e.g. "2x:cmd"
+const DoubleTapPrefix = "2x:"
+
+// DefaultDoubleTapWindow is the longest gap between the two taps
+const DefaultDoubleTapWindow = 300 * time.Millisecond
+
+// Modifier flag bits as reported by the platform modifier monitor
+// (CGEventFlags on macOS)
+const (
+	flagShift   uint64 = 0x20000
+	flagControl uint64 = 0x40000
+	flagOption  uint64 = 0x80000
+	flagCommand uint64 = 0x100000
+	flagFn      uint64 = 0x800000
+
+	modifierFlagsMask = flagShift | flagControl | flagOption | flagCommand | flagFn
+)
+
+// IsDoubleTap returns whether hotkeyStr is a double-tap spec like "2x:cmd"
+func IsDoubleTap(hotkeyStr string) bool {
+	return strings.HasPrefix(strings.ToLower(strings.TrimSpace(hotkeyStr)), DoubleTapPrefix)
+}
+
+// parseDoubleTap returns the modifier flag of a double-tap spec like "2x:cmd"
+func parseDoubleTap(hotkeyStr string) (uint64, error) {
+	name := strings.TrimPrefix(strings.ToLower(strings.TrimSpace(hotkeyStr)), DoubleTapPrefix)
+	switch name {
+	case "cmd", "command", "super":
+		return flagCommand, nil
+	case "ctrl", "control":
+		return flagControl, nil
+	case "shift":
+		return flagShift, nil
+	case "alt", "option", "opt":
+		return flagOption, nil
+	case "fn":
+		return flagFn, nil
+	}
+	return 0, fmt.Errorf("unknown double-tap modifier: %q (use cmd, ctrl, shift, alt or fn)", name)
+}
+
+// doubleTapDetector recognizes two quick taps of one modifier on its own.
+// A tap only counts if no other modifier or key is pressed with it and it's
+// released within the window; the second tap fires on keydown.
+type doubleTapDetector struct {
+	flag      uint64
+	pressed   bool      // The modifier is down on its own
+	clean     bool      // Nothing else was pressed since it went down
+	downAt    time.Time // When the modifier went down
+	lastTapAt time.Time // When the previous clean tap was released (zero if none)
+}
+
+// onFlags handles a modifier change and reports whether the double tap completed
+func (d *doubleTapDetector) onFlags(flags uint64, now time.Time, window time.Duration) bool {
+	flags &= modifierFlagsMask
+	switch {
+	case flags == d.flag && !d.pressed:
+		if !d.lastTapAt.IsZero() && now.Sub(d.lastTapAt) <= window {
+			d.reset()
+			return true
+		}
+		d.pressed, d.clean, d.downAt = true, true, now
+	case flags == 0 && d.pressed:
+		if d.clean && now.Sub(d.downAt) <= window {
+			d.lastTapAt = now
+		} else {
+			d.lastTapAt = time.Time{}
+		}
+		d.pressed = false
+	case flags != 0 && flags != d.flag:
+		// Part of a modifier combo, not a lone tap
+		d.reset()
+	}
+	return false
+}
+
+// onKeyDown cancels a pending tap when a regular key is pressed
+func (d *doubleTapDetector) onKeyDown() {
+	d.reset()
+}
+
+func (d *doubleTapDetector) reset() {
+	d.pressed, d.clean = false, false
+	d.lastTapAt = time.Time{}
+}
+
+// doubleTapWatcher feeds platform modifier events to the detectors of the
+// triggers bound to double-tap specs and reports completed double taps
+type doubleTapWatcher struct {
+	mu        sync.Mutex
+	window    time.Duration
+	detectors map[TriggerType]*doubleTapDetector
+	started   bool // The platform monitor is running
+	events    chan TriggerType
+}
+
+func newDoubleTapWatcher() *doubleTapWatcher {
+	return &doubleTapWatcher{
+		window:    DefaultDoubleTapWindow,
+		detectors: make(map[TriggerType]*doubleTapDetector),
+		events:    make(chan TriggerType, 1),
+	}
+}
+
+// bind watches for double taps of hotkeyStr's modifier on behalf of trigger,
+// starting the platform modifier monitor on first use. A monitor that failed
+// to start (e.g. no Input Monitoring permission yet) is retried on every bind,
+// so granting the permission takes effect without a restart.
+func (w *doubleTapWatcher) bind(trigger TriggerType, hotkeyStr string) error {
+	flag, err := parseDoubleTap(hotkeyStr)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.started {
+		if err := startModifierMonitor(w.handle); err != nil {
+			return err
+		}
+		w.started = true
+	}
+	w.detectors[trigger] = &doubleTapDetector{flag: flag}
+	return nil
+}
+
+// clear removes all double-tap bindings. The monitor keeps running, idle.
+func (w *doubleTapWatcher) clear() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.detectors = make(map[TriggerType]*doubleTapDetector)
+}
+
+// setWindow sets the longest gap between the two taps
+func (w *doubleTapWatcher) setWindow(window time.Duration) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.window = window
+}
+
+// handle is called by the platform monitor for every modifier change and keydown
+func (w *doubleTapWatcher) handle(flags uint64, keyDown bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	now := time.Now()
+	for trigger, d := range w.detectors {
+		if keyDown {
+			d.onKeyDown()
+			continue
+		}
+		if d.onFlags(flags, now, w.window) {
+			select {
+			case w.events <- trigger:
+			default: // The event loop is still handling the last one
+			}
+		}
+	}
+}
@@ -0,0 +1,34 @@
+#include <ApplicationServices/ApplicationServices.h>
+
+extern void goModifierEvent(unsigned long long flags, int keyDown);
+
+static CFMachPortRef modifierTap = NULL;
+
+static CGEventRef modifierTapCallback(CGEventTapProxy proxy, CGEventType type, CGEventRef event, void *refcon) {
+    // macOS disables taps that are slow to respond; turn it back on
+    if (type == kCGEventTapDisabledByTimeout || type == kCGEventTapDisabledByUserInput) {
+        if (modifierTap != NULL) {
+            CGEventTapEnable(modifierTap, true);
+        }
+        return event;
+    }
+    goModifierEvent(CGEventGetFlags(event), type == kCGEventKeyDown);
+    return event;
+}
+
+// createModifierTap creates a listen-only tap for modifier changes and
+// keydowns. Returns 0 if the app lacks Input Monitoring permission.
+int createModifierTap(void) {
+    CGEventMask mask = CGEventMaskBit(kCGEventFlagsChanged) | CGEventMaskBit(kCGEventKeyDown);
+    modifierTap = CGEventTapCreate(kCGSessionEventTap, kCGHeadInsertEventTap,
+                                   kCGEventTapOptionListenOnly, mask, modifierTapCallback, NULL);
+    return modifierTap != NULL;
+}
+
+// runModifierTap runs the tap on the calling thread's run loop. Never returns.
+void runModifierTap(void) {
+    CFRunLoopSourceRef source = CFMachPortCreateRunLoopSource(kCFAllocatorDefault, modifierTap, 0);
+    CFRunLoopAddSource(CFRunLoopGetCurrent(), source, kCFRunLoopCommonModes);
+    CGEventTapEnable(modifierTap, true);
+    CFRunLoopRun();
+}
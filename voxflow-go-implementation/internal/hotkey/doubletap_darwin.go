@@ -0,0 +1,51 @@
+//go:build darwin
+
+package hotkey
+
+/*
+#cgo LDFLAGS: -framework ApplicationServices -framework CoreFoundation
+
+int createModifierTap(void);
+void runModifierTap(void);
+*/
+import "C"
+
+import (
+	"errors"
+	"runtime"
+)
+
+// modifierHandler receives events from the modifier tap
+var modifierHandler func(flags uint64, keyDown bool)
+
+//export goModifierEvent
+func goModifierEvent(flags C.ulonglong, keyDown C.int) {
+	if modifierHandler != nil {
+		modifierHandler(uint64(flags), keyDown != 0)
+	}
+}
+
+// startModifierMonitor watches modifier keys with a Quartz event tap, which
+// golang.design/x/hotkey can't do since it only registers key combos. The tap
+// is listen-only and needs Input Monitoring permission; without it an error
+// is returned and double-tap hotkeys stay inactive.
+func startModifierMonitor(handler func(flags uint64, keyDown bool)) error {
+	modifierHandler = handler
+
+	created := make(chan bool)
+	go func() {
+		// The tap's run loop belongs to this thread
+		runtime.LockOSThread()
+		if C.createModifierTap() == 0 {
+			created <- false
+			return
+		}
+		created <- true
+		C.runModifierTap()
+	}()
+
+	if !<-created {
+		return errors.New("double-tap hotkeys need Input Monitoring permission (System Settings → Privacy & Security → Input Monitoring)")
+	}
+	return nil
+}
@@ -0,0 +1,10 @@
+//go:build !darwin
+
+package hotkey
+
+import "errors"
+
+// startModifierMonitor is only implemented on macOS
+func startModifierMonitor(handler func(flags uint64, keyDown bool)) error {
+	return errors.New("double-tap hotkeys are not supported on this platform")
+}
@@ -2,6 +2,7 @@ package hotkey
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 	"sync"
 	"time"
@@ -39,6 +40,7 @@ const (
 	TriggerNone TriggerType = iota
 	TriggerHandsFree
 	TriggerPushToTalk
+	TriggerQuickNote
 )
 
 // Callback is called when state changes
@@ -61,6 +63,28 @@ type Manager struct {
 	running       bool
 	activeTrigger TriggerType
 	reconfigCh    chan reconfigRequest
+	stopCh        chan chan struct{} // Asks the event loop to unregister everything and exit
+	loopRunning   bool               // The event loop has started and not yet exited
+
+	emergencyStr string // Always-on hotkey that bypasses state handling
+	emergencyHK  *hotkey.Hotkey
+	onEmergency  func()
+
+	quickNoteStr string // Toggles a history-only recording (empty = disabled)
+	quickNoteHK  *hotkey.Hotkey
+
+	cycleModeStr string // Advances to the next refinement mode (empty = disabled)
+	cycleModeHK  *hotkey.Hotkey
+	onCycleMode  func()
+
+	doubleTaps *doubleTapWatcher // Hands-free and quick note may be "2x:<modifier>"
+
+	pttDownAt  time.Time     // When the push-to-talk key went down
+	pttMinHold time.Duration // Shorter push-to-talk presses cancel the recording
+
+	// OnRegisterFailed is called when a hotkey can't be parsed or registered
+	// at startup, usually because another app or the OS already owns it
+	OnRegisterFailed func(name, hotkeyStr string, err error)
 }
 
 // NewManager creates a new hotkey manager
@@ -69,15 +93,27 @@ func NewManager(callback Callback) *Manager {
 		state:      StateIdle,
 		callback:   callback,
 		reconfigCh: make(chan reconfigRequest), // Unbuffered for synchronous update
+		stopCh:     make(chan chan struct{}),
+		doubleTaps: newDoubleTapWatcher(),
 	}
 }
 
 // parseHotkey converts a string like "cmd+shift+v" to hotkey modifiers and key
 func parseHotkey(hotkeyStr string) ([]hotkey.Modifier, hotkey.Key, error) {
+	if IsDoubleTap(hotkeyStr) {
+		return nil, 0, fmt.Errorf("double-tap hotkeys (%s) can only be used for hands-free and quick note", hotkeyStr)
+	}
 	parts := strings.Split(strings.ToLower(hotkeyStr), "+")
-	if len(parts) < 2 {
+	if hotkeyStr == "" || (len(parts) > 1 && parts[len(parts)-1] == "") {
 		return nil, 0, fmt.Errorf("invalid hotkey format: %s", hotkeyStr)
 	}
+	if len(parts) == 1 {
+		key, err := parseSingleKey(parts[0])
+		if err != nil {
+			return nil, 0, err
+		}
+		return nil, key, nil
+	}
 
 	var mods []hotkey.Modifier
 	for _, part := range parts[:len(parts)-1] {
@@ -104,32 +140,120 @@ func parseHotkey(hotkeyStr string) ([]hotkey.Modifier, hotkey.Key, error) {
 	return mods, key, nil
 }
 
-// parseKey converts a key string to a hotkey.Key
-func parseKey(keyStr string) (hotkey.Key, error) {
-	keyMap := map[string]hotkey.Key{
-		"a": hotkey.KeyA, "b": hotkey.KeyB, "c": hotkey.KeyC,
-		"d": hotkey.KeyD, "e": hotkey.KeyE, "f": hotkey.KeyF,
-		"g": hotkey.KeyG, "h": hotkey.KeyH, "i": hotkey.KeyI,
-		"j": hotkey.KeyJ, "k": hotkey.KeyK, "l": hotkey.KeyL,
-		"m": hotkey.KeyM, "n": hotkey.KeyN, "o": hotkey.KeyO,
-		"p": hotkey.KeyP, "q": hotkey.KeyQ, "r": hotkey.KeyR,
-		"s": hotkey.KeyS, "t": hotkey.KeyT, "u": hotkey.KeyU,
-		"v": hotkey.KeyV, "w": hotkey.KeyW, "x": hotkey.KeyX,
-		"y": hotkey.KeyY, "z": hotkey.KeyZ,
-		"0": hotkey.Key0, "1": hotkey.Key1, "2": hotkey.Key2,
-		"3": hotkey.Key3, "4": hotkey.Key4, "5": hotkey.Key5,
-		"6": hotkey.Key6, "7": hotkey.Key7, "8": hotkey.Key8,
-		"9":      hotkey.Key9,
-		"space":  hotkey.KeySpace,
-		"return": hotkey.KeyReturn, "enter": hotkey.KeyReturn,
-		"escape": hotkey.KeyEscape, "esc": hotkey.KeyEscape,
-		"tab": hotkey.KeyTab,
+// ValidateHotkey checks that hotkeyStr is a well-formed hotkey or double-tap
+// spec without registering it, returning the specific parse error if not
+func ValidateHotkey(hotkeyStr string) error {
+	if IsDoubleTap(hotkeyStr) {
+		_, err := parseDoubleTap(hotkeyStr)
+		return err
+	}
+	_, _, err := parseHotkey(hotkeyStr)
+	return err
+}
+
+// Keys golang.design/x/hotkey has no constants for, as macOS virtual key codes
+const (
+	keyHome          hotkey.Key = 0x73
+	keyEnd           hotkey.Key = 0x77
+	keyPageUp        hotkey.Key = 0x74
+	keyPageDown      hotkey.Key = 0x79
+	keyForwardDelete hotkey.Key = 0x75
+	keyMinus         hotkey.Key = 0x1B
+	keyEqual         hotkey.Key = 0x18
+	keyLeftBracket   hotkey.Key = 0x21
+	keyRightBracket  hotkey.Key = 0x1E
+	keySemicolon     hotkey.Key = 0x29
+	keyQuote         hotkey.Key = 0x27
+	keyComma         hotkey.Key = 0x2B
+	keyPeriod        hotkey.Key = 0x2F
+	keySlash         hotkey.Key = 0x2C
+	keyBackslash     hotkey.Key = 0x2A
+	keyGrave         hotkey.Key = 0x32
+)
+
+// keyMap maps key names in hotkey strings to keys
+var keyMap = map[string]hotkey.Key{
+	"a": hotkey.KeyA, "b": hotkey.KeyB, "c": hotkey.KeyC,
+	"d": hotkey.KeyD, "e": hotkey.KeyE, "f": hotkey.KeyF,
+	"g": hotkey.KeyG, "h": hotkey.KeyH, "i": hotkey.KeyI,
+	"j": hotkey.KeyJ, "k": hotkey.KeyK, "l": hotkey.KeyL,
+	"m": hotkey.KeyM, "n": hotkey.KeyN, "o": hotkey.KeyO,
+	"p": hotkey.KeyP, "q": hotkey.KeyQ, "r": hotkey.KeyR,
+	"s": hotkey.KeyS, "t": hotkey.KeyT, "u": hotkey.KeyU,
+	"v": hotkey.KeyV, "w": hotkey.KeyW, "x": hotkey.KeyX,
+	"y": hotkey.KeyY, "z": hotkey.KeyZ,
+	"0": hotkey.Key0, "1": hotkey.Key1, "2": hotkey.Key2,
+	"3": hotkey.Key3, "4": hotkey.Key4, "5": hotkey.Key5,
+	"6": hotkey.Key6, "7": hotkey.Key7, "8": hotkey.Key8,
+	"9":      hotkey.Key9,
+	"space":  hotkey.KeySpace,
+	"return": hotkey.KeyReturn, "enter": hotkey.KeyReturn,
+	"escape": hotkey.KeyEscape, "esc": hotkey.KeyEscape,
+	"tab": hotkey.KeyTab,
+
+	// Function keys
+	"f1": hotkey.KeyF1, "f2": hotkey.KeyF2, "f3": hotkey.KeyF3,
+	"f4": hotkey.KeyF4, "f5": hotkey.KeyF5, "f6": hotkey.KeyF6,
+	"f7": hotkey.KeyF7, "f8": hotkey.KeyF8, "f9": hotkey.KeyF9,
+	"f10": hotkey.KeyF10, "f11": hotkey.KeyF11, "f12": hotkey.KeyF12,
+	"f13": hotkey.KeyF13, "f14": hotkey.KeyF14, "f15": hotkey.KeyF15,
+	"f16": hotkey.KeyF16, "f17": hotkey.KeyF17, "f18": hotkey.KeyF18,
+	"f19": hotkey.KeyF19, "f20": hotkey.KeyF20,
+
+	// Navigation and editing
+	"up": hotkey.KeyUp, "down": hotkey.KeyDown,
+	"left": hotkey.KeyLeft, "right": hotkey.KeyRight,
+	"home": keyHome, "end": keyEnd,
+	"pageup": keyPageUp, "pagedown": keyPageDown,
+	"delete": hotkey.KeyDelete, "backspace": hotkey.KeyDelete,
+	"forwarddelete": keyForwardDelete,
+
+	// Punctuation, by name or character
+	"minus": keyMinus, "-": keyMinus,
+	"equal": keyEqual, "=": keyEqual,
+	"leftbracket": keyLeftBracket, "[": keyLeftBracket,
+	"rightbracket": keyRightBracket, "]": keyRightBracket,
+	"semicolon": keySemicolon, ";": keySemicolon,
+	"quote": keyQuote, "'": keyQuote,
+	"comma": keyComma, ",": keyComma,
+	"period": keyPeriod, ".": keyPeriod,
+	"slash": keySlash, "/": keySlash,
+	"backslash": keyBackslash, "\\": keyBackslash,
+	"grave": keyGrave, "`": keyGrave,
+}
+
+// supportedKeyNames summarizes the key names parseKey accepts, for errors
+const supportedKeyNames = "a-z, 0-9, f1-f20, space, return/enter, escape/esc, tab, " +
+	"up, down, left, right, home, end, pageup, pagedown, delete/backspace, forwarddelete, " +
+	"minus, equal, leftbracket, rightbracket, semicolon, quote, comma, period, slash, backslash, grave " +
+	"(or the punctuation character itself)"
+
+// functionKeyPattern matches f1-f20, the only keys allowed without a modifier
+var functionKeyPattern = regexp.MustCompile(`^f([1-9]|1[0-9]|20)$`)
+
+// parseSingleKey parses a binding with no modifier. Only function keys are
+// allowed: a plain letter, digit or editing key would be swallowed in every app.
+func parseSingleKey(keyStr string) (hotkey.Key, error) {
+	switch keyStr {
+	case "fn", "globe", "playpause", "play", "next", "previous", "mute", "volumeup", "volumedown":
+		return 0, fmt.Errorf("%s can't be a global hotkey on macOS; use a function key, or \"2x:fn\" to double-tap fn", keyStr)
 	}
+	key, err := parseKey(keyStr)
+	if err != nil {
+		return 0, err
+	}
+	if !functionKeyPattern.MatchString(keyStr) {
+		return 0, fmt.Errorf("%s needs a modifier (e.g. cmd+shift+%s); only function keys f1-f20 can be used alone", keyStr, keyStr)
+	}
+	return key, nil
+}
 
+// parseKey converts a key string to a hotkey.Key
+func parseKey(keyStr string) (hotkey.Key, error) {
 	if key, ok := keyMap[keyStr]; ok {
 		return key, nil
 	}
-	return 0, fmt.Errorf("unknown key: %s", keyStr)
+	return 0, fmt.Errorf("unknown key: %q (supported: %s)", keyStr, supportedKeyNames)
 }
 
 // Update updates the registered hotkeys (called from app.go)
@@ -152,52 +276,116 @@ func (m *Manager) Update(handsFreeStr, pttStr string) error {
 	}
 }
 
+// SetEmergencyHotkey configures a dedicated hotkey that calls callback
+// regardless of state. Takes effect on Start or the next Update.
+func (m *Manager) SetEmergencyHotkey(hotkeyStr string, callback func()) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.emergencyStr = hotkeyStr
+	m.onEmergency = callback
+}
+
+// SetQuickNoteHotkey sets the quick-note hotkey, which toggles recording like
+// hands-free but reports TriggerQuickNote. Takes effect on Start or the next Update.
+func (m *Manager) SetQuickNoteHotkey(hotkeyStr string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.quickNoteStr = hotkeyStr
+}
+
+// SetCycleModeHotkey sets the hotkey that calls callback to switch to the next
+// refinement mode, in any state. Takes effect on Start or the next Update.
+func (m *Manager) SetCycleModeHotkey(hotkeyStr string, callback func()) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cycleModeStr = hotkeyStr
+	m.onCycleMode = callback
+}
+
+// SetDoubleTapWindow sets the longest gap between the taps of a double-tap hotkey
+func (m *Manager) SetDoubleTapWindow(window time.Duration) {
+	if window <= 0 {
+		window = DefaultDoubleTapWindow
+	}
+	m.doubleTaps.setWindow(window)
+}
+
+// SetMinHoldDuration sets how long push-to-talk must be held; shorter presses
+// go back to idle so the recording is discarded (0 = no minimum)
+func (m *Manager) SetMinHoldDuration(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pttMinHold = d
+}
+
+// ActiveTrigger returns what started the current recording
+func (m *Manager) ActiveTrigger() TriggerType {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.activeTrigger
+}
+
 // Start begins listening using mainthread
 // This should only be called ONCE at app startup
 func (m *Manager) Start(handsFreeStr, pttStr string) error {
 	m.mu.Lock()
 	m.running = true
+	m.loopRunning = true
 	m.mu.Unlock()
 
 	go mainthread.Init(func() {
 		// Initial Registration
-		if handsFreeStr != "" {
-			mods, key, err := parseHotkey(handsFreeStr)
-			if err == nil {
-				m.handsFreeHK = hotkey.New(mods, key)
-				if err := m.handsFreeHK.Register(); err != nil {
-					fmt.Printf("Failed to register initial hands-free: %v\n", err)
-				}
-			}
-		}
-		if pttStr != "" {
-			mods, key, err := parseHotkey(pttStr)
-			if err == nil {
-				m.pushToTalkHK = hotkey.New(mods, key)
-				if err := m.pushToTalkHK.Register(); err != nil {
-					fmt.Printf("Failed to register initial ptt: %v\n", err)
-				}
-			}
-		}
+		m.handsFreeHK = m.registerToggle(TriggerHandsFree, "hands-free", handsFreeStr)
+		m.pushToTalkHK = m.registerInitial("push-to-talk", pttStr)
+
+		m.mu.RLock()
+		quickNoteStr := m.quickNoteStr
+		m.mu.RUnlock()
+		m.quickNoteHK = m.registerToggle(TriggerQuickNote, "quick note", quickNoteStr)
+		m.mu.RLock()
+		cycleModeStr := m.cycleModeStr
+		m.mu.RUnlock()
+		m.cycleModeHK = m.registerInitial("cycle mode", cycleModeStr)
+		m.mu.RLock()
+		emergencyStr := m.emergencyStr
+		m.mu.RUnlock()
+		m.emergencyHK = m.registerInitial("emergency stop", emergencyStr)
 
 		// Event Loop - runs FOREVER
 		for {
 			// Get current hotkey references (no lock needed for reading pointers in this context)
 			hf := m.handsFreeHK
 			ptt := m.pushToTalkHK
+			qn := m.quickNoteHK
+			cm := m.cycleModeHK
+			em := m.emergencyHK
 
-			var hfDown <-chan hotkey.Event
+			var hfDown, qnDown, cmDown, emergencyDown <-chan hotkey.Event
 			var pttDown, pttUp <-chan hotkey.Event
 
 			if hf != nil {
 				hfDown = hf.Keydown()
 			}
+			if qn != nil {
+				qnDown = qn.Keydown()
+			}
+			if cm != nil {
+				cmDown = cm.Keydown()
+			}
+			if em != nil {
+				emergencyDown = em.Keydown()
+			}
 			if ptt != nil {
 				pttDown = ptt.Keydown()
 				pttUp = ptt.Keyup()
 			}
 
 			select {
+			case done := <-m.stopCh:
+				m.unregisterAll()
+				close(done)
+				return
+
 			case req := <-m.reconfigCh:
 				// Reconfigure request received
 				err := m.handleReconfigure(req.handsFreeStr, req.pttStr)
@@ -210,6 +398,12 @@ func (m *Manager) Start(handsFreeStr, pttStr string) error {
 				}
 				m.handleHandsFree()
 
+			case _, ok := <-qnDown:
+				if !ok {
+					continue
+				}
+				m.handleQuickNote()
+
 			case _, ok := <-pttDown:
 				if !ok {
 					continue
@@ -222,6 +416,37 @@ func (m *Manager) Start(handsFreeStr, pttStr string) error {
 				}
 				m.handlePushToTalkUp()
 
+			case _, ok := <-cmDown:
+				if !ok {
+					continue
+				}
+				m.mu.RLock()
+				onCycleMode := m.onCycleMode
+				m.mu.RUnlock()
+				if onCycleMode != nil {
+					go onCycleMode()
+				}
+
+			case trigger := <-m.doubleTaps.events:
+				if trigger == TriggerQuickNote {
+					m.handleQuickNote()
+				} else {
+					m.handleHandsFree()
+				}
+
+			case _, ok := <-emergencyDown:
+				if !ok {
+					continue
+				}
+				fmt.Println("[Hotkey] Emergency stop triggered!")
+				m.mu.RLock()
+				onEmergency := m.onEmergency
+				m.mu.RUnlock()
+				if onEmergency != nil {
+					// Run outside the event loop so a slow stop can't block hotkeys
+					go onEmergency()
+				}
+
 			case <-time.After(100 * time.Millisecond):
 				// Heartbeat to allow reconfigure checks
 			}
@@ -231,6 +456,49 @@ func (m *Manager) Start(handsFreeStr, pttStr string) error {
 	return nil
 }
 
+// registerInitial parses and registers a hotkey at startup, reporting failures
+// through OnRegisterFailed. Returns nil if hotkeyStr is empty or registration failed.
+func (m *Manager) registerInitial(name, hotkeyStr string) *hotkey.Hotkey {
+	if hotkeyStr == "" {
+		return nil
+	}
+
+	mods, key, err := parseHotkey(hotkeyStr)
+	if err != nil {
+		fmt.Printf("[Hotkey] Invalid %s hotkey %q: %v\n", name, hotkeyStr, err)
+		m.reportRegisterFailed(name, hotkeyStr, err)
+		return nil
+	}
+
+	hk := hotkey.New(mods, key)
+	if err := hk.Register(); err != nil {
+		fmt.Printf("[Hotkey] Failed to register %s hotkey %q: %v\n", name, hotkeyStr, err)
+		m.reportRegisterFailed(name, hotkeyStr, err)
+		return nil
+	}
+	return hk
+}
+
+// registerToggle registers a hands-free or quick note hotkey, which may also
+// be a double-tap spec watched by the modifier monitor instead
+func (m *Manager) registerToggle(trigger TriggerType, name, hotkeyStr string) *hotkey.Hotkey {
+	if !IsDoubleTap(hotkeyStr) {
+		return m.registerInitial(name, hotkeyStr)
+	}
+	if err := m.doubleTaps.bind(trigger, hotkeyStr); err != nil {
+		fmt.Printf("[Hotkey] Failed to watch %s double tap %q: %v\n", name, hotkeyStr, err)
+		m.reportRegisterFailed(name, hotkeyStr, err)
+	}
+	return nil
+}
+
+// reportRegisterFailed calls OnRegisterFailed without blocking the event loop
+func (m *Manager) reportRegisterFailed(name, hotkeyStr string, err error) {
+	if m.OnRegisterFailed != nil {
+		go m.OnRegisterFailed(name, hotkeyStr, err)
+	}
+}
+
 // handleReconfigure performs the actual hotkey swap (called from main loop)
 func (m *Manager) handleReconfigure(handsFreeStr, pttStr string) error {
 	// Unregister old hotkeys
@@ -242,9 +510,43 @@ func (m *Manager) handleReconfigure(handsFreeStr, pttStr string) error {
 		m.pushToTalkHK.Unregister()
 		m.pushToTalkHK = nil
 	}
+	if m.quickNoteHK != nil {
+		m.quickNoteHK.Unregister()
+		m.quickNoteHK = nil
+	}
+	if m.cycleModeHK != nil {
+		m.cycleModeHK.Unregister()
+		m.cycleModeHK = nil
+	}
+	if m.emergencyHK != nil {
+		m.emergencyHK.Unregister()
+		m.emergencyHK = nil
+	}
+	m.doubleTaps.clear()
+
+	// Emergency stop goes first so it stays registered even if another binding is bad
+	m.mu.RLock()
+	emergencyStr := m.emergencyStr
+	m.mu.RUnlock()
+	if emergencyStr != "" {
+		mods, key, err := parseHotkey(emergencyStr)
+		if err != nil {
+			fmt.Printf("Invalid emergency stop hotkey: %v\n", err)
+		} else {
+			m.emergencyHK = hotkey.New(mods, key)
+			if err := m.emergencyHK.Register(); err != nil {
+				fmt.Printf("Failed to register emergency stop: %v\n", err)
+				m.emergencyHK = nil
+			}
+		}
+	}
 
 	// Parse and register new hands-free
-	if handsFreeStr != "" {
+	if IsDoubleTap(handsFreeStr) {
+		if err := m.doubleTaps.bind(TriggerHandsFree, handsFreeStr); err != nil {
+			return fmt.Errorf("invalid hands-free hotkey: %w", err)
+		}
+	} else if handsFreeStr != "" {
 		mods, key, err := parseHotkey(handsFreeStr)
 		if err != nil {
 			return fmt.Errorf("invalid hands-free hotkey: %w", err)
@@ -264,6 +566,7 @@ func (m *Manager) handleReconfigure(handsFreeStr, pttStr string) error {
 				m.handsFreeHK.Unregister()
 				m.handsFreeHK = nil
 			}
+			m.doubleTaps.clear()
 			return fmt.Errorf("invalid ptt hotkey: %w", err)
 		}
 		m.pushToTalkHK = hotkey.New(mods, key)
@@ -273,10 +576,48 @@ func (m *Manager) handleReconfigure(handsFreeStr, pttStr string) error {
 				m.handsFreeHK.Unregister()
 				m.handsFreeHK = nil
 			}
+			m.doubleTaps.clear()
 			return fmt.Errorf("failed to register ptt: %w", err)
 		}
 	}
 
+	// Quick note is optional; a bad binding shouldn't take the others down
+	m.mu.RLock()
+	quickNoteStr := m.quickNoteStr
+	m.mu.RUnlock()
+	if IsDoubleTap(quickNoteStr) {
+		if err := m.doubleTaps.bind(TriggerQuickNote, quickNoteStr); err != nil {
+			fmt.Printf("Invalid quick note hotkey: %v\n", err)
+		}
+	} else if quickNoteStr != "" {
+		mods, key, err := parseHotkey(quickNoteStr)
+		if err != nil {
+			fmt.Printf("Invalid quick note hotkey: %v\n", err)
+		} else {
+			m.quickNoteHK = hotkey.New(mods, key)
+			if err := m.quickNoteHK.Register(); err != nil {
+				fmt.Printf("Failed to register quick note: %v\n", err)
+				m.quickNoteHK = nil
+			}
+		}
+	}
+
+	// Cycle mode is optional too
+	m.mu.RLock()
+	cycleModeStr := m.cycleModeStr
+	m.mu.RUnlock()
+	if cycleModeStr != "" {
+		mods, key, err := parseHotkey(cycleModeStr)
+		if err != nil {
+			fmt.Printf("Invalid cycle mode hotkey: %v\n", err)
+		} else {
+			m.cycleModeHK = hotkey.New(mods, key)
+			if err := m.cycleModeHK.Register(); err != nil {
+				fmt.Printf("Failed to register cycle mode: %v\n", err)
+				m.cycleModeHK = nil
+			}
+		}
+	}
 	return nil
 }
 
@@ -321,6 +662,43 @@ func (m *Manager) handleHandsFree() {
 	}
 }
 
+func (m *Manager) handleQuickNote() {
+	fmt.Println("[Hotkey] QuickNote triggered!")
+	m.mu.Lock()
+
+	if !m.running {
+		fmt.Println("[Hotkey] QuickNote ignored - not running")
+		m.mu.Unlock()
+		return
+	}
+
+	var newState State
+	var shouldCallback bool
+
+	switch m.state {
+	case StateIdle:
+		m.state = StateRecording
+		m.activeTrigger = TriggerQuickNote
+		newState = m.state
+		shouldCallback = true
+	case StateRecording:
+		if m.activeTrigger == TriggerQuickNote {
+			m.state = StateProcessing
+			m.activeTrigger = TriggerNone
+			newState = m.state
+			shouldCallback = true
+		}
+	}
+
+	callback := m.callback
+	m.mu.Unlock()
+
+	if shouldCallback && callback != nil {
+		fmt.Printf("[Hotkey] QuickNote calling callback with state: %s\n", newState)
+		callback(newState)
+	}
+}
+
 func (m *Manager) handlePushToTalkDown() {
 	fmt.Println("[Hotkey] PushToTalk DOWN triggered!")
 	m.mu.Lock()
@@ -338,6 +716,7 @@ func (m *Manager) handlePushToTalkDown() {
 	if m.state == StateIdle {
 		m.state = StateRecording
 		m.activeTrigger = TriggerPushToTalk
+		m.pttDownAt = time.Now()
 		newState = m.state
 		shouldCallback = true
 	}
@@ -366,7 +745,13 @@ func (m *Manager) handlePushToTalkUp() {
 	var shouldCallback bool
 
 	if m.state == StateRecording && m.activeTrigger == TriggerPushToTalk {
-		m.state = StateProcessing
+		if held := time.Since(m.pttDownAt); held < m.pttMinHold {
+			// Too short to be intentional; cancel instead of processing
+			fmt.Printf("[Hotkey] PushToTalk held %v (< %v), cancelling\n", held.Round(time.Millisecond), m.pttMinHold)
+			m.state = StateIdle
+		} else {
+			m.state = StateProcessing
+		}
 		m.activeTrigger = TriggerNone
 		newState = m.state
 		shouldCallback = true
@@ -381,13 +766,37 @@ func (m *Manager) handlePushToTalkUp() {
 	}
 }
 
-// Stop stops listening for hotkey
+// Stop unregisters all hotkeys so the OS releases them, and ends the event loop
 func (m *Manager) Stop() {
 	m.mu.Lock()
 	m.running = false
+	loopRunning := m.loopRunning
+	m.loopRunning = false
 	m.mu.Unlock()
-	// Note: We don't actually stop the mainthread.Init loop because
-	// doing so would terminate the app. We just set running=false.
+
+	if !loopRunning {
+		return
+	}
+
+	done := make(chan struct{})
+	select {
+	case m.stopCh <- done:
+		<-done
+		fmt.Println("[Hotkey] Hotkeys unregistered")
+	case <-time.After(2 * time.Second):
+		fmt.Println("[Hotkey] Timed out waiting for the event loop to stop")
+	}
+}
+
+// unregisterAll releases every registered hotkey (called from the event loop)
+func (m *Manager) unregisterAll() {
+	for _, hk := range []**hotkey.Hotkey{&m.handsFreeHK, &m.pushToTalkHK, &m.quickNoteHK, &m.cycleModeHK, &m.emergencyHK} {
+		if *hk != nil {
+			(*hk).Unregister()
+			*hk = nil
+		}
+	}
+	m.doubleTaps.clear()
 }
 
 // GetState returns the current state
@@ -0,0 +1,148 @@
+package hotkey
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"golang.design/x/hotkey"
+)
+
+func TestParseKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want hotkey.Key
+	}{
+		{"f1", hotkey.KeyF1},
+		{"f5", hotkey.KeyF5},
+		{"f9", hotkey.KeyF9},
+		{"f10", hotkey.KeyF10},
+		{"f12", hotkey.KeyF12},
+		{"f13", hotkey.KeyF13},
+		{"f19", hotkey.KeyF19},
+		{"f20", hotkey.KeyF20},
+		{"up", hotkey.KeyUp},
+		{"down", hotkey.KeyDown},
+		{"left", hotkey.KeyLeft},
+		{"right", hotkey.KeyRight},
+		{"home", keyHome},
+		{"end", keyEnd},
+		{"pageup", keyPageUp},
+		{"pagedown", keyPageDown},
+		{"delete", hotkey.KeyDelete},
+		{"backspace", hotkey.KeyDelete},
+		{"forwarddelete", keyForwardDelete},
+		{"minus", keyMinus},
+		{"-", keyMinus},
+		{"equal", keyEqual},
+		{"=", keyEqual},
+		{"leftbracket", keyLeftBracket},
+		{"[", keyLeftBracket},
+		{"rightbracket", keyRightBracket},
+		{"]", keyRightBracket},
+		{"semicolon", keySemicolon},
+		{";", keySemicolon},
+		{"quote", keyQuote},
+		{"'", keyQuote},
+		{"comma", keyComma},
+		{",", keyComma},
+		{"period", keyPeriod},
+		{".", keyPeriod},
+		{"slash", keySlash},
+		{"/", keySlash},
+		{"backslash", keyBackslash},
+		{"\\", keyBackslash},
+		{"grave", keyGrave},
+		{"`", keyGrave},
+	}
+	for _, tt := range tests {
+		got, err := parseKey(tt.key)
+		if err != nil {
+			t.Errorf("parseKey(%q): %v", tt.key, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseKey(%q) = %#x, want %#x", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestParseKeyUnknown(t *testing.T) {
+	for _, key := range []string{"f0", "f21", "pgup", "insert", "plus", "ä"} {
+		_, err := parseKey(key)
+		if err == nil {
+			t.Errorf("parseKey(%q) succeeded, want an error", key)
+			continue
+		}
+		if !strings.Contains(err.Error(), "supported:") {
+			t.Errorf("parseKey(%q) error %q doesn't list the supported keys", key, err)
+		}
+	}
+}
+
+func TestParseHotkey(t *testing.T) {
+	tests := []struct {
+		hotkey   string
+		wantMods []hotkey.Modifier
+		wantKey  hotkey.Key
+		wantErr  bool
+	}{
+		{"cmd+shift+v", []hotkey.Modifier{hotkey.ModCmd, hotkey.ModShift}, hotkey.KeyV, false},
+		{"Ctrl+Option+Space", []hotkey.Modifier{hotkey.ModCtrl, hotkey.ModOption}, hotkey.KeySpace, false},
+		{"cmd+f5", []hotkey.Modifier{hotkey.ModCmd}, hotkey.KeyF5, false},
+		{"alt+pagedown", []hotkey.Modifier{hotkey.ModOption}, keyPageDown, false},
+		{"ctrl+shift+up", []hotkey.Modifier{hotkey.ModCtrl, hotkey.ModShift}, hotkey.KeyUp, false},
+		{"cmd+/", []hotkey.Modifier{hotkey.ModCmd}, keySlash, false},
+		{"cmd+shift+period", []hotkey.Modifier{hotkey.ModCmd, hotkey.ModShift}, keyPeriod, false},
+		{"f13", nil, hotkey.KeyF13, false},
+		{"", nil, 0, true},
+		{"cmd+", nil, 0, true},
+		{"hyper+v", nil, 0, true},
+		{"cmd+shift+nope", nil, 0, true},
+		{"2x:cmd", nil, 0, true},
+	}
+	for _, tt := range tests {
+		mods, key, err := parseHotkey(tt.hotkey)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseHotkey(%q) error = %v, wantErr %v", tt.hotkey, err, tt.wantErr)
+			continue
+		}
+		if tt.wantErr {
+			continue
+		}
+		if !slices.Equal(mods, tt.wantMods) || key != tt.wantKey {
+			t.Errorf("parseHotkey(%q) = (%v, %#x), want (%v, %#x)", tt.hotkey, mods, key, tt.wantMods, tt.wantKey)
+		}
+	}
+}
+
+func TestParseSingleKey(t *testing.T) {
+	tests := []struct {
+		key     string
+		want    hotkey.Key
+		wantErr bool
+	}{
+		{"f1", hotkey.KeyF1, false},
+		{"f9", hotkey.KeyF9, false},
+		{"f10", hotkey.KeyF10, false},
+		{"f19", hotkey.KeyF19, false},
+		{"f20", hotkey.KeyF20, false},
+		{"f", 0, true},
+		{"forwarddelete", 0, true},
+		{"f21", 0, true},
+		{"space", 0, true},
+		{"a", 0, true},
+		{"fn", 0, true},
+		{"playpause", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseSingleKey(tt.key)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseSingleKey(%q) error = %v, wantErr %v", tt.key, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseSingleKey(%q) = %#x, want %#x", tt.key, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+//go:build darwin
+
+package injection
+
+/*
+#cgo LDFLAGS: -framework ApplicationServices
+
+#include <ApplicationServices/ApplicationServices.h>
+
+int accessibilityTrusted(void) {
+    return AXIsProcessTrusted() ? 1 : 0;
+}
+*/
+import "C"
+
+// accessibilityTrusted reports whether the process may post keyboard events
+func accessibilityTrusted() (bool, error) {
+	return C.accessibilityTrusted() != 0, nil
+}
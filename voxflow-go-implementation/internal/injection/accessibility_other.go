@@ -0,0 +1,10 @@
+//go:build !darwin
+
+package injection
+
+import "errors"
+
+// accessibilityTrusted is only implemented on macOS
+func accessibilityTrusted() (bool, error) {
+	return false, errors.New("accessibility permission check is not supported on this platform")
+}
@@ -1,17 +1,94 @@
 package injection
 
 import (
+	"errors"
 	"fmt"
 	"os/exec"
+	"strings"
+	"sync/atomic"
 	"time"
+	"unicode/utf16"
 
 	"golang.design/x/clipboard"
 )
 
+// Line endings supported for clipboard text
+const (
+	LineEndingLF   = "lf"
+	LineEndingCRLF = "crlf"
+)
+
+// Injection modes: paste via the clipboard, or type each character
+const (
+	ModePaste = "paste"
+	ModeType  = "type"
+)
+
+// SlowTypeChars is the length above which type mode takes noticeably long
+const SlowTypeChars = 300
+
+// typeChunkRunes is how many characters are typed per keystroke command, so
+// Abort can stop a long text between chunks
+const typeChunkRunes = 100
+
+// ErrNoFocusedField is returned when no UI element has keyboard focus, so
+// there is nowhere to paste. It is not retried.
+var ErrNoFocusedField = errors.New("no focused text field")
+
+// ErrNoAccessibility is returned when voxflow isn't allowed to send
+// keystrokes; macOS would drop them silently. It is not retried.
+var ErrNoAccessibility = errors.New("accessibility permission not granted")
+
+// AccessibilitySettingsURL opens the Accessibility pane of System Settings
+const AccessibilitySettingsURL = "x-apple.systempreferences:com.apple.preference.security?Privacy_Accessibility"
+
+// ErrPasteFailed is returned when every paste attempt failed or didn't
+// change the focused field. The text is still on the clipboard.
+var ErrPasteFailed = errors.New("paste failed")
+
+// errPasteNotLanded is an attempt whose keystroke was sent but left the
+// focused field unchanged
+var errPasteNotLanded = errors.New("focused field unchanged after paste")
+
+// pasteVerifyDelay is how long to give the target app to apply a paste
+// before checking the focused field. Electron and web text areas can take a
+// while to update their accessibility value.
+const pasteVerifyDelay = 600 * time.Millisecond
+
+// ErrAborted is returned when Abort stops an injection before pasting
+var ErrAborted = errors.New("injection aborted")
+
+// Delays are the waits around a paste. Too short and the paste can race the
+// clipboard write, or the restored clipboard gets pasted instead.
+type Delays struct {
+	ClipboardSettle time.Duration // After writing the clipboard, before Cmd+V
+	PostPaste       time.Duration // After Cmd+V
+	Restore         time.Duration // Before restoring the previous clipboard, on top of PostPaste
+	Adaptive        bool          // Wait for the pasteboard to report the write instead of ClipboardSettle
+}
+
+// DefaultDelays are safe on slow machines
+var DefaultDelays = Delays{
+	ClipboardSettle: 50 * time.Millisecond,
+	PostPaste:       100 * time.Millisecond,
+	Restore:         200 * time.Millisecond,
+}
+
+// adaptiveTimeout bounds how long Adaptive waits for the pasteboard
+const adaptiveTimeout = 500 * time.Millisecond
+
+// pasteRetryBackoff is the delay before the first paste retry; it grows linearly
+const pasteRetryBackoff = 150 * time.Millisecond
+
 // Service handles text injection into the active application
 type Service struct {
-	originalClipboard []byte
+	originalClipboard clipboardSnapshot
 	preserveClipboard bool
+	lineEnding        string
+	pasteRetries      int    // Extra paste attempts after a transient failure
+	mode              string // ModePaste or ModeType
+	delays            Delays
+	aborted           atomic.Bool
 }
 
 // NewService creates a new injection service
@@ -23,41 +100,354 @@ func NewService(preserveClipboard bool) (*Service, error) {
 
 	return &Service{
 		preserveClipboard: preserveClipboard,
+		lineEnding:        LineEndingLF,
+		mode:              ModePaste,
+		delays:            DefaultDelays,
 	}, nil
 }
 
-// Inject injects text into the currently focused application
+// SetLineEnding sets the line ending written to the clipboard (lf or crlf)
+func (s *Service) SetLineEnding(ending string) error {
+	switch ending {
+	case LineEndingLF, LineEndingCRLF:
+		s.lineEnding = ending
+		return nil
+	default:
+		return fmt.Errorf("unknown line ending: %s", ending)
+	}
+}
+
+// SetMode sets how text is injected: ModePaste (clipboard and Cmd+V) or
+// ModeType (keystrokes, for apps that block or mangle paste)
+func (s *Service) SetMode(mode string) error {
+	switch mode {
+	case ModePaste, ModeType:
+		s.mode = mode
+		return nil
+	default:
+		return fmt.Errorf("unknown injection mode: %s (use paste or type)", mode)
+	}
+}
+
+// SetDelays sets the waits around a paste
+func (s *Service) SetDelays(delays Delays) {
+	s.delays = delays
+}
+
+// SetPasteRetries sets how many times a failed paste keystroke is retried
+func (s *Service) SetPasteRetries(retries int) {
+	if retries < 0 {
+		retries = 0
+	}
+	s.pasteRetries = retries
+}
+
+// normalizeLineEndings converts all line breaks in text to the configured ending
+func (s *Service) normalizeLineEndings(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+	if s.lineEnding == LineEndingCRLF {
+		text = strings.ReplaceAll(text, "\n", "\r\n")
+	}
+	return text
+}
+
+// Inject injects text into the currently focused application. In paste mode
+// the text is left on the clipboard if pasting fails, and the previous
+// clipboard comes back if it succeeds or is aborted.
 func (s *Service) Inject(text string) error {
+	s.aborted.Store(false)
+
+	if s.mode == ModeType {
+		if trusted, err := s.CheckAccessibilityPermission(); err == nil && !trusted {
+			return ErrNoAccessibility
+		}
+		return s.typeText(text)
+	}
+
 	// Optionally save current clipboard content
 	if s.preserveClipboard {
-		s.originalClipboard = clipboard.Read(clipboard.FmtText)
+		s.originalClipboard = snapshotClipboard()
 	}
 
-	// Copy text to clipboard
-	clipboard.Write(clipboard.FmtText, []byte(text))
+	// Copy text to clipboard as plain UTF-8, first so it's there to paste by
+	// hand if the keystroke can't be sent
+	before, countable := pasteboardChangeCount()
+	clipboard.Write(clipboard.FmtText, []byte(s.normalizeLineEndings(text)))
 
-	// Small delay to ensure clipboard is updated
-	time.Sleep(50 * time.Millisecond)
+	if trusted, err := s.CheckAccessibilityPermission(); err == nil && !trusted {
+		return ErrNoAccessibility
+	}
+
+	// Make sure the clipboard is updated before pasting
+	if s.delays.Adaptive && countable {
+		waitForPasteboardChange(before)
+	} else {
+		time.Sleep(s.delays.ClipboardSettle)
+	}
+
+	if s.aborted.Load() {
+		s.restoreClipboard()
+		return ErrAborted
+	}
 
 	// Simulate Cmd+V using AppleScript (macOS only, but avoids CGO)
-	err := simulatePasteAppleScript()
-	if err != nil {
+	if err := s.pasteWithRetry(); err != nil {
+		if errors.Is(err, ErrAborted) {
+			// Nothing was pasted, so the dictated text needn't stay on the clipboard
+			s.restoreClipboard()
+		}
 		return err
 	}
 
-	// Small delay before restoring clipboard
-	time.Sleep(100 * time.Millisecond)
+	// There's no signal for when the target app has read the clipboard, so
+	// these stay fixed waits even in adaptive mode
+	time.Sleep(s.delays.PostPaste)
 
 	// Optionally restore original clipboard content
-	if s.preserveClipboard && len(s.originalClipboard) > 0 {
-		// Delay a bit more to ensure paste completed
-		time.Sleep(200 * time.Millisecond)
-		clipboard.Write(clipboard.FmtText, s.originalClipboard)
+	if s.preserveClipboard && !s.originalClipboard.empty() {
+		time.Sleep(s.delays.Restore)
+		s.restoreClipboard()
 	}
 
 	return nil
 }
 
+// restoreClipboard puts back the clipboard content saved by Inject, if any
+func (s *Service) restoreClipboard() {
+	if s.preserveClipboard && !s.originalClipboard.empty() {
+		s.originalClipboard.restore()
+	}
+}
+
+// waitForPasteboardChange polls until the pasteboard change count moves past
+// before, or adaptiveTimeout passes
+func waitForPasteboardChange(before int64) {
+	deadline := time.Now().Add(adaptiveTimeout)
+	for time.Now().Before(deadline) {
+		if count, _ := pasteboardChangeCount(); count != before {
+			return
+		}
+		time.Sleep(2 * time.Millisecond)
+	}
+	fmt.Printf("[Injection] Clipboard write not confirmed after %v, pasting anyway\n", adaptiveTimeout)
+}
+
+// clipboardSnapshot is the clipboard content saved across an injection: text
+// or, failing that, an image. Other pasteboard types (rich text, files) and
+// all but one representation of the content are not kept.
+type clipboardSnapshot struct {
+	format clipboard.Format
+	data   []byte
+}
+
+// snapshotClipboard saves the current clipboard text, or image if it has no text
+func snapshotClipboard() clipboardSnapshot {
+	return takeSnapshot(clipboard.Read)
+}
+
+// takeSnapshot saves the text read returns, or the image if there is no text
+func takeSnapshot(read func(clipboard.Format) []byte) clipboardSnapshot {
+	if text := read(clipboard.FmtText); len(text) > 0 {
+		return clipboardSnapshot{format: clipboard.FmtText, data: text}
+	}
+	if image := read(clipboard.FmtImage); len(image) > 0 {
+		return clipboardSnapshot{format: clipboard.FmtImage, data: image}
+	}
+	return clipboardSnapshot{}
+}
+
+func (c clipboardSnapshot) empty() bool {
+	return len(c.data) == 0
+}
+
+// restore puts the saved content back on the clipboard. Images come back as PNG.
+func (c clipboardSnapshot) restore() {
+	c.restoreTo(clipboard.Write)
+}
+
+// restoreTo writes the saved content with write
+func (c clipboardSnapshot) restoreTo(write func(clipboard.Format, []byte) <-chan struct{}) {
+	write(c.format, c.data)
+}
+
+// CheckAccessibilityPermission reports whether voxflow may send keystrokes
+// to other apps (System Settings → Privacy & Security → Accessibility)
+func (s *Service) CheckAccessibilityPermission() (bool, error) {
+	return accessibilityTrusted()
+}
+
+// Abort stops an in-progress Inject before its paste keystroke is sent
+func (s *Service) Abort() {
+	s.aborted.Store(true)
+}
+
+// pasteWithRetry sends the paste keystroke, retrying with a short backoff
+// only if posting it failed. Once it's sent, it is never sent again, since a
+// field that updates slowly would get the text twice; the field is checked
+// once instead. A missing focused field fails immediately.
+func (s *Service) pasteWithRetry() error {
+	if !hasFocusedElement() {
+		return ErrNoFocusedField
+	}
+
+	var err error
+	for attempt := 0; attempt <= s.pasteRetries; attempt++ {
+		if attempt > 0 {
+			fmt.Printf("[Injection] Paste failed (%v), retrying (%d/%d)\n", err, attempt, s.pasteRetries)
+			time.Sleep(time.Duration(attempt) * pasteRetryBackoff)
+			if s.aborted.Load() {
+				return ErrAborted
+			}
+		}
+		before, readable := focusedValue()
+		if err = simulatePasteAppleScript(); err != nil {
+			continue
+		}
+		if !readable {
+			// Nothing to check against, so trust the keystroke
+			return nil
+		}
+		time.Sleep(pasteVerifyDelay)
+		if after, ok := focusedValue(); !ok || after != before {
+			return nil
+		}
+		return fmt.Errorf("%w: %w", ErrPasteFailed, errPasteNotLanded)
+	}
+	return fmt.Errorf("%w after %d attempts: %w", ErrPasteFailed, s.pasteRetries+1, err)
+}
+
+// focusedValue returns the text of the focused UI element, and false if it
+// has none or doesn't expose it (many apps don't)
+func focusedValue() (string, bool) {
+	script := `
+		tell application "System Events"
+			set frontApp to first application process whose frontmost is true
+			try
+				set v to value of attribute "AXValue" of (value of attribute "AXFocusedUIElement" of frontApp)
+				if class of v is text then return "ok:" & v
+			end try
+			return "none"
+		end tell
+	`
+	out, err := exec.Command("osascript", "-e", script).Output()
+	if err != nil {
+		return "", false
+	}
+	return strings.CutPrefix(strings.TrimSuffix(string(out), "\n"), "ok:")
+}
+
+// typeText types text into the focused field one keystroke at a time,
+// leaving the clipboard alone. Line breaks are sent as Return and tabs as Tab.
+func (s *Service) typeText(text string) error {
+	if !hasFocusedElement() {
+		return ErrNoFocusedField
+	}
+
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+	for i, line := range strings.Split(text, "\n") {
+		if i > 0 {
+			if err := sendKeyCode(keyCodeReturn); err != nil {
+				return err
+			}
+		}
+		for j, field := range strings.Split(line, "\t") {
+			if j > 0 {
+				if err := sendKeyCode(keyCodeTab); err != nil {
+					return err
+				}
+			}
+			runes := []rune(field)
+			for start := 0; start < len(runes); start += typeChunkRunes {
+				if s.aborted.Load() {
+					return ErrAborted
+				}
+				end := min(start+typeChunkRunes, len(runes))
+				if err := keystroke(string(runes[start:end])); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Virtual key codes typed for characters keystroke can't send reliably
+const (
+	keyCodeReturn = 36
+	keyCodeTab    = 48
+)
+
+// maxEventUnits is the most UTF-16 code units macOS reliably delivers from
+// one keyboard event's Unicode string
+const maxEventUnits = 20
+
+// unicodeChunks splits text into UTF-16 runs of at most maxUnits code units,
+// never splitting a surrogate pair
+func unicodeChunks(text string, maxUnits int) [][]uint16 {
+	var chunks [][]uint16
+	var chunk []uint16
+	for _, r := range text {
+		units := utf16.AppendRune(nil, r)
+		if len(chunk)+len(units) > maxUnits {
+			chunks = append(chunks, chunk)
+			chunk = nil
+		}
+		chunk = append(chunk, units...)
+	}
+	if len(chunk) > 0 {
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
+
+// sendKeyCode presses the key with the given virtual key code
+func sendKeyCode(code int) error {
+	script := fmt.Sprintf(`tell application "System Events" to key code %d`, code)
+	if out, err := exec.Command("osascript", "-e", script).CombinedOutput(); err != nil {
+		return fmt.Errorf("typing failed: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// hasFocusedElement reports whether the frontmost app has a focused UI element.
+// If focus can't be determined it assumes there is one.
+func hasFocusedElement() bool {
+	script := `
+		tell application "System Events"
+			set frontApp to first application process whose frontmost is true
+			try
+				if value of attribute "AXFocusedUIElement" of frontApp is missing value then return "none"
+			end try
+			return "ok"
+		end tell
+	`
+	out, err := exec.Command("osascript", "-e", script).Output()
+	if err != nil {
+		return true
+	}
+	return strings.TrimSpace(string(out)) != "none"
+}
+
+// FrontmostApp returns the name of the application with keyboard focus, or ""
+// if none is focused or it can't be determined
+func FrontmostApp() string {
+	script := `
+		tell application "System Events"
+			try
+				return name of first application process whose frontmost is true
+			end try
+			return ""
+		end tell
+	`
+	out, err := exec.Command("osascript", "-e", script).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
 // simulatePasteAppleScript uses AppleScript to simulate Cmd+V
 func simulatePasteAppleScript() error {
 	script := `
@@ -66,11 +456,23 @@ func simulatePasteAppleScript() error {
 		end tell
 	`
 	cmd := exec.Command("osascript", "-e", script)
-	return cmd.Run()
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
 }
 
 // CopyToClipboard just copies text to clipboard without pasting
 func (s *Service) CopyToClipboard(text string) error {
-	clipboard.Write(clipboard.FmtText, []byte(text))
+	clipboard.Write(clipboard.FmtText, []byte(s.normalizeLineEndings(text)))
 	return nil
 }
+
+// ClearClipboardIfMatches empties the clipboard if it still holds text,
+// leaving anything the user copied since untouched
+func (s *Service) ClearClipboardIfMatches(text string) {
+	current := clipboard.Read(clipboard.FmtText)
+	if string(current) == s.normalizeLineEndings(text) {
+		clipboard.Write(clipboard.FmtText, []byte{})
+	}
+}
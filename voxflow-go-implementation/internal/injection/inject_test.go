@@ -0,0 +1,93 @@
+package injection
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+	"unicode/utf16"
+
+	"golang.design/x/clipboard"
+)
+
+// fakeClipboard holds one value per format, like the system clipboard
+type fakeClipboard map[clipboard.Format][]byte
+
+func (f fakeClipboard) read(format clipboard.Format) []byte {
+	return f[format]
+}
+
+func (f fakeClipboard) write(format clipboard.Format, data []byte) <-chan struct{} {
+	clear(f)
+	f[format] = data
+	return nil
+}
+
+func TestClipboardSnapshot(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\nfake")
+
+	tests := []struct {
+		name       string
+		clipboard  fakeClipboard
+		wantEmpty  bool
+		wantFormat clipboard.Format
+		wantData   []byte
+	}{
+		{"text", fakeClipboard{clipboard.FmtText: []byte("copied text")}, false, clipboard.FmtText, []byte("copied text")},
+		{"image", fakeClipboard{clipboard.FmtImage: png}, false, clipboard.FmtImage, png},
+		{"text wins over image", fakeClipboard{clipboard.FmtText: []byte("caption"), clipboard.FmtImage: png}, false, clipboard.FmtText, []byte("caption")},
+		{"empty text falls back to image", fakeClipboard{clipboard.FmtText: {}, clipboard.FmtImage: png}, false, clipboard.FmtImage, png},
+		{"nothing copied", fakeClipboard{}, true, 0, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snapshot := takeSnapshot(tt.clipboard.read)
+			if snapshot.empty() != tt.wantEmpty {
+				t.Fatalf("empty() = %v, want %v", snapshot.empty(), tt.wantEmpty)
+			}
+			if tt.wantEmpty {
+				return
+			}
+
+			// The dictated text replaces the clipboard, then the snapshot comes back
+			tt.clipboard.write(clipboard.FmtText, []byte("dictated text"))
+			snapshot.restoreTo(tt.clipboard.write)
+			if got := tt.clipboard.read(tt.wantFormat); !bytes.Equal(got, tt.wantData) {
+				t.Errorf("restored %q, want %q", got, tt.wantData)
+			}
+			if tt.wantFormat != clipboard.FmtText && len(tt.clipboard.read(clipboard.FmtText)) > 0 {
+				t.Errorf("dictated text left on the clipboard after restoring an image")
+			}
+		})
+	}
+}
+
+func TestUnicodeChunks(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		maxUnits int
+		want     []string
+	}{
+		{"empty", "", 20, nil},
+		{"fits in one", "hello", 20, []string{"hello"}},
+		{"splits at the limit", "abcdefg", 3, []string{"abc", "def", "g"}},
+		{"non-ASCII", "• café", 20, []string{"• café"}},
+		{"keeps surrogate pairs together", "ab😀c", 3, []string{"ab", "😀c"}},
+		{"emoji only", "😀😀", 2, []string{"😀", "😀"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chunks := unicodeChunks(tt.text, tt.maxUnits)
+			var got []string
+			for _, chunk := range chunks {
+				if len(chunk) > tt.maxUnits {
+					t.Errorf("chunk of %d units exceeds %d", len(chunk), tt.maxUnits)
+				}
+				got = append(got, string(utf16.Decode(chunk)))
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("unicodeChunks(%q, %d) = %q, want %q", tt.text, tt.maxUnits, got, tt.want)
+			}
+		})
+	}
+}
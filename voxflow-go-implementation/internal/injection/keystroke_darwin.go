@@ -0,0 +1,47 @@
+//go:build darwin
+
+package injection
+
+/*
+#cgo LDFLAGS: -framework ApplicationServices
+
+#include <ApplicationServices/ApplicationServices.h>
+
+// typeUnicode posts a key down and up carrying chars as their text. Modifier
+// flags are cleared so a still-held hotkey modifier can't turn it into a shortcut.
+int typeUnicode(const UniChar *chars, int length) {
+    CGEventRef down = CGEventCreateKeyboardEvent(NULL, 0, true);
+    CGEventRef up = CGEventCreateKeyboardEvent(NULL, 0, false);
+    if (down == NULL || up == NULL) {
+        if (down != NULL) CFRelease(down);
+        if (up != NULL) CFRelease(up);
+        return 0;
+    }
+    CGEventSetFlags(down, 0);
+    CGEventSetFlags(up, 0);
+    CGEventKeyboardSetUnicodeString(down, length, chars);
+    CGEventKeyboardSetUnicodeString(up, length, chars);
+    CGEventPost(kCGHIDEventTap, down);
+    CGEventPost(kCGHIDEventTap, up);
+    CFRelease(down);
+    CFRelease(up);
+    return 1;
+}
+*/
+import "C"
+
+import (
+	"errors"
+	"unsafe"
+)
+
+// keystroke types text as Unicode keyboard events, so characters the keyboard
+// layout has no key for (e.g. "•" or "é") come through too
+func keystroke(text string) error {
+	for _, chunk := range unicodeChunks(text, maxEventUnits) {
+		if C.typeUnicode((*C.UniChar)(unsafe.Pointer(&chunk[0])), C.int(len(chunk))) == 0 {
+			return errors.New("typing failed: could not create keyboard event")
+		}
+	}
+	return nil
+}
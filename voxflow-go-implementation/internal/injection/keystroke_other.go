@@ -0,0 +1,10 @@
+//go:build !darwin
+
+package injection
+
+import "errors"
+
+// keystroke is only implemented on macOS
+func keystroke(text string) error {
+	return errors.New("typing is not supported on this platform")
+}
@@ -0,0 +1,21 @@
+//go:build darwin
+
+package injection
+
+/*
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework AppKit
+
+#import <AppKit/AppKit.h>
+
+long pasteboardChangeCount(void) {
+    return (long)[[NSPasteboard generalPasteboard] changeCount];
+}
+*/
+import "C"
+
+// pasteboardChangeCount returns the general pasteboard's change count, which
+// macOS bumps on every write. ok is false where it isn't available.
+func pasteboardChangeCount() (count int64, ok bool) {
+	return int64(C.pasteboardChangeCount()), true
+}
@@ -0,0 +1,8 @@
+//go:build !darwin
+
+package injection
+
+// pasteboardChangeCount is only implemented on macOS
+func pasteboardChangeCount() (count int64, ok bool) {
+	return 0, false
+}
@@ -0,0 +1,12 @@
+// Package keychain stores secrets in the macOS login keychain. It calls the
+// Security framework rather than the `security` tool, so secrets never show
+// up in process arguments.
+package keychain
+
+import "errors"
+
+// service is the keychain item service name shared by all voxflow secrets
+const service = "voxflow"
+
+// ErrNotFound is returned by Get when the keychain has no such secret
+var ErrNotFound = errors.New("secret not found in keychain")
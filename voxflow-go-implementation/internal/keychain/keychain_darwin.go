@@ -0,0 +1,128 @@
+//go:build darwin
+
+package keychain
+
+/*
+#cgo LDFLAGS: -framework Security -framework CoreFoundation
+
+#include <stdlib.h>
+#include <string.h>
+#include <Security/Security.h>
+
+// newQuery returns a generic password query for service and account. The
+// caller releases it.
+static CFMutableDictionaryRef newQuery(const char *service, const char *account) {
+    CFMutableDictionaryRef query = CFDictionaryCreateMutable(NULL, 0,
+        &kCFTypeDictionaryKeyCallBacks, &kCFTypeDictionaryValueCallBacks);
+    CFStringRef svc = CFStringCreateWithCString(NULL, service, kCFStringEncodingUTF8);
+    CFStringRef acct = CFStringCreateWithCString(NULL, account, kCFStringEncodingUTF8);
+    CFDictionarySetValue(query, kSecClass, kSecClassGenericPassword);
+    CFDictionarySetValue(query, kSecAttrService, svc);
+    CFDictionarySetValue(query, kSecAttrAccount, acct);
+    CFRelease(svc);
+    CFRelease(acct);
+    return query;
+}
+
+// keychainGet copies the secret into a malloc'd buffer the caller frees
+static OSStatus keychainGet(const char *service, const char *account, void **out, long *outLen) {
+    CFMutableDictionaryRef query = newQuery(service, account);
+    CFDictionarySetValue(query, kSecReturnData, kCFBooleanTrue);
+    CFDictionarySetValue(query, kSecMatchLimit, kSecMatchLimitOne);
+
+    CFTypeRef result = NULL;
+    OSStatus status = SecItemCopyMatching(query, &result);
+    CFRelease(query);
+    if (status != errSecSuccess) {
+        return status;
+    }
+
+    CFDataRef data = (CFDataRef)result;
+    *outLen = CFDataGetLength(data);
+    *out = malloc(*outLen > 0 ? *outLen : 1);
+    memcpy(*out, CFDataGetBytePtr(data), *outLen);
+    CFRelease(result);
+    return errSecSuccess;
+}
+
+// keychainSet updates the secret, adding the item if there is none yet
+static OSStatus keychainSet(const char *service, const char *account, const void *secret, long secretLen) {
+    CFMutableDictionaryRef query = newQuery(service, account);
+    CFDataRef data = CFDataCreate(NULL, secret, secretLen);
+
+    CFMutableDictionaryRef update = CFDictionaryCreateMutable(NULL, 0,
+        &kCFTypeDictionaryKeyCallBacks, &kCFTypeDictionaryValueCallBacks);
+    CFDictionarySetValue(update, kSecValueData, data);
+    OSStatus status = SecItemUpdate(query, update);
+    CFRelease(update);
+
+    if (status == errSecItemNotFound) {
+        CFDictionarySetValue(query, kSecValueData, data);
+        status = SecItemAdd(query, NULL);
+    }
+    CFRelease(data);
+    CFRelease(query);
+    return status;
+}
+
+static OSStatus keychainDelete(const char *service, const char *account) {
+    CFMutableDictionaryRef query = newQuery(service, account);
+    OSStatus status = SecItemDelete(query);
+    CFRelease(query);
+    return status;
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// Get returns the secret stored for account. Items written by older versions
+// through the `security` tool still read, after macOS asks once whether
+// voxflow may use them.
+func Get(account string) (string, error) {
+	cService, cAccount := C.CString(service), C.CString(account)
+	defer C.free(unsafe.Pointer(cService))
+	defer C.free(unsafe.Pointer(cAccount))
+
+	var out unsafe.Pointer
+	var outLen C.long
+	status := C.keychainGet(cService, cAccount, &out, &outLen)
+	if status == C.errSecItemNotFound {
+		return "", ErrNotFound
+	}
+	if status != C.errSecSuccess {
+		return "", fmt.Errorf("failed to read %s from keychain (OSStatus %d)", account, int(status))
+	}
+	defer C.free(out)
+	return string(C.GoBytes(out, C.int(outLen))), nil
+}
+
+// Set stores secret for account, replacing any existing value
+func Set(account, secret string) error {
+	cService, cAccount := C.CString(service), C.CString(account)
+	defer C.free(unsafe.Pointer(cService))
+	defer C.free(unsafe.Pointer(cAccount))
+	cSecret := C.CBytes([]byte(secret))
+	defer C.free(cSecret)
+
+	if status := C.keychainSet(cService, cAccount, cSecret, C.long(len(secret))); status != C.errSecSuccess {
+		return fmt.Errorf("failed to store %s in keychain (OSStatus %d)", account, int(status))
+	}
+	return nil
+}
+
+// Delete removes the secret stored for account. A missing secret is not an error.
+func Delete(account string) error {
+	cService, cAccount := C.CString(service), C.CString(account)
+	defer C.free(unsafe.Pointer(cService))
+	defer C.free(unsafe.Pointer(cAccount))
+
+	status := C.keychainDelete(cService, cAccount)
+	if status != C.errSecSuccess && status != C.errSecItemNotFound {
+		return fmt.Errorf("failed to delete %s from keychain (OSStatus %d)", account, int(status))
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+//go:build !darwin
+
+package keychain
+
+import "errors"
+
+// errUnsupported is returned where there is no macOS keychain
+var errUnsupported = errors.New("the keychain is only available on macOS")
+
+// Get is unavailable off macOS
+func Get(account string) (string, error) {
+	return "", errUnsupported
+}
+
+// Set is unavailable off macOS
+func Set(account, secret string) error {
+	return errUnsupported
+}
+
+// Delete is unavailable off macOS
+func Delete(account string) error {
+	return errUnsupported
+}
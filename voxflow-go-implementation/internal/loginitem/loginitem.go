@@ -0,0 +1,115 @@
+// Package loginitem starts voxflow when the user logs in, via a LaunchAgent
+// plist in ~/Library/LaunchAgents
+package loginitem
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// label identifies the LaunchAgent, and names its plist file
+const label = "com.voxflow.launch-at-login"
+
+// plistPath returns where the LaunchAgent plist lives
+func plistPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, "Library", "LaunchAgents", label+".plist"), nil
+}
+
+// AppPath returns the .app bundle voxflow runs from, or the bare executable
+// when it isn't in one (e.g. `go run`)
+func AppPath() (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+		exe = resolved
+	}
+	if i := strings.Index(exe, ".app/Contents/MacOS/"); i >= 0 {
+		return exe[:i+len(".app")], nil
+	}
+	return exe, nil
+}
+
+// IsEnabled reports whether voxflow is set to launch at login
+func IsEnabled() bool {
+	path, err := plistPath()
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(path)
+	return err == nil
+}
+
+// Enable sets voxflow to launch at login from where it runs now. Calling it
+// again after the app moved (e.g. an update installed elsewhere) points the
+// login item at the new location.
+func Enable() error {
+	appPath, err := AppPath()
+	if err != nil {
+		return fmt.Errorf("failed to locate app: %w", err)
+	}
+	path, err := plistPath()
+	if err != nil {
+		return err
+	}
+
+	plist := launchAgentPlist(appPath)
+	if existing, err := os.ReadFile(path); err == nil && bytes.Equal(existing, plist) {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create LaunchAgents directory: %w", err)
+	}
+	if err := os.WriteFile(path, plist, 0644); err != nil {
+		return fmt.Errorf("failed to write login item: %w", err)
+	}
+	fmt.Printf("[LoginItem] Launching %s at login\n", appPath)
+	return nil
+}
+
+// Disable stops voxflow from launching at login
+func Disable() error {
+	path, err := plistPath()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove login item: %w", err)
+	}
+	return nil
+}
+
+// launchAgentPlist returns a LaunchAgent that opens appPath once at login.
+// Bundles go through `open` so they launch like from Finder. launchd reads it
+// at the next login, so nothing is loaded now (that would start a second copy).
+func launchAgentPlist(appPath string) []byte {
+	args := []string{appPath}
+	if strings.HasSuffix(appPath, ".app") {
+		args = []string{"/usr/bin/open", "-a", appPath}
+	}
+
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	b.WriteString(`<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">` + "\n")
+	b.WriteString("<plist version=\"1.0\">\n<dict>\n")
+	b.WriteString("\t<key>Label</key>\n\t<string>" + label + "</string>\n")
+	b.WriteString("\t<key>ProgramArguments</key>\n\t<array>\n")
+	for _, arg := range args {
+		b.WriteString("\t\t<string>")
+		xml.EscapeText(&b, []byte(arg))
+		b.WriteString("</string>\n")
+	}
+	b.WriteString("\t</array>\n")
+	b.WriteString("\t<key>RunAtLoad</key>\n\t<true/>\n")
+	b.WriteString("</dict>\n</plist>\n")
+	return b.Bytes()
+}
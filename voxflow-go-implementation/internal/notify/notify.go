@@ -0,0 +1,40 @@
+// Package notify posts macOS Notification Center notifications
+package notify
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"unicode/utf8"
+)
+
+// previewChars is how much of a transcript a notification shows
+const previewChars = 50
+
+// Post shows a notification with title and message. The text is passed as
+// arguments rather than spliced into the script, so it needs no escaping.
+func Post(title, message string) error {
+	script := `on run argv
+		display notification (item 2 of argv) with title (item 1 of argv)
+	end run`
+	out, err := exec.Command("osascript", "-e", script, title, message).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to post notification: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// Summary returns the start of text on one line and its word count, e.g.
+// "Let's meet on Thursday to go over the launch pla… (42 words)"
+func Summary(text string) string {
+	words := len(strings.Fields(text))
+	preview := strings.Join(strings.Fields(text), " ")
+	if utf8.RuneCountInString(preview) > previewChars {
+		preview = string([]rune(preview)[:previewChars]) + "…"
+	}
+	unit := "words"
+	if words == 1 {
+		unit = "word"
+	}
+	return fmt.Sprintf("%s (%d %s)", preview, words, unit)
+}
@@ -0,0 +1,214 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"voxflow/internal/gemini"
+)
+
+// DefaultBaseURL is Ollama's OpenAI-compatible endpoint
+const DefaultBaseURL = "http://localhost:11434/v1"
+
+// Client refines text with any OpenAI-compatible chat completions API,
+// e.g. OpenAI itself, Ollama or LM Studio. It uses the same prompts and
+// {"text","refused"} output contract as the Gemini client.
+type Client struct {
+	gemini.UsageCounter
+	baseURL     string
+	model       string
+	apiKey      string // Optional; local servers usually don't need one
+	httpClient  *http.Client
+	toneTagging bool              // Ask refinement to also classify the tone
+	customModes map[string]string // User-defined mode name -> prompt
+}
+
+// NewClient creates a client for the chat completions API under baseURL
+func NewClient(baseURL, model, apiKey string) *Client {
+	c := &Client{
+		httpClient: &http.Client{
+			Timeout: 60 * time.Second, // Local models can be slow to respond
+		},
+	}
+	c.SetEndpoint(baseURL, model, apiKey)
+	return c
+}
+
+// SetEndpoint sets the API base URL ("" = DefaultBaseURL), model and API key
+func (c *Client) SetEndpoint(baseURL, model, apiKey string) {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	c.baseURL = strings.TrimRight(baseURL, "/")
+	c.model = model
+	c.apiKey = apiKey
+}
+
+// SetToneTagging sets whether refinement also classifies the speaker's tone
+func (c *Client) SetToneTagging(enabled bool) {
+	c.toneTagging = enabled
+}
+
+// SetCustomModes sets the prompts of user-defined modes, keyed by mode name
+func (c *Client) SetCustomModes(prompts map[string]string) {
+	c.customModes = prompts
+}
+
+// chatMessage is one message of a chat completions request or reply
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// chatRequest is a chat completions request
+type chatRequest struct {
+	Model       string        `json:"model"`
+	Messages    []chatMessage `json:"messages"`
+	Temperature float64       `json:"temperature"`
+	MaxTokens   int           `json:"max_tokens,omitempty"`
+}
+
+// chatResponse is the subset of a chat completions reply that we use
+type chatResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+	Usage *struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+		TotalTokens      int `json:"total_tokens"`
+	} `json:"usage,omitempty"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// RefineText sends raw transcription to the model for refinement
+func (c *Client) RefineText(rawText string, mode string) (string, error) {
+	text, _, _, err := c.RefineTextWithTone(context.Background(), rawText, mode)
+	return text, err
+}
+
+// RefineTextWithTone refines the transcription and, if tone tagging is
+// enabled, also returns the classified tone ("" if unavailable) and the
+// tokens the server reported for the request. Cancelling ctx aborts the request.
+func (c *Client) RefineTextWithTone(ctx context.Context, rawText string, mode string) (string, string, gemini.Usage, error) {
+	// Lengths only: the log ends up on disk and in diagnostics
+	fmt.Printf("[OpenAI] Refining text with %s (%d chars)\n", c.model, len(rawText))
+
+	// The refinement instructions become the system message
+	result, usage, err := c.complete(ctx, []chatMessage{
+		{Role: "system", Content: gemini.SystemPrompt(mode, c.customModes, c.toneTagging)},
+		{Role: "user", Content: "Transcription to refine:\n" + rawText},
+	})
+	if err != nil {
+		return "", "", usage, err
+	}
+
+	fmt.Printf("[OpenAI] Raw output (%d chars)\n", len(result))
+	text, tone := gemini.ParseRefineOutput(result, rawText)
+	return text, tone, usage, nil
+}
+
+// RetryWithInstruction re-processes text with a custom instruction
+func (c *Client) RetryWithInstruction(text string, instruction string) (string, error) {
+	result, _, err := c.complete(context.Background(), []chatMessage{
+		{Role: "user", Content: gemini.InstructionPrompt(text, instruction)},
+	})
+	return result, err
+}
+
+// IsLocal reports whether the server runs on this machine, so its tokens cost nothing
+func (c *Client) IsLocal() bool {
+	u, err := url.Parse(c.baseURL)
+	if err != nil {
+		return false
+	}
+	switch u.Hostname() {
+	case "localhost", "127.0.0.1", "::1":
+		return true
+	}
+	return false
+}
+
+// complete sends a chat completions request and returns the reply text with
+// the tokens the server reported (zero if it didn't report any). Unreachable
+// servers, rate limits and server errors wrap gemini.ErrUnavailable.
+func (c *Client) complete(ctx context.Context, messages []chatMessage) (string, gemini.Usage, error) {
+	var usage gemini.Usage
+	if c.model == "" {
+		return "", usage, fmt.Errorf("model not set")
+	}
+
+	reqBody, err := json.Marshal(chatRequest{
+		Model:       c.model,
+		Messages:    messages,
+		Temperature: 0.3, // Lower temperature for more consistent output
+		MaxTokens:   2048,
+	})
+	if err != nil {
+		return "", usage, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/chat/completions", bytes.NewReader(reqBody))
+	if err != nil {
+		return "", usage, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if c.apiKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	// Transport errors usually mean a local server isn't running
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", usage, ctx.Err()
+		}
+		return "", usage, fmt.Errorf("%w: failed to send request: %v", gemini.ErrUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", usage, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var chatResp chatResponse
+	parseErr := json.Unmarshal(respBody, &chatResp)
+
+	if resp.StatusCode != http.StatusOK {
+		message := http.StatusText(resp.StatusCode)
+		if parseErr == nil && chatResp.Error != nil {
+			message = chatResp.Error.Message
+		}
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			return "", usage, fmt.Errorf("%w: API error: %s (code: %d)", gemini.ErrUnavailable, message, resp.StatusCode)
+		}
+		return "", usage, fmt.Errorf("API error: %s (code: %d)", message, resp.StatusCode)
+	}
+
+	if parseErr != nil {
+		return "", usage, fmt.Errorf("failed to parse response: %w", parseErr)
+	}
+	if u := chatResp.Usage; u != nil {
+		usage = gemini.Usage{
+			PromptTokens: u.PromptTokens,
+			OutputTokens: u.CompletionTokens,
+			TotalTokens:  u.TotalTokens,
+		}
+		c.AddUsage(usage)
+	}
+	if len(chatResp.Choices) == 0 {
+		return "", usage, fmt.Errorf("no response generated")
+	}
+
+	return chatResp.Choices[0].Message.Content, usage, nil
+}
@@ -0,0 +1,200 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// StatusFunc returns a snapshot of the app status for GET /status
+type StatusFunc func() map[string]interface{}
+
+// Event is a single message streamed to /events subscribers
+type Event struct {
+	Event     string      `json:"event"`
+	Data      interface{} `json:"data"`
+	Timestamp time.Time   `json:"timestamp"`
+}
+
+// Server is a local-only HTTP server that exposes app status to external tools
+type Server struct {
+	port       int
+	status     StatusFunc
+	httpServer *http.Server
+	mu         sync.Mutex
+	clients    map[chan Event]struct{}
+}
+
+// NewServer creates a new local server bound to 127.0.0.1 on the given port
+func NewServer(port int, status StatusFunc) *Server {
+	return &Server{
+		port:    port,
+		status:  status,
+		clients: make(map[chan Event]struct{}),
+	}
+}
+
+// Start begins serving in the background
+func (s *Server) Start() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.httpServer != nil {
+		return fmt.Errorf("server already running")
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", s.port))
+	if err != nil {
+		return fmt.Errorf("failed to listen on port %d: %w", s.port, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/status", s.handleStatus)
+	mux.HandleFunc("/events", s.handleEvents)
+
+	s.httpServer = &http.Server{Handler: localOnly(mux)}
+	go func() {
+		if err := s.httpServer.Serve(listener); err != nil && err != http.ErrServerClosed {
+			fmt.Printf("[Server] Stopped with error: %v\n", err)
+		}
+	}()
+
+	fmt.Printf("[Server] Listening on http://127.0.0.1:%d\n", s.port)
+	return nil
+}
+
+// Stop shuts down the server and disconnects all event subscribers
+func (s *Server) Stop() error {
+	s.mu.Lock()
+	httpServer := s.httpServer
+	s.httpServer = nil
+	for ch := range s.clients {
+		close(ch)
+		delete(s.clients, ch)
+	}
+	s.mu.Unlock()
+
+	if httpServer == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	return httpServer.Shutdown(ctx)
+}
+
+// IsRunning returns whether the server is currently serving
+func (s *Server) IsRunning() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.httpServer != nil
+}
+
+// Publish sends an event to all connected /events subscribers.
+// Slow subscribers drop events rather than blocking the caller.
+func (s *Server) Publish(event string, data interface{}) {
+	ev := Event{Event: event, Data: data, Timestamp: time.Now()}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for ch := range s.clients {
+		select {
+		case ch <- ev:
+		default:
+		}
+	}
+}
+
+// localOnly rejects requests that didn't come from a local tool. Checking Host
+// defeats DNS rebinding, where a web page points its own domain at 127.0.0.1;
+// checking Origin stops pages on other origins reading the event stream.
+func localOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !isLocalHost(r.Host) {
+			http.Error(w, "forbidden host", http.StatusForbidden)
+			return
+		}
+		if origin := r.Header.Get("Origin"); origin != "" {
+			u, err := url.Parse(origin)
+			if err != nil || u.Scheme != "http" || u.Host != r.Host {
+				http.Error(w, "cross-origin requests are not allowed", http.StatusForbidden)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isLocalHost reports whether a Host header names this machine's loopback
+func isLocalHost(host string) bool {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return host == "127.0.0.1" || host == "localhost"
+}
+
+// handleStatus serves GET /status
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.status())
+}
+
+// handleEvents serves GET /events as a Server-Sent Events stream
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	ch := make(chan Event, 16)
+	s.mu.Lock()
+	s.clients[ch] = struct{}{}
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		if _, ok := s.clients[ch]; ok {
+			delete(s.clients, ch)
+			close(ch)
+		}
+		s.mu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case ev, ok := <-ch:
+			if !ok {
+				return
+			}
+			payload, err := json.Marshal(ev)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", ev.Event, payload)
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLocalOnly(t *testing.T) {
+	handler := localOnly(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name   string
+		host   string
+		origin string
+		want   int
+	}{
+		{"loopback IP", "127.0.0.1:7777", "", http.StatusOK},
+		{"localhost", "localhost:7777", "", http.StatusOK},
+		{"no port", "localhost", "", http.StatusOK},
+		{"same origin", "127.0.0.1:7777", "http://127.0.0.1:7777", http.StatusOK},
+		{"rebound domain", "evil.example:7777", "", http.StatusForbidden},
+		{"rebound domain with its own origin", "evil.example:7777", "http://evil.example:7777", http.StatusForbidden},
+		{"cross origin", "127.0.0.1:7777", "http://evil.example", http.StatusForbidden},
+		{"other local port", "127.0.0.1:7777", "http://localhost:3000", http.StatusForbidden},
+		{"opaque origin", "127.0.0.1:7777", "null", http.StatusForbidden},
+		{"lookalike host", "localhost.evil.example", "", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/status", nil)
+			req.Host = tt.host
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
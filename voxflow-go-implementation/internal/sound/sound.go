@@ -0,0 +1,122 @@
+// Package sound plays short audio cues when recording starts and stops
+package sound
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Cue is an audio cue
+type Cue int
+
+const (
+	CueStart Cue = iota // Rising two-note chirp
+	CueStop             // Falling two-note chirp
+)
+
+// cueSampleRate is the sample rate of the generated cue WAVs
+const cueSampleRate = 44100
+
+// noteDuration is the length of each of a cue's two notes
+const noteDuration = 60 * time.Millisecond
+
+// CueDuration is how long a cue plays
+const CueDuration = 2 * noteDuration
+
+// playerLatency is roughly how long afplay takes to start making sound
+const playerLatency = 150 * time.Millisecond
+
+// Audible is how long after Play a cue may still be heard, e.g. to keep it
+// out of a recording that starts at the same time
+const Audible = playerLatency + CueDuration
+
+// cueNotes are the frequencies (Hz) of each cue's two notes
+var cueNotes = map[Cue][2]float64{
+	CueStart: {880, 1320},
+	CueStop:  {1320, 880},
+}
+
+var (
+	filesMu sync.Mutex
+	files   = map[Cue]string{}
+)
+
+// Play plays cue at volume (0-1) without waiting for it to finish
+func Play(cue Cue, volume float64) error {
+	path, err := cueFile(cue)
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command("afplay", "-v", strconv.FormatFloat(volume, 'f', 2, 64), path)
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to play sound: %w", err)
+	}
+	go cmd.Wait()
+	return nil
+}
+
+// cueFile returns the WAV file for cue, writing it to the temp directory the
+// first time. The tones are generated, so no assets need shipping.
+func cueFile(cue Cue) (string, error) {
+	filesMu.Lock()
+	defer filesMu.Unlock()
+	if path, ok := files[cue]; ok {
+		return path, nil
+	}
+	notes, ok := cueNotes[cue]
+	if !ok {
+		return "", fmt.Errorf("unknown sound cue: %d", cue)
+	}
+
+	path := filepath.Join(os.TempDir(), fmt.Sprintf("voxflow_cue_%d.wav", cue))
+	if err := os.WriteFile(path, chirpWav(notes), 0644); err != nil {
+		return "", fmt.Errorf("failed to write sound cue: %w", err)
+	}
+	files[cue] = path
+	return path, nil
+}
+
+// chirpWav returns a 16-bit mono WAV of the two notes back to back, each
+// faded in and out so they don't click
+func chirpWav(notes [2]float64) []byte {
+	perNote := int(noteDuration.Seconds() * cueSampleRate)
+	fade := perNote / 6
+	samples := make([]int16, 0, 2*perNote)
+	for _, freq := range notes {
+		for i := 0; i < perNote; i++ {
+			envelope := 1.0
+			if i < fade {
+				envelope = float64(i) / float64(fade)
+			} else if i > perNote-fade {
+				envelope = float64(perNote-i) / float64(fade)
+			}
+			t := float64(i) / cueSampleRate
+			samples = append(samples, int16(0.5*envelope*math.MaxInt16*math.Sin(2*math.Pi*freq*t)))
+		}
+	}
+
+	dataSize := uint32(len(samples) * 2)
+	var b bytes.Buffer
+	b.WriteString("RIFF")
+	binary.Write(&b, binary.LittleEndian, 36+dataSize)
+	b.WriteString("WAVEfmt ")
+	binary.Write(&b, binary.LittleEndian, uint32(16))              // fmt chunk size
+	binary.Write(&b, binary.LittleEndian, uint16(1))               // PCM
+	binary.Write(&b, binary.LittleEndian, uint16(1))               // Mono
+	binary.Write(&b, binary.LittleEndian, uint32(cueSampleRate))   // Sample rate
+	binary.Write(&b, binary.LittleEndian, uint32(cueSampleRate*2)) // Byte rate
+	binary.Write(&b, binary.LittleEndian, uint16(2))               // Block align
+	binary.Write(&b, binary.LittleEndian, uint16(16))              // Bits per sample
+	b.WriteString("data")
+	binary.Write(&b, binary.LittleEndian, dataSize)
+	binary.Write(&b, binary.LittleEndian, samples)
+	return b.Bytes()
+}
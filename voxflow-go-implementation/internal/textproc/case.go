@@ -0,0 +1,158 @@
+package textproc
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Case styles
+const (
+	CaseAsIs     = "asis"
+	CaseSentence = "sentence"
+	CaseTitle    = "title"
+	CaseUpper    = "upper"
+	CaseLower    = "lower"
+)
+
+// wordPattern matches a word, including contractions like "don't"
+var wordPattern = regexp.MustCompile(`[\p{L}\p{N}]+(?:['’][\p{L}]+)*`)
+
+// titleSmallWords stay lowercase in title case unless first or last
+var titleSmallWords = map[string]bool{
+	"a": true, "an": true, "and": true, "as": true, "at": true, "but": true,
+	"by": true, "for": true, "from": true, "in": true, "into": true, "nor": true,
+	"of": true, "on": true, "or": true, "per": true, "so": true, "the": true,
+	"to": true, "up": true, "via": true, "vs": true, "with": true, "yet": true,
+}
+
+// ValidateCaseStyle returns an error if style is not a known case style
+func ValidateCaseStyle(style string) error {
+	switch style {
+	case CaseAsIs, CaseSentence, CaseTitle, CaseUpper, CaseLower:
+		return nil
+	}
+	return fmt.Errorf("unknown case style: %s", style)
+}
+
+// ApplyCaseStyle recases text. Unknown styles and "asis" return text unchanged.
+func ApplyCaseStyle(text, style string) string {
+	switch style {
+	case CaseUpper:
+		return strings.ToUpper(text)
+	case CaseLower:
+		return strings.ToLower(text)
+	case CaseSentence:
+		return recaseWords(text, sentenceCaseWord)
+	case CaseTitle:
+		return recaseWords(text, titleCaseWord)
+	default:
+		return text
+	}
+}
+
+// wordContext describes where a word sits in the text
+type wordContext struct {
+	sentenceStart bool // First word, or follows . ! ? : or a newline
+	last          bool // Last word of the text
+}
+
+// recaseWords rewrites each word with fn, leaving punctuation and spacing intact
+func recaseWords(text string, fn func(word string, ctx wordContext) string) string {
+	matches := wordPattern.FindAllStringIndex(text, -1)
+	if len(matches) == 0 {
+		return text
+	}
+
+	var b strings.Builder
+	b.Grow(len(text))
+	prev := 0
+	for i, m := range matches {
+		gap := text[prev:m[0]]
+		b.WriteString(gap)
+		ctx := wordContext{
+			sentenceStart: i == 0 || strings.ContainsAny(gap, ".!?:\n"),
+			last:          i == len(matches)-1,
+		}
+		b.WriteString(fn(text[m[0]:m[1]], ctx))
+		prev = m[1]
+	}
+	b.WriteString(text[prev:])
+	return b.String()
+}
+
+// sentenceCaseWord capitalizes sentence starts and lowercases everything else,
+// keeping acronyms and the pronoun "I"
+func sentenceCaseWord(word string, ctx wordContext) string {
+	if isAcronym(word) {
+		return word
+	}
+	if isPronounI(word) {
+		return "I" + word[1:]
+	}
+	lower := strings.ToLower(word)
+	if ctx.sentenceStart {
+		return capitalize(lower)
+	}
+	return lower
+}
+
+// titleCaseWord capitalizes every word except small words in the middle of a
+// sentence, keeping acronyms and mixed-case words like "iPhone"
+func titleCaseWord(word string, ctx wordContext) string {
+	if isAcronym(word) || hasInnerUpper(word) {
+		return word
+	}
+	if isPronounI(word) {
+		return "I" + word[1:]
+	}
+	lower := strings.ToLower(word)
+	if titleSmallWords[lower] && !ctx.sentenceStart && !ctx.last {
+		return lower
+	}
+	return capitalize(lower)
+}
+
+// capitalize uppercases the first rune of word
+func capitalize(word string) string {
+	r, size := utf8.DecodeRuneInString(word)
+	if r == utf8.RuneError {
+		return word
+	}
+	return string(unicode.ToTitle(r)) + word[size:]
+}
+
+// isAcronym reports whether word is two or more letters, all uppercase
+func isAcronym(word string) bool {
+	letters := 0
+	for _, r := range word {
+		if unicode.IsLetter(r) {
+			if !unicode.IsUpper(r) {
+				return false
+			}
+			letters++
+		}
+	}
+	return letters >= 2
+}
+
+// hasInnerUpper reports whether word has an uppercase letter after the first rune
+func hasInnerUpper(word string) bool {
+	for i, r := range word {
+		if i > 0 && unicode.IsUpper(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// isPronounI reports whether word is "I" or a contraction of it ("I'm", "I'll")
+func isPronounI(word string) bool {
+	lower := strings.ToLower(word)
+	if lower == "i" {
+		return true
+	}
+	return strings.HasPrefix(lower, "i'") || strings.HasPrefix(lower, "i’")
+}
@@ -0,0 +1,56 @@
+package textproc
+
+import "testing"
+
+func TestApplyCaseStyle(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		style string
+		want  string
+	}{
+		{"asis", "hello World", CaseAsIs, "hello World"},
+		{"unknown style", "hello World", "shout", "hello World"},
+		{"upper", "Hello world", CaseUpper, "HELLO WORLD"},
+		{"lower", "Hello World", CaseLower, "hello world"},
+		{"sentence", "fix the Bug. then Ship it", CaseSentence, "Fix the bug. Then ship it"},
+		{"sentence keeps acronyms", "the API is DOWN", CaseSentence, "The API is DOWN"},
+		{"sentence keeps pronoun I", "yes i'm sure i did", CaseSentence, "Yes I'm sure I did"},
+		{"sentence after newline", "first line\nsecond line", CaseSentence, "First line\nSecond line"},
+		{"title", "the lord of the rings", CaseTitle, "The Lord of the Rings"},
+		{"title last small word", "what are you looking for", CaseTitle, "What Are You Looking For"},
+		{"title after colon", "update: a new hope", CaseTitle, "Update: A New Hope"},
+		{"title keeps mixed case", "buying an iPhone", CaseTitle, "Buying an iPhone"},
+		{"title contraction", "don't stop", CaseTitle, "Don't Stop"},
+		{"title non-ASCII", "élan and über", CaseTitle, "Élan and Über"},
+		{"no words", "...", CaseTitle, "..."},
+		{"empty", "", CaseSentence, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ApplyCaseStyle(tt.text, tt.style); got != tt.want {
+				t.Errorf("ApplyCaseStyle(%q, %q) = %q, want %q", tt.text, tt.style, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateCaseStyle(t *testing.T) {
+	tests := []struct {
+		style   string
+		wantErr bool
+	}{
+		{CaseAsIs, false},
+		{CaseSentence, false},
+		{CaseTitle, false},
+		{CaseUpper, false},
+		{CaseLower, false},
+		{"", true},
+		{"Title", true},
+	}
+	for _, tt := range tests {
+		if err := ValidateCaseStyle(tt.style); (err != nil) != tt.wantErr {
+			t.Errorf("ValidateCaseStyle(%q) error = %v, wantErr %v", tt.style, err, tt.wantErr)
+		}
+	}
+}
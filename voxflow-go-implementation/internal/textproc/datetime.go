@@ -0,0 +1,42 @@
+package textproc
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Default layouts for expanded date/time tokens (Go reference time format)
+const (
+	DefaultDateFormat = "January 2, 2006"
+	DefaultTimeFormat = "3:04 PM"
+)
+
+// dateTokenPattern matches spoken date/time commands such as "insert date",
+// "insert today's date", "insert the current time", "insert a timestamp" or a
+// bare "timestamp"
+var dateTokenPattern = regexp.MustCompile(`(?i)\b(?:insert\s+(?:the\s+|a\s+)?(?:today'?s\s+|current\s+)?(date|time|timestamp)|timestamp)\b`)
+
+// ExpandDateTokens replaces date/time voice commands in text with the given
+// time formatted using dateFormat and timeFormat. Empty formats use the defaults.
+func ExpandDateTokens(text string, now time.Time, dateFormat, timeFormat string) string {
+	if dateFormat == "" {
+		dateFormat = DefaultDateFormat
+	}
+	if timeFormat == "" {
+		timeFormat = DefaultTimeFormat
+	}
+
+	return dateTokenPattern.ReplaceAllStringFunc(text, func(match string) string {
+		// A bare "timestamp" leaves the kind group empty
+		kind := strings.ToLower(dateTokenPattern.FindStringSubmatch(match)[1])
+		switch kind {
+		case "date":
+			return now.Format(dateFormat)
+		case "time":
+			return now.Format(timeFormat)
+		default:
+			return now.Format(dateFormat + " " + timeFormat)
+		}
+	})
+}
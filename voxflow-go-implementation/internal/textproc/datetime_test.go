@@ -0,0 +1,47 @@
+package textproc
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExpandDateTokens(t *testing.T) {
+	now := time.Date(2024, time.March, 5, 14, 7, 0, 0, time.UTC)
+	tokyo := now.In(time.FixedZone("JST", 9*60*60))
+
+	tests := []struct {
+		name       string
+		text       string
+		now        time.Time
+		dateFormat string
+		timeFormat string
+		want       string
+	}{
+		{"date", "Due insert date.", now, "", "", "Due March 5, 2024."},
+		{"time", "It is insert time now", now, "", "", "It is 2:07 PM now"},
+		{"timestamp", "insert timestamp", now, "", "", "March 5, 2024 2:07 PM"},
+		{"bare timestamp", "Logged timestamp.", now, "", "", "Logged March 5, 2024 2:07 PM."},
+		{"bare timestamp case insensitive", "Timestamp: done", now, "", "", "March 5, 2024 2:07 PM: done"},
+		{"a timestamp", "insert a timestamp", now, "", "", "March 5, 2024 2:07 PM"},
+		{"today's date", "Filed insert today's date", now, "", "", "Filed March 5, 2024"},
+		{"todays date without apostrophe", "insert todays date", now, "", "", "March 5, 2024"},
+		{"the current time", "insert the current time", now, "", "", "2:07 PM"},
+		{"case insensitive", "INSERT Date", now, "", "", "March 5, 2024"},
+		{"several tokens", "insert date at insert time", now, "", "", "March 5, 2024 at 2:07 PM"},
+		{"custom formats", "insert timestamp", now, "2006-01-02", "15:04", "2024-03-05 14:07"},
+		{"day first format", "insert date", now, "02/01/2006", "", "05/03/2024"},
+		{"other time zone", "insert timestamp", tokyo, "2006-01-02", "15:04", "2024-03-05 23:07"},
+		{"no token", "the date was fine", now, "", "", "the date was fine"},
+		{"needs word boundary", "reinsert date", now, "", "", "reinsert date"},
+		{"not a date kind", "insert dates", now, "", "", "insert dates"},
+		{"not a bare timestamp", "compare timestamps", now, "", "", "compare timestamps"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExpandDateTokens(tt.text, tt.now, tt.dateFormat, tt.timeFormat)
+			if got != tt.want {
+				t.Errorf("ExpandDateTokens(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
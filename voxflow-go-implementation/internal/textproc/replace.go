@@ -0,0 +1,109 @@
+package textproc
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// RegexPrefix marks a replacement key as a regular expression, e.g. `re:\bv(\d+)\b`
+const RegexPrefix = "re:"
+
+// ValidateReplacement returns an error if from is empty or an invalid regex entry
+func ValidateReplacement(from string) error {
+	if strings.TrimSpace(from) == "" {
+		return fmt.Errorf("replacement text cannot be empty")
+	}
+	if pattern, ok := strings.CutPrefix(from, RegexPrefix); ok {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid regex %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// ApplyReplacements applies a user dictionary to text. Plain entries match
+// whole words case-insensitively and follow the case of the matched text
+// ("BTW" → "BY THE WAY", "Btw" → "By the way"); otherwise the replacement is
+// used as written. Entries prefixed with RegexPrefix are Go regular
+// expressions whose replacement may use $1-style groups. Longer entries are
+// applied first so they win over entries they contain.
+func ApplyReplacements(text string, replacements map[string]string) string {
+	keys := make([]string, 0, len(replacements))
+	for from := range replacements {
+		keys = append(keys, from)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+
+	for _, from := range keys {
+		to := replacements[from]
+		if pattern, ok := strings.CutPrefix(from, RegexPrefix); ok {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				fmt.Printf("[Replace] Skipping invalid regex %q: %v\n", pattern, err)
+				continue
+			}
+			text = re.ReplaceAllString(text, to)
+			continue
+		}
+
+		from = strings.TrimSpace(from)
+		if from == "" {
+			continue
+		}
+		re := wholeWordPattern(from)
+		text = re.ReplaceAllStringFunc(text, func(match string) string {
+			return matchCase(match, to)
+		})
+	}
+	return text
+}
+
+// wholeWordPattern matches word case-insensitively, not inside a longer word
+func wholeWordPattern(word string) *regexp.Regexp {
+	pattern := regexp.QuoteMeta(word)
+	if first, _ := utf8.DecodeRuneInString(word); isWordRune(first) {
+		pattern = `\b` + pattern
+	}
+	if last, _ := utf8.DecodeLastRuneInString(word); isWordRune(last) {
+		pattern += `\b`
+	}
+	return regexp.MustCompile(`(?i)` + pattern)
+}
+
+// isWordRune reports whether r counts as part of a word for \b
+func isWordRune(r rune) bool {
+	return r == '_' || ('0' <= r && r <= '9') || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z')
+}
+
+// matchCase recases replacement to follow match: all caps, capitalized, or as written
+func matchCase(match, replacement string) string {
+	hasLetter, allUpper := false, true
+	for _, r := range match {
+		if unicode.IsLetter(r) {
+			hasLetter = true
+			if !unicode.IsUpper(r) {
+				allUpper = false
+			}
+		}
+	}
+	if !hasLetter || replacement == "" {
+		return replacement
+	}
+	if allUpper && utf8.RuneCountInString(match) > 1 {
+		return strings.ToUpper(replacement)
+	}
+	if first, _ := utf8.DecodeRuneInString(match); unicode.IsUpper(first) {
+		r, size := utf8.DecodeRuneInString(replacement)
+		return string(unicode.ToUpper(r)) + replacement[size:]
+	}
+	return replacement
+}
@@ -0,0 +1,126 @@
+package textproc
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// symbolGlue controls how a spoken symbol joins its neighbours
+type symbolGlue int
+
+const (
+	glueNone  symbolGlue = iota // Keep surrounding spaces: "x equals 1" → "x = 1"
+	glueBoth                    // Join both sides: "foo dot bar" → "foo.bar"
+	glueRight                   // Join the following word: "open paren x" → "(x"
+	glueLeft                    // Join the preceding word: "x close paren" → "x)"
+)
+
+// spokenSymbol maps a spoken phrase to the symbol it stands for
+type spokenSymbol struct {
+	phrase string
+	symbol string
+	glue   symbolGlue
+}
+
+var spokenSymbols = []spokenSymbol{
+	{"open brace", "{", glueRight},
+	{"open curly", "{", glueRight},
+	{"close brace", "}", glueLeft},
+	{"close curly", "}", glueLeft},
+	{"open bracket", "[", glueRight},
+	{"close bracket", "]", glueLeft},
+	{"open paren", "(", glueRight},
+	{"open parenthesis", "(", glueRight},
+	{"close paren", ")", glueLeft},
+	{"close parenthesis", ")", glueLeft},
+	{"double equals", "==", glueNone},
+	{"triple equals", "===", glueNone},
+	{"not equals", "!=", glueNone},
+	{"equals", "=", glueNone},
+	{"plus equals", "+=", glueNone},
+	{"minus equals", "-=", glueNone},
+	{"less than", "<", glueNone},
+	{"greater than", ">", glueNone},
+	{"fat arrow", "=>", glueNone},
+	{"arrow", "->", glueNone},
+	{"plus", "+", glueNone},
+	{"minus", "-", glueNone},
+	{"star", "*", glueNone},
+	{"asterisk", "*", glueNone},
+	{"slash", "/", glueNone},
+	{"backslash", `\`, glueNone},
+	{"pipe", "|", glueNone},
+	{"double ampersand", "&&", glueNone},
+	{"double pipe", "||", glueNone},
+	{"dot", ".", glueBoth},
+	{"underscore", "_", glueBoth},
+	{"double colon", "::", glueBoth},
+	{"dash", "-", glueBoth},
+	{"comma", ",", glueLeft},
+	{"semicolon", ";", glueLeft},
+	{"colon", ":", glueLeft},
+	{"bang", "!", glueRight},
+	{"at sign", "@", glueRight},
+	{"hash", "#", glueRight},
+	{"dollar sign", "$", glueRight},
+	{"backtick", "`", glueNone},
+	{"new line", "\n", glueBoth},
+}
+
+// symbolPattern matches any spoken symbol phrase
+var symbolPattern = buildSymbolPattern()
+
+// symbolsByPhrase indexes spokenSymbols by lowercase phrase
+var symbolsByPhrase = func() map[string]spokenSymbol {
+	m := make(map[string]spokenSymbol, len(spokenSymbols))
+	for _, s := range spokenSymbols {
+		m[s.phrase] = s
+	}
+	return m
+}()
+
+// buildSymbolPattern compiles an alternation of all phrases, longest first so
+// "double equals" wins over "equals"
+func buildSymbolPattern() *regexp.Regexp {
+	phrases := make([]string, 0, len(spokenSymbols))
+	for _, s := range spokenSymbols {
+		phrases = append(phrases, regexp.QuoteMeta(s.phrase))
+	}
+	sort.Slice(phrases, func(i, j int) bool { return len(phrases[i]) > len(phrases[j]) })
+	return regexp.MustCompile(`(?i)\b(?:` + strings.Join(phrases, "|") + `)\b`)
+}
+
+// ReplaceSpokenSymbols converts spoken symbol names ("open brace", "equals",
+// "dot") in dictated code to the symbols themselves
+func ReplaceSpokenSymbols(text string) string {
+	matches := symbolPattern.FindAllStringIndex(text, -1)
+	if len(matches) == 0 {
+		return text
+	}
+
+	var b strings.Builder
+	b.Grow(len(text))
+	prev := 0
+	trimNext := false
+	for _, m := range matches {
+		gap := text[prev:m[0]]
+		if trimNext {
+			gap = strings.TrimLeft(gap, " \t")
+		}
+		sym := symbolsByPhrase[strings.ToLower(text[m[0]:m[1]])]
+		if sym.glue == glueBoth || sym.glue == glueLeft {
+			gap = strings.TrimRight(gap, " \t")
+		}
+		b.WriteString(gap)
+		b.WriteString(sym.symbol)
+		trimNext = sym.glue == glueBoth || sym.glue == glueRight
+		prev = m[1]
+	}
+	rest := text[prev:]
+	if trimNext {
+		rest = strings.TrimLeft(rest, " \t")
+	}
+	b.WriteString(rest)
+	return b.String()
+}
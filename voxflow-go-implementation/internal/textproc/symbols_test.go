@@ -0,0 +1,45 @@
+package textproc
+
+import "testing"
+
+func TestReplaceSpokenSymbols(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"no symbols", "hello world", "hello world"},
+		{"empty", "", ""},
+		{"spaced operator", "x equals 1", "x = 1"},
+		{"longest phrase wins", "a double equals b", "a == b"},
+		{"triple before double", "a triple equals b", "a === b"},
+		{"joins both sides", "foo dot bar", "foo.bar"},
+		{"chained joins", "os dot path dot join", "os.path.join"},
+		{"snake case", "user underscore id", "user_id"},
+		{"comma attaches left", "a comma b", "a, b"},
+		{"semicolon ends a statement", "x equals 1 semicolon", "x = 1;"},
+		{"colon attaches left", "key colon value", "key: value"},
+		{"bang attaches right", "bang done", "!done"},
+		{"parens", "print open paren x close paren", "print (x)"},
+		{"call with args", "foo dot bar open paren a comma b close paren", "foo.bar (a, b)"},
+		{"brackets", "items open bracket 0 close bracket", "items [0]"},
+		{"braces", "open brace x close brace", "{x}"},
+		{"open parenthesis and close parenthesis", "open parenthesis a close parenthesis", "(a)"},
+		{"backticks stay spaced", "backtick code backtick", "` code `"},
+		{"after a quoted string", `say "hi" comma then`, `say "hi", then`},
+		{"inside quotes", `"a dot b"`, `"a.b"`},
+		{"bracket around a quote", `open bracket "x" close bracket`, `["x"]`},
+		{"uppercase", "X EQUALS 1", "X = 1"},
+		{"mixed case", "foo Dot bar Open Paren close PAREN", "foo.bar ()"},
+		{"whole words only", "the dotted equalsign", "the dotted equalsign"},
+		{"hash attaches right", "hash tag", "#tag"},
+		{"new line", "first new line second", "first\nsecond"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ReplaceSpokenSymbols(tt.text); got != tt.want {
+				t.Errorf("ReplaceSpokenSymbols(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package textproc
+
+import (
+	"strings"
+	"unicode"
+)
+
+// TruncateAtWord shortens text to at most maxChars characters, cutting at the
+// last word boundary before the limit. It reports whether text was truncated.
+// A maxChars of 0 or less means no limit.
+func TruncateAtWord(text string, maxChars int) (string, bool) {
+	runes := []rune(text)
+	if maxChars <= 0 || len(runes) <= maxChars {
+		return text, false
+	}
+
+	cut := maxChars
+	// If the limit falls mid-word, back up to the previous whitespace
+	if !unicode.IsSpace(runes[cut]) {
+		for i := cut - 1; i > 0; i-- {
+			if unicode.IsSpace(runes[i]) {
+				cut = i
+				break
+			}
+		}
+	}
+
+	return strings.TrimRightFunc(string(runes[:cut]), unicode.IsSpace), true
+}
@@ -0,0 +1,21 @@
+package whisper
+
+import "strings"
+
+// BlankAudioMarkers are what Whisper outputs instead of text when it hears no speech
+var BlankAudioMarkers = []string{
+	"[BLANK_AUDIO]",
+	"(blank audio)",
+	"[NO SPEECH]",
+}
+
+// IsBlankAudio reports whether a transcript is only a blank-audio marker
+func IsBlankAudio(text string) bool {
+	text = strings.TrimSpace(text)
+	for _, marker := range BlankAudioMarkers {
+		if strings.EqualFold(text, marker) {
+			return true
+		}
+	}
+	return false
+}
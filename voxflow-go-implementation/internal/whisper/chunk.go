@@ -0,0 +1,282 @@
+package whisper
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	bytesPerSample = 2 // Chunking only splits 16-bit PCM
+
+	// maxOverlapWords bounds how far back stitching looks for repeated words
+	maxOverlapWords = 30
+)
+
+// SetChunking configures splitting of long recordings. Recordings longer than
+// chunkSeconds are transcribed in chunks that overlap by overlapSeconds.
+// A chunkSeconds of 0 disables chunking.
+func (s *Service) SetChunking(chunkSeconds, overlapSeconds int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if chunkSeconds < 0 {
+		chunkSeconds = 0
+	}
+	if overlapSeconds < 0 || (chunkSeconds > 0 && overlapSeconds >= chunkSeconds) {
+		overlapSeconds = 0
+	}
+	s.chunkSeconds = chunkSeconds
+	s.overlapSeconds = overlapSeconds
+}
+
+// transcribeChunked transcribes wavPath directly if it is short enough,
+// otherwise splits it into overlapping chunks and stitches the results
+func (s *Service) transcribeChunked(ctx context.Context, whisperBin, modelPath, wavPath, language string) (string, []Segment, error) {
+	if s.chunkSeconds == 0 {
+		return s.transcribeWithCLI(ctx, whisperBin, modelPath, wavPath, language, s.OnTranscribeProgress)
+	}
+
+	format, err := readWavFormat(wavPath)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read WAV file: %w", err)
+	}
+	if format.bitsPerSample != bytesPerSample*8 {
+		fmt.Printf("[Whisper] Not chunking %d-bit audio\n", format.bitsPerSample)
+		return s.transcribeWithCLI(ctx, whisperBin, modelPath, wavPath, language, s.OnTranscribeProgress)
+	}
+
+	// Offsets are in samples, so a frame spans format.channels of them
+	frameSamples := format.channels
+	chunkSamples := s.chunkSeconds * format.sampleRate * frameSamples
+	if format.dataSize/bytesPerSample <= int64(chunkSamples) {
+		return s.transcribeWithCLI(ctx, whisperBin, modelPath, wavPath, language, s.OnTranscribeProgress)
+	}
+
+	samples, err := readWavSamples(wavPath, format)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read WAV file: %w", err)
+	}
+
+	overlapSamples := s.overlapSeconds * format.sampleRate * frameSamples
+	step := chunkSamples - overlapSamples
+	chunkCount := 1 + (len(samples)-chunkSamples+step-1)/step
+	var parts []string
+	var segments []Segment
+	for start := 0; start < len(samples); start += step {
+		end := start + chunkSamples
+		if end > len(samples) {
+			end = len(samples)
+		}
+
+		chunkPath := filepath.Join(os.TempDir(), fmt.Sprintf("%s.chunk%d.wav", filepath.Base(wavPath), len(parts)))
+		if err := writeWavSamples(chunkPath, samples[start:end], format); err != nil {
+			return "", nil, fmt.Errorf("failed to write WAV chunk: %w", err)
+		}
+
+		fmt.Printf("[Whisper] Transcribing chunk %d (%.0fs-%.0fs)\n", len(parts)+1,
+			float64(samplesToMs(start, format))/1000, float64(samplesToMs(end, format))/1000)
+		// Report progress across the whole recording, not per chunk
+		var progress func(int)
+		if onProgress := s.OnTranscribeProgress; onProgress != nil {
+			done := len(parts)
+			progress = func(percent int) {
+				onProgress((done*100 + percent) / chunkCount)
+			}
+		}
+
+		text, chunkSegments, err := s.transcribeWithCLI(ctx, whisperBin, modelPath, chunkPath, language, progress)
+		os.Remove(chunkPath)
+		if err != nil {
+			return "", nil, fmt.Errorf("chunk %d: %w", len(parts)+1, err)
+		}
+		parts = append(parts, text)
+
+		// Each chunk owns the audio up to the middle of its overlaps, so
+		// segments heard twice are only kept once
+		offsetMs := samplesToMs(start, format)
+		fromMs := offsetMs
+		if start > 0 {
+			fromMs += samplesToMs(overlapSamples/2, format)
+		}
+		toMs := samplesToMs(end, format)
+		if end < len(samples) {
+			toMs -= samplesToMs(overlapSamples/2, format)
+		}
+		for _, seg := range chunkSegments {
+			seg.StartMs += offsetMs
+			seg.EndMs += offsetMs
+			if seg.StartMs >= fromMs && seg.StartMs < toMs {
+				segments = append(segments, seg)
+			}
+		}
+
+		if end == len(samples) {
+			break
+		}
+	}
+
+	return stitchTranscripts(parts), segments, nil
+}
+
+// samplesToMs converts a sample count in the given format to milliseconds
+func samplesToMs(n int, format wavFormat) int64 {
+	return int64(n) * 1000 / int64(format.sampleRate*format.channels)
+}
+
+// stitchTranscripts joins chunk transcripts, dropping words repeated at the
+// start of a chunk because of the audio overlap with the previous chunk
+func stitchTranscripts(parts []string) string {
+	var words []string
+	for _, part := range parts {
+		next := strings.Fields(part)
+		overlap := 0
+		limit := min(maxOverlapWords, len(words), len(next))
+		for n := limit; n > 0; n-- {
+			if wordsEqual(words[len(words)-n:], next[:n]) {
+				overlap = n
+				break
+			}
+		}
+		words = append(words, next[overlap:]...)
+	}
+	return strings.Join(words, " ")
+}
+
+// wordsEqual compares word slices ignoring case and surrounding punctuation
+func wordsEqual(a, b []string) bool {
+	for i := range a {
+		if normalizeWord(a[i]) != normalizeWord(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func normalizeWord(w string) string {
+	return strings.ToLower(strings.Trim(w, ".,!?;:\"'()"))
+}
+
+// wavFormat describes a PCM WAV file and where its samples are
+type wavFormat struct {
+	sampleRate    int
+	channels      int
+	bitsPerSample int
+	dataOffset    int64 // Start of the data chunk's samples
+	dataSize      int64 // Bytes of sample data
+}
+
+// readWavFormat walks the RIFF chunks of a WAV file for its fmt and data
+// chunks, so headers with extra chunks (LIST, fact, ...) are handled
+func readWavFormat(path string) (wavFormat, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return wavFormat{}, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return wavFormat{}, err
+	}
+
+	var riff [12]byte
+	if _, err := io.ReadFull(file, riff[:]); err != nil || string(riff[0:4]) != "RIFF" || string(riff[8:12]) != "WAVE" {
+		return wavFormat{}, errors.New("invalid WAV file format")
+	}
+
+	offset := int64(len(riff))
+	var format wavFormat
+	for {
+		var chunk [8]byte
+		if _, err := io.ReadFull(file, chunk[:]); err != nil {
+			return wavFormat{}, errors.New("WAV file has no data chunk")
+		}
+		offset += int64(len(chunk))
+		chunkSize := int64(binary.LittleEndian.Uint32(chunk[4:8]))
+
+		switch string(chunk[0:4]) {
+		case "fmt ":
+			if chunkSize < 16 {
+				return wavFormat{}, errors.New("WAV fmt chunk is too short")
+			}
+			var fmtChunk [16]byte
+			if _, err := io.ReadFull(file, fmtChunk[:]); err != nil {
+				return wavFormat{}, errors.New("WAV fmt chunk is truncated")
+			}
+			format.channels = int(binary.LittleEndian.Uint16(fmtChunk[2:4]))
+			format.sampleRate = int(binary.LittleEndian.Uint32(fmtChunk[4:8]))
+			format.bitsPerSample = int(binary.LittleEndian.Uint16(fmtChunk[14:16]))
+			if format.channels == 0 || format.sampleRate == 0 {
+				return wavFormat{}, errors.New("WAV fmt chunk is invalid")
+			}
+		case "data":
+			if format.sampleRate == 0 {
+				return wavFormat{}, errors.New("WAV data chunk comes before the fmt chunk")
+			}
+			// An unpatched header (e.g. after a crash) understates or
+			// overstates the data, so trust the file size over it
+			if remaining := info.Size() - offset; chunkSize == 0 || chunkSize > remaining {
+				chunkSize = remaining
+			}
+			format.dataOffset = offset
+			format.dataSize = chunkSize
+			return format, nil
+		}
+
+		// Chunks are padded to an even size
+		next := offset + chunkSize + chunkSize%2
+		if _, err := file.Seek(next, io.SeekStart); err != nil {
+			return wavFormat{}, err
+		}
+		offset = next
+	}
+}
+
+// readWavSamples reads the 16-bit PCM samples from the data chunk of a WAV file
+func readWavSamples(path string, format wavFormat) ([]int16, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	pcm := make([]byte, format.dataSize)
+	if _, err := file.ReadAt(pcm, format.dataOffset); err != nil {
+		return nil, err
+	}
+	samples := make([]int16, len(pcm)/bytesPerSample)
+	for i := range samples {
+		samples[i] = int16(binary.LittleEndian.Uint16(pcm[i*2 : i*2+2]))
+	}
+	return samples, nil
+}
+
+// writeWavSamples writes 16-bit PCM samples to a WAV file in the given format
+func writeWavSamples(path string, samples []int16, format wavFormat) error {
+	dataSize := len(samples) * bytesPerSample
+	blockAlign := format.channels * bytesPerSample
+
+	buf := bytes.NewBuffer(make([]byte, 0, 44+dataSize))
+	buf.WriteString("RIFF")
+	binary.Write(buf, binary.LittleEndian, uint32(36+dataSize))
+	buf.WriteString("WAVE")
+	buf.WriteString("fmt ")
+	binary.Write(buf, binary.LittleEndian, uint32(16))
+	binary.Write(buf, binary.LittleEndian, uint16(1)) // PCM
+	binary.Write(buf, binary.LittleEndian, uint16(format.channels))
+	binary.Write(buf, binary.LittleEndian, uint32(format.sampleRate))
+	binary.Write(buf, binary.LittleEndian, uint32(format.sampleRate*blockAlign))
+	binary.Write(buf, binary.LittleEndian, uint16(blockAlign))
+	binary.Write(buf, binary.LittleEndian, uint16(bytesPerSample*8))
+	buf.WriteString("data")
+	binary.Write(buf, binary.LittleEndian, uint32(dataSize))
+	binary.Write(buf, binary.LittleEndian, samples)
+
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
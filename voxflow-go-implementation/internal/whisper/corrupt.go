@@ -0,0 +1,102 @@
+package whisper
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrModelCorrupt means the model file exists but fails its header or
+// checksum check, so it needs downloading again
+var ErrModelCorrupt = errors.New("model file is corrupt")
+
+// ErrModelLoad means whisper couldn't load a model that checks out, e.g. out
+// of memory or a Metal init failure. It may go away on its own.
+var ErrModelLoad = errors.New("whisper could not load the model")
+
+// modelLoadFailureOutput are whisper-cli messages printed when loading the
+// model fails. A corrupt file prints them, but so do out-of-memory and Metal
+// failures, so they only prompt a check of the file itself.
+var modelLoadFailureOutput = []string{
+	"invalid model data",
+	"bad magic",
+	"failed to load model",
+	"failed to initialize whisper context",
+	"has wrong size",
+	"has wrong shape",
+	"unknown tensor",
+}
+
+// isModelLoadFailure reports whether whisper-cli output says the model failed to load
+func isModelLoadFailure(output string) bool {
+	output = strings.ToLower(output)
+	for _, msg := range modelLoadFailureOutput {
+		if strings.Contains(output, msg) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkModelHeader does a quick sanity check that path is a ggml model:
+// it must be larger than minModelBytes and start with the ggml magic
+func checkModelHeader(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	if info.Size() <= minModelBytes {
+		return fmt.Errorf("%w: %s is only %d bytes", ErrModelCorrupt, filepath.Base(path), info.Size())
+	}
+
+	var magic uint32
+	if err := binary.Read(file, binary.LittleEndian, &magic); err != nil || magic != ggmlMagic {
+		return fmt.Errorf("%w: %s is not a whisper.cpp (ggml) model", ErrModelCorrupt, filepath.Base(path))
+	}
+	return nil
+}
+
+// checkModelFile checks the model at path against the ggml magic and, for
+// known models, the published SHA-256. Only a mismatch returns ErrModelCorrupt.
+func checkModelFile(path string) error {
+	if err := checkModelHeader(path); err != nil {
+		return err
+	}
+	for name := range modelChecksums {
+		if modelFileName(name) == filepath.Base(path) {
+			return verifyChecksum(path, name)
+		}
+	}
+	return nil
+}
+
+// DiscardModel deletes a model so it can be downloaded again, unloading it if
+// it is the current model. Only called when the user asks for a re-download.
+func (s *Service) DiscardModel(modelSize string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	modelsDir, err := GetModelsDir()
+	if err != nil {
+		return err
+	}
+	modelPath := filepath.Join(modelsDir, modelFileName(modelSize))
+	if err := os.Remove(modelPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete model: %w", err)
+	}
+
+	if s.modelSize == modelSize {
+		s.loaded = false
+	}
+	fmt.Printf("[Whisper] Discarded model %s\n", modelSize)
+	return nil
+}
@@ -0,0 +1,113 @@
+//go:build whisper_bindings
+
+package whisper
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	bindings "github.com/ggerganov/whisper.cpp/bindings/go/pkg/whisper"
+)
+
+// goModel caches the model loaded by the Go bindings across transcriptions
+var goModel struct {
+	mu    sync.Mutex
+	path  string
+	model bindings.Model
+}
+
+// transcribeWithGoWhisper transcribes wavPath in-process with the whisper.cpp
+// Go bindings, for when no whisper-cli binary is installed. Must be called
+// with s.mu held.
+func (s *Service) transcribeWithGoWhisper(ctx context.Context, wavPath, language string) (string, []Segment, error) {
+	samples, err := readWavFile(wavPath)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read WAV file: %w", err)
+	}
+
+	goModel.mu.Lock()
+	defer goModel.mu.Unlock()
+
+	if goModel.model == nil || goModel.path != s.modelPath {
+		if goModel.model != nil {
+			goModel.model.Close()
+			goModel.model = nil
+		}
+		model, err := bindings.New(s.modelPath)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to load model: %w", err)
+		}
+		goModel.model = model
+		goModel.path = s.modelPath
+		fmt.Printf("[Whisper] Loaded %s with Go bindings\n", s.modelPath)
+	}
+
+	wctx, err := goModel.model.NewContext()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create whisper context: %w", err)
+	}
+	if language != "" {
+		if err := wctx.SetLanguage(language); err != nil {
+			return "", nil, fmt.Errorf("failed to set language: %w", err)
+		}
+	}
+	wctx.SetTranslate(s.translate)
+	if s.threads > 0 {
+		wctx.SetThreads(uint(s.threads))
+	}
+	if s.beamSize > 0 {
+		wctx.SetBeamSize(s.beamSize)
+	}
+	wctx.SetTemperature(float32(s.temperature))
+	if s.prompt != "" {
+		wctx.SetInitialPrompt(s.prompt)
+	}
+
+	// Returning false from the encoder callback aborts processing
+	keepGoing := func() bool { return ctx.Err() == nil }
+	err = wctx.Process(samples, keepGoing, nil, nil)
+	if ctx.Err() != nil {
+		return "", nil, fmt.Errorf("transcription cancelled: %w", ctx.Err())
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("whisper failed: %w", err)
+	}
+
+	var segments []Segment
+	var texts []string
+	for {
+		seg, err := wctx.NextSegment()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to read segment: %w", err)
+		}
+		text := strings.TrimSpace(seg.Text)
+		if text == "" {
+			continue
+		}
+		segments = append(segments, Segment{
+			StartMs: seg.Start.Milliseconds(),
+			EndMs:   seg.End.Milliseconds(),
+			Text:    text,
+		})
+		texts = append(texts, text)
+	}
+
+	return strings.Join(texts, " "), segments, nil
+}
+
+// closeGoWhisper releases the model loaded by the Go bindings
+func closeGoWhisper() {
+	goModel.mu.Lock()
+	defer goModel.mu.Unlock()
+	if goModel.model != nil {
+		goModel.model.Close()
+		goModel.model = nil
+		goModel.path = ""
+	}
+}
@@ -0,0 +1,16 @@
+//go:build !whisper_bindings
+
+package whisper
+
+import (
+	"context"
+	"fmt"
+)
+
+// transcribeWithGoWhisper is unavailable unless built with -tags whisper_bindings
+func (s *Service) transcribeWithGoWhisper(ctx context.Context, wavPath, language string) (string, []Segment, error) {
+	return "", nil, fmt.Errorf("whisper CLI binary not found. Please install whisper.cpp or provide the binary at ~/.voxflow/bin/whisper-cli")
+}
+
+// closeGoWhisper is a no-op without the Go bindings
+func closeGoWhisper() {}
@@ -0,0 +1,91 @@
+package whisper
+
+import "fmt"
+
+// AutoLanguage lets whisper detect the spoken language
+const AutoLanguage = "auto"
+
+// Language is a transcription language whisper understands
+type Language struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+// SupportedLanguages lists the languages offered in settings, auto-detect first
+var SupportedLanguages = []Language{
+	{AutoLanguage, "Auto-detect"},
+	{"en", "English"},
+	{"zh", "Chinese"},
+	{"de", "German"},
+	{"es", "Spanish"},
+	{"ru", "Russian"},
+	{"ko", "Korean"},
+	{"fr", "French"},
+	{"ja", "Japanese"},
+	{"pt", "Portuguese"},
+	{"tr", "Turkish"},
+	{"pl", "Polish"},
+	{"ca", "Catalan"},
+	{"nl", "Dutch"},
+	{"ar", "Arabic"},
+	{"sv", "Swedish"},
+	{"it", "Italian"},
+	{"id", "Indonesian"},
+	{"hi", "Hindi"},
+	{"fi", "Finnish"},
+	{"vi", "Vietnamese"},
+	{"he", "Hebrew"},
+	{"uk", "Ukrainian"},
+	{"el", "Greek"},
+	{"ms", "Malay"},
+	{"cs", "Czech"},
+	{"ro", "Romanian"},
+	{"da", "Danish"},
+	{"hu", "Hungarian"},
+	{"ta", "Tamil"},
+	{"no", "Norwegian"},
+	{"th", "Thai"},
+	{"ur", "Urdu"},
+	{"hr", "Croatian"},
+	{"bg", "Bulgarian"},
+	{"lt", "Lithuanian"},
+	{"la", "Latin"},
+	{"cy", "Welsh"},
+	{"sk", "Slovak"},
+	{"te", "Telugu"},
+	{"fa", "Persian"},
+	{"lv", "Latvian"},
+	{"bn", "Bengali"},
+	{"sr", "Serbian"},
+	{"sl", "Slovenian"},
+	{"kn", "Kannada"},
+	{"et", "Estonian"},
+	{"mk", "Macedonian"},
+	{"ml", "Malayalam"},
+	{"mr", "Marathi"},
+	{"gu", "Gujarati"},
+	{"pa", "Punjabi"},
+	{"sw", "Swahili"},
+	{"tl", "Tagalog"},
+}
+
+// ValidateLanguage returns an error if code is not in SupportedLanguages
+func ValidateLanguage(code string) error {
+	for _, l := range SupportedLanguages {
+		if l.Code == code {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported language: %s", code)
+}
+
+// SetLanguage sets the language used by Transcribe (AutoLanguage to detect)
+func (s *Service) SetLanguage(code string) error {
+	if err := ValidateLanguage(code); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.language = code
+	return nil
+}
@@ -0,0 +1,72 @@
+package whisper
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Segment is a span of transcribed speech with its position in the audio
+type Segment struct {
+	StartMs int64  `json:"start_ms"`
+	EndMs   int64  `json:"end_ms"`
+	Text    string `json:"text"`
+}
+
+// cliJSONOutput is the subset of whisper-cli's -oj output that we use
+type cliJSONOutput struct {
+	Transcription []struct {
+		Offsets struct {
+			From int64 `json:"from"`
+			To   int64 `json:"to"`
+		} `json:"offsets"`
+		Text string `json:"text"`
+	} `json:"transcription"`
+}
+
+// stdoutTimestamp matches the "[00:00:00.000 --> 00:00:02.000]" prefix
+// whisper-cli prints before each segment
+var stdoutTimestamp = regexp.MustCompile(`(?m)^\[[0-9:.]+ --> [0-9:.]+\]\s*`)
+
+// readSegmentsJSON parses the segments from a whisper-cli JSON output file
+func readSegmentsJSON(path string) ([]Segment, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var out cliJSONOutput
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("failed to parse whisper JSON output: %w", err)
+	}
+
+	segments := make([]Segment, 0, len(out.Transcription))
+	for _, t := range out.Transcription {
+		text := strings.TrimSpace(t.Text)
+		if text == "" {
+			continue
+		}
+		segments = append(segments, Segment{
+			StartMs: t.Offsets.From,
+			EndMs:   t.Offsets.To,
+			Text:    text,
+		})
+	}
+	return segments, nil
+}
+
+// segmentsText joins segment texts into a plain transcript
+func segmentsText(segments []Segment) string {
+	texts := make([]string, len(segments))
+	for i, seg := range segments {
+		texts[i] = seg.Text
+	}
+	return strings.Join(texts, " ")
+}
+
+// stripStdoutTimestamps removes segment timestamps from whisper-cli stdout
+func stripStdoutTimestamps(output string) string {
+	return strings.TrimSpace(stdoutTimestamp.ReplaceAllString(output, ""))
+}
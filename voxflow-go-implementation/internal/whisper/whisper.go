@@ -3,39 +3,102 @@ package whisper
 import (
 	"bufio"
 	"context"
+	"crypto/sha256"
+	_ "embed"
 	"encoding/binary"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
 )
 
 // Model sizes and their download URLs (Hugging Face)
 var modelURLs = map[string]string{
-	"tiny":   "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-tiny.bin",
-	"base":   "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-base.bin",
-	"small":  "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-small.bin",
-	"medium": "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-medium.bin",
+	"tiny":          "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-tiny.bin",
+	"base":          "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-base.bin",
+	"base-q5_1":     "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-base-q5_1.bin",
+	"base-q8_0":     "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-base-q8_0.bin",
+	"small":         "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-small.bin",
+	"small-q5_1":    "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-small-q5_1.bin",
+	"small-q8_0":    "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-small-q8_0.bin",
+	"medium":        "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-medium.bin",
+	"medium-q5_0":   "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-medium-q5_0.bin",
+	"medium-q8_0":   "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-medium-q8_0.bin",
+	"large-v3":      "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-large-v3.bin",
+	"large-v3-q5_0": "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-large-v3-q5_0.bin",
 }
 
 // Model sizes in bytes (approximate)
 var modelSizes = map[string]int64{
-	"tiny":   75 * 1024 * 1024,   // ~75 MB
-	"base":   142 * 1024 * 1024,  // ~142 MB
-	"small":  466 * 1024 * 1024,  // ~466 MB
-	"medium": 1500 * 1024 * 1024, // ~1.5 GB
+	"tiny":          75 * 1024 * 1024,   // ~75 MB
+	"base":          142 * 1024 * 1024,  // ~142 MB
+	"base-q5_1":     57 * 1024 * 1024,   // ~57 MB
+	"base-q8_0":     78 * 1024 * 1024,   // ~78 MB
+	"small":         466 * 1024 * 1024,  // ~466 MB
+	"small-q5_1":    181 * 1024 * 1024,  // ~181 MB
+	"small-q8_0":    252 * 1024 * 1024,  // ~252 MB
+	"medium":        1500 * 1024 * 1024, // ~1.5 GB
+	"medium-q5_0":   514 * 1024 * 1024,  // ~514 MB
+	"medium-q8_0":   785 * 1024 * 1024,  // ~785 MB
+	"large-v3":      2950 * 1024 * 1024, // ~2.9 GB
+	"large-v3-q5_0": 1030 * 1024 * 1024, // ~1.1 GB
+}
+
+// modelOrder lists models for the UI, grouped by accuracy tier
+var modelOrder = []struct{ name, tier string }{
+	{"tiny", "Fast"},
+	{"base-q5_1", "Fast"},
+	{"base-q8_0", "Fast"},
+	{"base", "Fast"},
+	{"small-q5_1", "Balanced"},
+	{"small-q8_0", "Balanced"},
+	{"small", "Balanced"},
+	{"medium-q5_0", "Accurate"},
+	{"medium-q8_0", "Accurate"},
+	{"medium", "Accurate"},
+	{"large-v3-q5_0", "Most accurate"},
+	{"large-v3", "Most accurate"},
+}
+
+// modelFileName returns the file a model is stored in, matching the upstream
+// names (e.g. ggml-base-q5_1.bin)
+func modelFileName(name string) string {
+	return "ggml-" + name + ".bin"
+}
+
+// SHA-256 of each model file, as published on Hugging Face
+var modelChecksums = map[string]string{
+	"tiny":   "be07e048e1e599ad46341c8d2a135645097a538221678b7acdd1b1919c6e1b21",
+	"base":   "60ed5bc3dd14eea856493d334349b405782ddcaf0028d4b5df4088345fba2efe",
+	"small":  "1be3a9b2063867b937e64e2ec7483364a79917e157fa98c5d94b5c1fffea987b",
+	"medium": "6c14d5adee5f86394037b4e4e8b59f1673b6cee10e3cf0b11bbdbee79c156208",
 }
 
 // Model descriptions for UI
 var ModelDescriptions = map[string]string{
-	"tiny":   "Fastest, least accurate (~75 MB)",
-	"base":   "Good balance of speed and accuracy (~142 MB)",
-	"small":  "Better accuracy, slower (~466 MB)",
-	"medium": "Best accuracy, slowest (~1.5 GB)",
+	"tiny":          "Fastest, least accurate (~75 MB)",
+	"base":          "Good balance of speed and accuracy (~142 MB)",
+	"base-q5_1":     "Base, 5-bit quantized: smaller, slightly less accurate (~57 MB)",
+	"base-q8_0":     "Base, 8-bit quantized: near-base accuracy (~78 MB)",
+	"small":         "Better accuracy, slower (~466 MB)",
+	"small-q5_1":    "Small, 5-bit quantized: smaller, slightly less accurate (~181 MB)",
+	"small-q8_0":    "Small, 8-bit quantized: near-small accuracy (~252 MB)",
+	"medium":        "High accuracy, slow (~1.5 GB)",
+	"medium-q5_0":   "Medium, 5-bit quantized: smaller, slightly less accurate (~514 MB)",
+	"medium-q8_0":   "Medium, 8-bit quantized: near-medium accuracy (~785 MB)",
+	"large-v3":      "Best accuracy, slowest (~2.9 GB)",
+	"large-v3-q5_0": "Large v3, 5-bit quantized: most of the accuracy at a third of the size (~1.1 GB)",
 }
 
 // Whisper CLI binary download URL (pre-compiled for macOS)
@@ -46,6 +109,15 @@ const whisperCLIMacARM = "https://github.com/ggerganov/whisper.cpp/releases/down
 // ProgressCallback is called during model download
 type ProgressCallback func(downloaded, total int64)
 
+// maxStallRetries is how many times a stalled download is attempted before giving up
+const maxStallRetries = 3
+
+// errDownloadStalled is returned by a download attempt that stopped receiving data
+var errDownloadStalled = fmt.Errorf("download stalled")
+
+// errResumeRejected is returned when the server refuses to resume a partial download
+var errResumeRejected = fmt.Errorf("server rejected download resume")
+
 // Service handles Whisper transcription
 type Service struct {
 	modelSize   string
@@ -53,6 +125,25 @@ type Service struct {
 	whisperPath string // Path to whisper.cpp binary
 	mu          sync.RWMutex
 	loaded      bool
+
+	chunkSeconds   int // Split recordings longer than this (0 = never)
+	overlapSeconds int // Overlap between consecutive chunks
+
+	stallTimeout time.Duration // Retry a download after this long without data (0 = never)
+
+	beamSize    int     // whisper-cli -bs (0 = CLI default)
+	temperature float64 // whisper-cli -tp
+	language    string  // whisper-cli -l for Transcribe ("" = CLI default, "auto" = detect)
+	translate   bool    // whisper-cli -tr: output English regardless of spoken language
+	threads     int     // whisper-cli -t (0 = CLI default)
+	prompt      string  // whisper-cli --prompt: vocabulary to bias decoding towards
+
+	// OnDownloadStall is called when a stalled download is about to be retried
+	OnDownloadStall func(modelSize string, attempt int)
+
+	// OnTranscribeProgress is called with the percentage (0-100) of the
+	// current transcription that whisper-cli has processed
+	OnTranscribeProgress func(percent int)
 }
 
 // NewService creates a new Whisper service
@@ -60,19 +151,66 @@ func NewService() *Service {
 	return &Service{}
 }
 
+// ModelsDirEnv overrides the models directory for a single launch
+const ModelsDirEnv = "VOXFLOW_MODELS_DIR"
+
+// modelsDirOverride is set by SetModelsDir ("" = ~/.voxflow/models)
+var (
+	modelsDirOverride string
+	modelsDirMu       sync.RWMutex
+)
+
 // GetModelsDir returns the directory where models are stored
 func GetModelsDir() (string, error) {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return "", err
+	modelsDirMu.RLock()
+	modelsDir := modelsDirOverride
+	modelsDirMu.RUnlock()
+
+	if modelsDir == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		modelsDir = filepath.Join(homeDir, ".voxflow", "models")
 	}
-	modelsDir := filepath.Join(homeDir, ".voxflow", "models")
 	if err := os.MkdirAll(modelsDir, 0755); err != nil {
 		return "", err
 	}
 	return modelsDir, nil
 }
 
+// SetModelsDir stores models in dir instead of ~/.voxflow/models ("" = default).
+// The directory is created if needed and must be writable. Existing models are
+// not moved.
+func SetModelsDir(dir string) error {
+	if dir != "" {
+		if !filepath.IsAbs(dir) {
+			return fmt.Errorf("models directory must be an absolute path")
+		}
+		if err := checkWritableDir(dir); err != nil {
+			return err
+		}
+	}
+	modelsDirMu.Lock()
+	defer modelsDirMu.Unlock()
+	modelsDirOverride = dir
+	return nil
+}
+
+// checkWritableDir creates dir if needed and verifies files can be written to it
+func checkWritableDir(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	probe, err := os.CreateTemp(dir, ".voxflow-write-test-*")
+	if err != nil {
+		return fmt.Errorf("%s is not writable: %w", dir, err)
+	}
+	probe.Close()
+	os.Remove(probe.Name())
+	return nil
+}
+
 // GetBinDir returns the directory for binaries
 func GetBinDir() (string, error) {
 	homeDir, err := os.UserHomeDir()
@@ -139,6 +277,7 @@ func (s *Service) downloadWhisperCLI(progress ProgressCallback) error {
 type ModelInfo struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
+	Tier        string `json:"tier"` // Accuracy tier, for grouping in the UI
 	Size        int64  `json:"size"`
 	Downloaded  bool   `json:"downloaded"`
 	FilePath    string `json:"file_path"`
@@ -152,16 +291,18 @@ func (s *Service) GetAllModels() ([]ModelInfo, error) {
 	}
 
 	models := []ModelInfo{}
-	for _, name := range []string{"tiny", "base", "small", "medium"} {
-		modelPath := filepath.Join(modelsDir, fmt.Sprintf("ggml-%s.bin", name))
+	for _, m := range modelOrder {
+		name := m.name
+		modelPath := filepath.Join(modelsDir, modelFileName(name))
 		downloaded := false
-		if info, err := os.Stat(modelPath); err == nil && info.Size() > 10*1024*1024 {
+		if info, err := os.Stat(modelPath); err == nil && info.Size() > minModelBytes {
 			downloaded = true
 		}
 
 		models = append(models, ModelInfo{
 			Name:        name,
 			Description: ModelDescriptions[name],
+			Tier:        m.tier,
 			Size:        modelSizes[name],
 			Downloaded:  downloaded,
 			FilePath:    modelPath,
@@ -177,7 +318,7 @@ func (s *Service) DeleteModel(modelSize string) error {
 	if err != nil {
 		return err
 	}
-	modelPath := filepath.Join(modelsDir, fmt.Sprintf("ggml-%s.bin", modelSize))
+	modelPath := filepath.Join(modelsDir, modelFileName(modelSize))
 	return os.Remove(modelPath)
 }
 
@@ -187,16 +328,128 @@ func (s *Service) IsModelDownloaded(modelSize string) (bool, error) {
 	if err != nil {
 		return false, err
 	}
-	modelPath := filepath.Join(modelsDir, fmt.Sprintf("ggml-%s.bin", modelSize))
+	modelPath := filepath.Join(modelsDir, modelFileName(modelSize))
 	info, err := os.Stat(modelPath)
 	if err != nil {
 		return false, nil
 	}
 	// Check if file size is reasonable (at least 10MB)
-	return info.Size() > 10*1024*1024, nil
+	return info.Size() > minModelBytes, nil
+}
+
+const (
+	// minModelBytes is the smallest file treated as a downloaded model
+	minModelBytes = 10 * 1024 * 1024
+
+	// ggmlMagic starts every whisper.cpp model file ("ggml", little-endian)
+	ggmlMagic = 0x67676d6c
+)
+
+// ImportModel copies an already-downloaded model file into the models
+// directory under modelName, so it doesn't have to be downloaded again.
+// The file must look like a ggml model of roughly the expected size.
+func (s *Service) ImportModel(modelName, srcPath string) error {
+	expectedSize, ok := modelSizes[modelName]
+	if !ok {
+		return fmt.Errorf("unknown model: %s", modelName)
+	}
+
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return fmt.Errorf("failed to open model file: %w", err)
+	}
+	defer src.Close()
+
+	info, err := src.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to read model file: %w", err)
+	}
+	// Sizes in modelSizes are approximate, so allow some slack either way
+	if info.Size() < expectedSize*3/4 || info.Size() > expectedSize*4/3 {
+		return fmt.Errorf("%s is %d MB, but the %s model is about %d MB", filepath.Base(srcPath),
+			info.Size()/(1024*1024), modelName, expectedSize/(1024*1024))
+	}
+
+	if err := checkModelHeader(srcPath); err != nil {
+		return fmt.Errorf("%s is not a whisper.cpp (ggml) model", filepath.Base(srcPath))
+	}
+
+	modelsDir, err := GetModelsDir()
+	if err != nil {
+		return err
+	}
+	modelPath := filepath.Join(modelsDir, modelFileName(modelName))
+	if same, _ := filepath.Abs(srcPath); same == modelPath {
+		return nil
+	}
+
+	// Copy to a temp file first so a failed import never leaves a partial model
+	tmpPath := modelPath + ".import"
+	dst, err := os.Create(tmpPath)
+	if err != nil {
+		return fmt.Errorf("failed to create model file: %w", err)
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to copy model: %w", err)
+	}
+	if err := dst.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to copy model: %w", err)
+	}
+	if err := os.Rename(tmpPath, modelPath); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to save model: %w", err)
+	}
+
+	fmt.Printf("[Whisper] Imported %s model from %s\n", modelName, srcPath)
+	return nil
 }
 
-// DownloadModelWithContext downloads the specified model with cancellation support
+// VerifyModel checks a downloaded model against its known SHA-256. This reads
+// the whole file, so it is slower than IsModelDownloaded.
+func (s *Service) VerifyModel(modelSize string) error {
+	modelsDir, err := GetModelsDir()
+	if err != nil {
+		return err
+	}
+	modelPath := filepath.Join(modelsDir, modelFileName(modelSize))
+	if _, err := os.Stat(modelPath); err != nil {
+		return fmt.Errorf("model %s is not downloaded", modelSize)
+	}
+	if err := checkModelHeader(modelPath); err != nil {
+		return err
+	}
+	return verifyChecksum(modelPath, modelSize)
+}
+
+// verifyChecksum compares the SHA-256 of path with the known hash for modelSize.
+// Models without a known hash pass.
+func verifyChecksum(path, modelSize string) error {
+	expected, ok := modelChecksums[modelSize]
+	if !ok {
+		return nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open model for verification: %w", err)
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return fmt.Errorf("failed to hash model: %w", err)
+	}
+	if actual := hex.EncodeToString(hash.Sum(nil)); actual != expected {
+		return fmt.Errorf("%w: model %s failed checksum verification (got sha256 %s, expected %s); the file is corrupt or incomplete, please download it again", ErrModelCorrupt, modelSize, actual, expected)
+	}
+	return nil
+}
+
+// DownloadModelWithContext downloads the specified model with cancellation support.
+// If no bytes arrive for the configured stall timeout, the attempt is abandoned and retried.
 func (s *Service) DownloadModelWithContext(ctx context.Context, modelSize string, progress ProgressCallback) error {
 	url, ok := modelURLs[modelSize]
 	if !ok {
@@ -208,7 +461,7 @@ func (s *Service) DownloadModelWithContext(ctx context.Context, modelSize string
 		return err
 	}
 
-	modelPath := filepath.Join(modelsDir, fmt.Sprintf("ggml-%s.bin", modelSize))
+	modelPath := filepath.Join(modelsDir, modelFileName(modelSize))
 
 	// Check if already exists and has correct size
 	if info, err := os.Stat(modelPath); err == nil {
@@ -218,45 +471,166 @@ func (s *Service) DownloadModelWithContext(ctx context.Context, modelSize string
 		}
 	}
 
+	tempPath := modelPath + ".tmp"
+
+	var bytesWritten int64
+	for attempt := 1; ; attempt++ {
+		bytesWritten, err = s.downloadAttempt(ctx, url, tempPath, modelSize, progress)
+		if err == errResumeRejected && attempt < maxStallRetries {
+			fmt.Println("[Whisper] Server rejected resume, restarting download")
+			continue
+		}
+		if err == errDownloadStalled && attempt < maxStallRetries {
+			fmt.Printf("[Whisper] Download stalled, retrying (%d/%d)...\n", attempt, maxStallRetries)
+			if s.OnDownloadStall != nil {
+				s.OnDownloadStall(modelSize, attempt)
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		break
+	}
+
+	// Verify downloaded size
+	expectedSize := modelSizes[modelSize]
+	minSize := int64(float64(expectedSize) * 0.95)
+	if bytesWritten < minSize {
+		os.Remove(tempPath)
+		return fmt.Errorf("download incomplete: got %d bytes, expected at least %d bytes", bytesWritten, minSize)
+	}
+
+	if err := verifyChecksum(tempPath, modelSize); err != nil {
+		os.Remove(tempPath)
+		return err
+	}
+
+	// Rename temp file to final name
+	if err := os.Rename(tempPath, modelPath); err != nil {
+		os.Remove(tempPath)
+		return fmt.Errorf("failed to finalize model file: %w", err)
+	}
+
+	fmt.Printf("[Whisper] Model %s downloaded successfully (%d bytes)\n", modelSize, bytesWritten)
+	return nil
+}
+
+// SetStallTimeout sets how long a download may go without receiving data
+// before it is retried. Zero disables stall detection.
+func (s *Service) SetStallTimeout(timeout time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stallTimeout = timeout
+}
+
+// downloadAttempt performs a single download of url into tempPath
+func (s *Service) downloadAttempt(ctx context.Context, url, tempPath, modelSize string, progress ProgressCallback) (int64, error) {
+	s.mu.RLock()
+	stallTimeout := s.stallTimeout
+	s.mu.RUnlock()
+
+	// Per-attempt context so a stalled attempt can be abandoned without cancelling the whole download
+	attemptCtx, cancelAttempt := context.WithCancel(ctx)
+	defer cancelAttempt()
+
+	var lastProgress atomic.Int64
+	var stalled atomic.Bool
+	lastProgress.Store(time.Now().UnixNano())
+
+	if stallTimeout > 0 {
+		go func() {
+			ticker := time.NewTicker(time.Second)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-attemptCtx.Done():
+					return
+				case <-ticker.C:
+					if time.Since(time.Unix(0, lastProgress.Load())) > stallTimeout {
+						stalled.Store(true)
+						cancelAttempt()
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	// Resume from a partial file left by an earlier attempt
+	var offset int64
+	if info, err := os.Stat(tempPath); err == nil {
+		offset = info.Size()
+	}
+
 	// Create HTTP request with context for cancellation
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	req, err := http.NewRequestWithContext(attemptCtx, "GET", url, nil)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
 	}
 
 	client := &http.Client{}
 	resp, err := client.Do(req)
 	if err != nil {
+		if stalled.Load() {
+			return 0, errDownloadStalled
+		}
 		if ctx.Err() == context.Canceled {
-			return fmt.Errorf("download cancelled")
+			return 0, fmt.Errorf("download cancelled")
 		}
-		return fmt.Errorf("failed to download model: %w", err)
+		return 0, fmt.Errorf("failed to download model: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to download model: HTTP %d", resp.StatusCode)
+	var file *os.File
+	totalSize := resp.ContentLength
+	switch {
+	case resp.StatusCode == http.StatusPartialContent:
+		start, total, ok := parseContentRange(resp.Header.Get("Content-Range"))
+		if !ok || start != offset {
+			// Not the bytes that were asked for; appending them would corrupt the file
+			os.Remove(tempPath)
+			return 0, errResumeRejected
+		}
+		if offset > 0 {
+			fmt.Printf("[Whisper] Resuming %s download at %d bytes\n", modelSize, offset)
+		}
+		if total > 0 {
+			totalSize = total
+		} else if totalSize > 0 {
+			totalSize += offset
+		}
+		file, err = os.OpenFile(tempPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	case resp.StatusCode == http.StatusOK:
+		// Server ignored the range (or there was nothing to resume): start over
+		offset = 0
+		file, err = os.Create(tempPath)
+	case resp.StatusCode == http.StatusRequestedRangeNotSatisfiable:
+		// The partial file doesn't fit the remote one; discard it and retry
+		os.Remove(tempPath)
+		return 0, errResumeRejected
+	default:
+		return 0, fmt.Errorf("failed to download model: HTTP %d", resp.StatusCode)
 	}
-
-	// Create temporary file
-	tempPath := modelPath + ".tmp"
-	file, err := os.Create(tempPath)
 	if err != nil {
-		return fmt.Errorf("failed to create temp file: %w", err)
+		return 0, fmt.Errorf("failed to open temp file: %w", err)
 	}
 
-	totalSize := resp.ContentLength
 	if totalSize <= 0 {
 		totalSize = modelSizes[modelSize]
 	}
-	var downloaded int64
+	downloaded := offset
 
 	// Create a cancellable reader
 	reader := &cancellableProgressReader{
-		ctx:    ctx,
+		ctx:    attemptCtx,
 		reader: resp.Body,
 		onProgress: func(n int64) {
 			downloaded += n
+			lastProgress.Store(time.Now().UnixNano())
 			if progress != nil {
 				progress(downloaded, totalSize)
 			}
@@ -268,35 +642,52 @@ func (s *Service) DownloadModelWithContext(ctx context.Context, modelSize string
 	file.Close()
 
 	if err != nil {
-		os.Remove(tempPath)
+		// Keep the partial file so the next attempt can resume, unless cancelled
+		if stalled.Load() {
+			return 0, errDownloadStalled
+		}
 		if ctx.Err() == context.Canceled {
-			return fmt.Errorf("download cancelled")
+			os.Remove(tempPath)
+			return 0, fmt.Errorf("download cancelled")
 		}
-		return fmt.Errorf("failed to save model: %w", err)
+		return 0, fmt.Errorf("failed to save model: %w", err)
 	}
 
 	// Check if cancelled during download
 	if ctx.Err() == context.Canceled {
 		os.Remove(tempPath)
-		return fmt.Errorf("download cancelled")
+		return 0, fmt.Errorf("download cancelled")
 	}
 
-	// Verify downloaded size
-	expectedSize := modelSizes[modelSize]
-	minSize := int64(float64(expectedSize) * 0.95)
-	if bytesWritten < minSize {
-		os.Remove(tempPath)
-		return fmt.Errorf("download incomplete: got %d bytes, expected at least %d bytes", bytesWritten, minSize)
-	}
+	return offset + bytesWritten, nil
+}
 
-	// Rename temp file to final name
-	if err := os.Rename(tempPath, modelPath); err != nil {
-		os.Remove(tempPath)
-		return fmt.Errorf("failed to finalize model file: %w", err)
+// parseContentRange returns the first byte and the complete length from a
+// "bytes start-end/total" Content-Range header. total is 0 if unknown ("*");
+// ok is false if the header is missing or malformed.
+func parseContentRange(header string) (start, total int64, ok bool) {
+	spec, found := strings.CutPrefix(header, "bytes ")
+	if !found {
+		return 0, 0, false
 	}
-
-	fmt.Printf("[Whisper] Model %s downloaded successfully (%d bytes)\n", modelSize, bytesWritten)
-	return nil
+	byteRange, length, found := strings.Cut(spec, "/")
+	if !found {
+		return 0, 0, false
+	}
+	first, _, found := strings.Cut(byteRange, "-")
+	if !found {
+		return 0, 0, false
+	}
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil || start < 0 {
+		return 0, 0, false
+	}
+	if length != "*" {
+		if total, err = strconv.ParseInt(length, 10, 64); err != nil {
+			return 0, 0, false
+		}
+	}
+	return start, total, true
 }
 
 // cancellableProgressReader wraps an io.Reader with cancellation and progress
@@ -338,7 +729,7 @@ func (s *Service) downloadModelLegacy(modelSize string, progress ProgressCallbac
 		return err
 	}
 
-	modelPath := filepath.Join(modelsDir, fmt.Sprintf("ggml-%s.bin", modelSize))
+	modelPath := filepath.Join(modelsDir, modelFileName(modelSize))
 
 	// Check if already exists and has correct size
 	if info, err := os.Stat(modelPath); err == nil {
@@ -405,6 +796,11 @@ func (s *Service) downloadModelLegacy(modelSize string, progress ProgressCallbac
 		return fmt.Errorf("download incomplete: got %d bytes, expected at least %d bytes", bytesWritten, minSize)
 	}
 
+	if err := verifyChecksum(tempPath, modelSize); err != nil {
+		os.Remove(tempPath)
+		return err
+	}
+
 	// Rename temp file to final name (atomic operation)
 	if err := os.Rename(tempPath, modelPath); err != nil {
 		os.Remove(tempPath)
@@ -415,7 +811,11 @@ func (s *Service) downloadModelLegacy(modelSize string, progress ProgressCallbac
 	return nil
 }
 
-// CleanupPartialDownloads removes any stale .tmp files from failed downloads
+// partialDownloadMaxAge is how long a partial download is kept for resuming
+const partialDownloadMaxAge = 7 * 24 * time.Hour
+
+// CleanupPartialDownloads removes .tmp files from failed downloads that are too
+// old to resume
 func CleanupPartialDownloads() error {
 	modelsDir, err := GetModelsDir()
 	if err != nil {
@@ -429,6 +829,9 @@ func CleanupPartialDownloads() error {
 
 	for _, entry := range entries {
 		if strings.HasSuffix(entry.Name(), ".tmp") {
+			if info, err := entry.Info(); err == nil && time.Since(info.ModTime()) < partialDownloadMaxAge {
+				continue // Recent enough to resume
+			}
 			tmpPath := filepath.Join(modelsDir, entry.Name())
 			fmt.Printf("[Whisper] Cleaning up partial download: %s\n", entry.Name())
 			os.Remove(tmpPath)
@@ -447,12 +850,15 @@ func (s *Service) LoadModel(modelSize string) error {
 		return err
 	}
 
-	modelPath := filepath.Join(modelsDir, fmt.Sprintf("ggml-%s.bin", modelSize))
+	modelPath := filepath.Join(modelsDir, modelFileName(modelSize))
 
 	// Check if model exists
 	if _, err := os.Stat(modelPath); os.IsNotExist(err) {
 		return fmt.Errorf("model not found: %s. Please download it first", modelPath)
 	}
+	if err := checkModelHeader(modelPath); err != nil {
+		return err
+	}
 
 	s.modelSize = modelSize
 	s.modelPath = modelPath
@@ -463,21 +869,49 @@ func (s *Service) LoadModel(modelSize string) error {
 
 // Transcribe transcribes the given WAV file using whisper.cpp CLI
 func (s *Service) Transcribe(wavPath string) (string, error) {
+	return s.TranscribeContext(context.Background(), wavPath)
+}
+
+// TranscribeContext is Transcribe, killing whisper if ctx is cancelled
+func (s *Service) TranscribeContext(ctx context.Context, wavPath string) (string, error) {
+	text, _, err := s.TranscribeSegments(ctx, wavPath)
+	return text, err
+}
+
+// TranscribeSegments transcribes wavPath with the configured language and
+// also returns the timestamped segments (nil if whisper didn't report any)
+func (s *Service) TranscribeSegments(ctx context.Context, wavPath string) (string, []Segment, error) {
+	s.mu.RLock()
+	language := s.language
+	s.mu.RUnlock()
+	return s.TranscribeWithSegments(ctx, wavPath, language)
+}
+
+// TranscribeWithLanguage transcribes wavPath forcing the given whisper language
+// code (e.g. "en", "de"); an empty language uses the CLI default
+func (s *Service) TranscribeWithLanguage(wavPath, language string) (string, error) {
+	text, _, err := s.TranscribeWithSegments(context.Background(), wavPath, language)
+	return text, err
+}
+
+// TranscribeWithSegments is TranscribeWithLanguage that also returns the
+// timestamped segments, and stops whisper if ctx is cancelled
+func (s *Service) TranscribeWithSegments(ctx context.Context, wavPath, language string) (string, []Segment, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	if !s.loaded {
-		return "", fmt.Errorf("model not loaded")
+		return "", nil, fmt.Errorf("model not loaded")
 	}
 
 	// First, try to use whisper.cpp binary if available
 	whisperBin := s.findWhisperBinary()
 	if whisperBin != "" {
-		return s.transcribeWithCLI(whisperBin, wavPath)
+		return s.transcribeChunked(ctx, whisperBin, s.modelPath, wavPath, language)
 	}
 
-	// Fall back to using go-whisper (if we can build it)
-	return s.transcribeWithGoWhisper(wavPath)
+	// Fall back to the whisper.cpp Go bindings (if built with them)
+	return s.transcribeWithGoWhisper(ctx, wavPath, language)
 }
 
 // findWhisperBinary looks for whisper.cpp binary
@@ -516,49 +950,283 @@ func (s *Service) findWhisperBinary() string {
 	return ""
 }
 
+// Decoding parameter bounds
+const (
+	DefaultBeamSize = 5
+	MaxBeamSize     = 16
+)
+
+// SetDecoding sets the beam size and sampling temperature passed to whisper-cli.
+// Larger beam sizes are more accurate but slower.
+func (s *Service) SetDecoding(beamSize int, temperature float64) error {
+	if beamSize < 1 || beamSize > MaxBeamSize {
+		return fmt.Errorf("beam size must be between 1 and %d", MaxBeamSize)
+	}
+	if temperature < 0 || temperature > 1 {
+		return fmt.Errorf("temperature must be between 0 and 1")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.beamSize = beamSize
+	s.temperature = temperature
+	return nil
+}
+
+// SetTranslate sets whether whisper translates speech to English.
+// Works with any language setting, including auto-detect.
+func (s *Service) SetTranslate(translate bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.translate = translate
+}
+
+// SetThreads sets how many CPU threads whisper-cli uses, clamped to at least 1
+func (s *Service) SetThreads(threads int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.threads = max(threads, 1)
+}
+
+// maxPromptChars keeps the initial prompt within whisper's prompt budget of
+// 224 tokens (half the text context), assuming about 3 characters per token
+// for names and jargon
+const maxPromptChars = 600
+
+// TrimPrompt shortens prompt to fit whisper's context at a word boundary and
+// reports whether anything was cut
+func TrimPrompt(prompt string) (string, bool) {
+	prompt = strings.Join(strings.Fields(prompt), " ")
+	if len(prompt) <= maxPromptChars {
+		return prompt, false
+	}
+	// Back off to a rune boundary so multi-byte text isn't split mid-character
+	end := maxPromptChars
+	for end > 0 && !utf8.RuneStart(prompt[end]) {
+		end--
+	}
+	cut := prompt[:end]
+	if i := strings.LastIndex(cut, " "); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " ,;"), true
+}
+
+// SetPrompt sets the initial prompt used to bias whisper towards domain terms,
+// such as product names. It is a hint, not a guarantee: whisper may still
+// transcribe a term differently. The prompt is trimmed to fit the context.
+func (s *Service) SetPrompt(prompt string) {
+	prompt, _ = TrimPrompt(prompt)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prompt = prompt
+}
+
+// decodingArgs returns the whisper-cli flags for the configured decoding options
+func (s *Service) decodingArgs() []string {
+	var args []string
+	if s.prompt != "" {
+		args = append(args, "--prompt", s.prompt)
+	}
+	if s.beamSize > 0 {
+		args = append(args, "-bs", fmt.Sprintf("%d", s.beamSize))
+	}
+	args = append(args, "-tp", fmt.Sprintf("%.2f", s.temperature))
+	if s.threads > 0 {
+		args = append(args, "-t", fmt.Sprintf("%d", s.threads))
+	}
+	return args
+}
+
 // transcribeWithCLI uses the whisper.cpp CLI
-func (s *Service) transcribeWithCLI(whisperBin, wavPath string) (string, error) {
-	// Create a temp file for output
+// progress, if set, receives whisper-cli's progress percentage.
+func (s *Service) transcribeWithCLI(ctx context.Context, whisperBin, modelPath, wavPath, language string, progress func(percent int)) (string, []Segment, error) {
+	// Create temp files for output; the JSON carries segment timestamps
 	outputPath := wavPath + ".txt"
+	jsonPath := wavPath + ".json"
 	defer os.Remove(outputPath)
+	defer os.Remove(jsonPath)
 
 	// Run whisper CLI
-	cmd := exec.Command(whisperBin,
-		"-m", s.modelPath,
+	args := []string{
+		"-m", modelPath,
 		"-f", wavPath,
 		"-otxt",
-		"--no-timestamps",
-		"-of", strings.TrimSuffix(outputPath, ".txt"),
-	)
+		"-oj",
+		"-of", wavPath,
+	}
+	args = append(args, s.decodingArgs()...)
+	if language != "" {
+		args = append(args, "-l", language)
+	}
+	if s.translate {
+		args = append(args, "-tr")
+	}
+	if progress != nil {
+		args = append(args, "--print-progress")
+	}
+	cmd := exec.CommandContext(ctx, whisperBin, args...)
 
-	output, err := cmd.CombinedOutput()
+	output, stderr, err := runWithProgress(cmd, progress)
+	if ctx.Err() != nil {
+		return "", nil, fmt.Errorf("transcription cancelled: %w", ctx.Err())
+	}
 	if err != nil {
-		return "", fmt.Errorf("whisper CLI failed: %w, output: %s", err, string(output))
+		if isModelLoadFailure(stderr) {
+			if checkErr := checkModelFile(modelPath); errors.Is(checkErr, ErrModelCorrupt) {
+				return "", nil, checkErr
+			}
+			return "", nil, fmt.Errorf("%w: whisper CLI failed: %v, output: %s%s", ErrModelLoad, err, output, stderr)
+		}
+		return "", nil, fmt.Errorf("whisper CLI failed: %w, output: %s%s", err, output, stderr)
+	}
+
+	segments, err := readSegmentsJSON(jsonPath)
+	if err != nil {
+		fmt.Printf("[Whisper] No segment timestamps: %v\n", err)
+		segments = nil
 	}
 
 	// Read the output file
 	content, err := os.ReadFile(outputPath)
 	if err != nil {
+		if segments != nil {
+			return segmentsText(segments), segments, nil
+		}
 		// Try to parse from stdout
-		return strings.TrimSpace(string(output)), nil
+		return stripStdoutTimestamps(output), nil, nil
 	}
 
-	return strings.TrimSpace(string(content)), nil
+	return strings.TrimSpace(string(content)), segments, nil
 }
 
-// transcribeWithGoWhisper uses the Go whisper bindings
-// This is a fallback that requires building whisper.cpp
-func (s *Service) transcribeWithGoWhisper(wavPath string) (string, error) {
-	// Read WAV file and convert to samples
-	samples, err := readWavFile(wavPath)
+// progressLine matches whisper-cli's "whisper_print_progress_callback: progress =  42%"
+var progressLine = regexp.MustCompile(`progress =\s*(\d+)%`)
+
+// runWithProgress runs cmd, returning its stdout and stderr. stderr is read
+// line by line as it arrives and progress lines are passed to progress;
+// stdout is buffered separately so neither pipe can fill up and block.
+func runWithProgress(cmd *exec.Cmd, progress func(percent int)) (string, string, error) {
+	var stdout strings.Builder
+	cmd.Stdout = &stdout
+	stderrPipe, err := cmd.StderrPipe()
 	if err != nil {
-		return "", fmt.Errorf("failed to read WAV file: %w", err)
+		return "", "", err
+	}
+	if err := cmd.Start(); err != nil {
+		return "", "", err
+	}
+
+	var stderr strings.Builder
+	scanner := bufio.NewScanner(stderrPipe)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if m := progressLine.FindStringSubmatch(line); m != nil {
+			if progress != nil {
+				if percent, err := strconv.Atoi(m[1]); err == nil {
+					progress(min(percent, 100))
+				}
+			}
+			continue
+		}
+		stderr.WriteString(line)
+		stderr.WriteByte('\n')
+	}
+	// Keep draining if a line was too long for the scanner, so whisper never
+	// blocks writing to a full pipe before Wait closes it
+	io.Copy(io.Discard, stderrPipe)
+
+	err = cmd.Wait()
+	return stdout.String(), stderr.String(), err
+}
+
+// BenchmarkResult holds the outcome of transcribing a sample clip with one model
+type BenchmarkResult struct {
+	Model      string `json:"model"`
+	DurationMs int64  `json:"duration_ms"`
+	Text       string `json:"text"`
+	Error      string `json:"error,omitempty"`
+}
+
+// benchmarkSample is the built-in benchmark clip: 11 seconds of 16kHz mono
+// speech from the whisper.cpp samples ("And so my fellow Americans, ask not
+// what your country can do for you, ask what you can do for your country.")
+//
+//go:embed samples/jfk.wav
+var benchmarkSample []byte
+
+// BenchmarkProgressCallback is called after each model finishes its benchmark run
+type BenchmarkProgressCallback func(done, total int, result BenchmarkResult)
+
+// BenchmarkModels transcribes the same clip with every downloaded model and
+// records how long each took, so users can compare speed and accuracy.
+// An empty wavPath uses the built-in clip. The currently loaded model is not affected.
+func (s *Service) BenchmarkModels(ctx context.Context, wavPath string, progress BenchmarkProgressCallback) ([]BenchmarkResult, error) {
+	if wavPath == "" {
+		f, err := os.CreateTemp("", "voxflow_benchmark_*.wav")
+		if err != nil {
+			return nil, fmt.Errorf("failed to write built-in sample clip: %w", err)
+		}
+		defer os.Remove(f.Name())
+		_, err = f.Write(benchmarkSample)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to write built-in sample clip: %w", err)
+		}
+		wavPath = f.Name()
+	} else if _, err := os.Stat(wavPath); err != nil {
+		return nil, fmt.Errorf("sample clip not found: %w", err)
+	}
+
+	whisperBin := s.findWhisperBinary()
+	if whisperBin == "" {
+		return nil, fmt.Errorf("whisper CLI binary not found. Please install via: brew install whisper-cpp")
+	}
+
+	models, err := s.GetAllModels()
+	if err != nil {
+		return nil, err
+	}
+
+	var downloaded []ModelInfo
+	for _, m := range models {
+		if m.Downloaded {
+			downloaded = append(downloaded, m)
+		}
+	}
+	if len(downloaded) == 0 {
+		return nil, fmt.Errorf("no models downloaded")
+	}
+
+	results := []BenchmarkResult{}
+	for i, m := range downloaded {
+		if ctx.Err() != nil {
+			return results, fmt.Errorf("benchmark cancelled")
+		}
+
+		start := time.Now()
+		s.mu.RLock()
+		text, _, err := s.transcribeWithCLI(ctx, whisperBin, m.FilePath, wavPath, "", nil)
+		s.mu.RUnlock()
+		result := BenchmarkResult{
+			Model:      m.Name,
+			DurationMs: time.Since(start).Milliseconds(),
+			Text:       text,
+		}
+		if err != nil {
+			result.Error = err.Error()
+		}
+		fmt.Printf("[Whisper] Benchmark %s: %dms\n", m.Name, result.DurationMs)
+
+		results = append(results, result)
+		if progress != nil {
+			progress(i+1, len(downloaded), result)
+		}
 	}
 
-	// For now, return a message indicating CLI is needed
-	// In production, we would use the whisper.cpp Go bindings here
-	_ = samples
-	return "", fmt.Errorf("whisper CLI binary not found. Please install whisper.cpp or provide the binary at ~/.voxflow/bin/whisper-cli")
+	return results, nil
 }
 
 // Close closes the service
@@ -566,6 +1234,7 @@ func (s *Service) Close() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.loaded = false
+	closeGoWhisper()
 	return nil
 }
 
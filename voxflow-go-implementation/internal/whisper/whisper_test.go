@@ -0,0 +1,275 @@
+package whisper
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"voxflow/internal/audio"
+)
+
+func TestParseContentRange(t *testing.T) {
+	tests := []struct {
+		header    string
+		wantStart int64
+		wantTotal int64
+		wantOK    bool
+	}{
+		{"bytes 100-999/1000", 100, 1000, true},
+		{"bytes 0-0/1", 0, 1, true},
+		{"bytes 100-999/*", 100, 0, true},
+		{"bytes 100-999", 0, 0, false},
+		{"bytes x-999/1000", 0, 0, false},
+		{"bytes 100-999/big", 0, 0, false},
+		{"items 0-9/10", 0, 0, false},
+		{"", 0, 0, false},
+	}
+	for _, tt := range tests {
+		start, total, ok := parseContentRange(tt.header)
+		if start != tt.wantStart || total != tt.wantTotal || ok != tt.wantOK {
+			t.Errorf("parseContentRange(%q) = (%d, %d, %v), want (%d, %d, %v)",
+				tt.header, start, total, ok, tt.wantStart, tt.wantTotal, tt.wantOK)
+		}
+	}
+}
+
+func TestDownloadAttemptResume(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 100)
+
+	// Serves content, honoring Range requests like the model host does
+	ranged := func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, "model.bin", time.Time{}, bytes.NewReader(content))
+	}
+	// Ignores Range and always sends the whole file
+	whole := func(w http.ResponseWriter, r *http.Request) {
+		w.Write(content)
+	}
+	// Always answers 206 with the whole file, even for a plain request
+	partialFromZero := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes 0-%d/%d", len(content)-1, len(content)))
+		w.WriteHeader(http.StatusPartialContent)
+		w.Write(content)
+	}
+
+	tests := []struct {
+		name      string
+		handler   http.HandlerFunc
+		partial   []byte // Left in the .tmp file by an earlier attempt
+		wantErr   error
+		wantFirst int64 // Bytes reported by the first progress callback, at least
+	}{
+		{"fresh download", ranged, nil, nil, 1},
+		{"resumes a partial file", ranged, content[:300], nil, 301},
+		{"restarts when the range is ignored", whole, content[:300], nil, 1},
+		{"accepts 206 for a fresh download", partialFromZero, nil, nil, 1},
+		{"rejects 206 from the wrong offset", partialFromZero, content[:300], errResumeRejected, 0},
+		{"discards a partial file longer than the model", ranged, append(content, 'x'), errResumeRejected, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			tempPath := filepath.Join(t.TempDir(), "model.bin.tmp")
+			if tt.partial != nil {
+				if err := os.WriteFile(tempPath, tt.partial, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var first, total int64
+			progress := func(downloaded, size int64) {
+				if first == 0 {
+					first = downloaded
+				}
+				total = size
+			}
+			s := &Service{}
+			n, err := s.downloadAttempt(context.Background(), server.URL, tempPath, "base", progress)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("downloadAttempt error = %v, want %v", err, tt.wantErr)
+				}
+				if _, err := os.Stat(tempPath); !os.IsNotExist(err) {
+					t.Errorf("partial file was kept after %v", tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("downloadAttempt: %v", err)
+			}
+
+			got, err := os.ReadFile(tempPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, content) {
+				t.Errorf("downloaded %d bytes that don't match the %d-byte source", len(got), len(content))
+			}
+			if n != int64(len(content)) {
+				t.Errorf("downloadAttempt returned %d bytes, want %d", n, len(content))
+			}
+			if first < tt.wantFirst || first > int64(len(content)) {
+				t.Errorf("first progress = %d, want between %d and %d", first, tt.wantFirst, len(content))
+			}
+			if total != int64(len(content)) {
+				t.Errorf("progress total = %d, want %d", total, len(content))
+			}
+		})
+	}
+}
+
+func TestBenchmarkSample(t *testing.T) {
+	if len(benchmarkSample) <= 44 || string(benchmarkSample[0:4]) != "RIFF" || string(benchmarkSample[8:12]) != "WAVE" {
+		t.Fatalf("built-in benchmark clip is not a WAV file (%d bytes)", len(benchmarkSample))
+	}
+	channels := binary.LittleEndian.Uint16(benchmarkSample[22:24])
+	sampleRate := binary.LittleEndian.Uint32(benchmarkSample[24:28])
+	if channels != 1 || sampleRate != 16000 {
+		t.Errorf("built-in benchmark clip is %d channels at %dHz, want mono 16kHz", channels, sampleRate)
+	}
+}
+
+func TestTrimPrompt(t *testing.T) {
+	words := strings.Repeat("word ", maxPromptChars/5+10)
+	cjk := "a" + strings.Repeat("語", maxPromptChars) // 3 bytes each, off the cut, no spaces
+
+	tests := []struct {
+		name    string
+		prompt  string
+		wantCut bool
+	}{
+		{"short", "Kubernetes, Terraform", false},
+		{"collapses whitespace", "  Kubernetes \n\t Terraform ", false},
+		{"cuts at a word", words, true},
+		{"cuts multi-byte text without spaces", cjk, true},
+		{"cuts after a multi-byte word", strings.Repeat("x", maxPromptChars-1) + "é tail", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, cut := TrimPrompt(tt.prompt)
+			if cut != tt.wantCut {
+				t.Errorf("TrimPrompt cut = %v, want %v", cut, tt.wantCut)
+			}
+			if len(got) > maxPromptChars {
+				t.Errorf("TrimPrompt returned %d bytes, want at most %d", len(got), maxPromptChars)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("TrimPrompt returned invalid UTF-8: %q", got[max(len(got)-8, 0):])
+			}
+			if !strings.HasPrefix(strings.Join(strings.Fields(tt.prompt), " "), got) {
+				t.Errorf("TrimPrompt returned %q, not a prefix of the prompt", got)
+			}
+		})
+	}
+}
+
+func TestReadWavFormat(t *testing.T) {
+	dir := t.TempDir()
+	samples := []int16{1, -1, 2, -2, 3, -3}
+
+	t.Run("round trip", func(t *testing.T) {
+		path := filepath.Join(dir, "stereo.wav")
+		want := wavFormat{sampleRate: 44100, channels: 2, bitsPerSample: 16}
+		if err := writeWavSamples(path, samples, want); err != nil {
+			t.Fatal(err)
+		}
+		got, err := readWavFormat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.sampleRate != want.sampleRate || got.channels != want.channels || got.bitsPerSample != want.bitsPerSample {
+			t.Errorf("format = %+v, want %+v", got, want)
+		}
+		read, err := readWavSamples(path, got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fmt.Sprint(read) != fmt.Sprint(samples) {
+			t.Errorf("samples = %v, want %v", read, samples)
+		}
+	})
+
+	t.Run("skips extra chunks", func(t *testing.T) {
+		var buf bytes.Buffer
+		buf.WriteString("RIFF")
+		binary.Write(&buf, binary.LittleEndian, uint32(0))
+		buf.WriteString("WAVE")
+		buf.WriteString("fmt ")
+		binary.Write(&buf, binary.LittleEndian, uint32(18)) // Extended fmt chunk
+		binary.Write(&buf, binary.LittleEndian, []uint16{1, 1})
+		binary.Write(&buf, binary.LittleEndian, []uint32{48000, 96000})
+		binary.Write(&buf, binary.LittleEndian, []uint16{2, 16, 0})
+		buf.WriteString("LIST")
+		binary.Write(&buf, binary.LittleEndian, uint32(3)) // Odd size, padded
+		buf.Write([]byte{'a', 'b', 'c', 0})
+		buf.WriteString("data")
+		binary.Write(&buf, binary.LittleEndian, uint32(len(samples)*2))
+		binary.Write(&buf, binary.LittleEndian, samples)
+
+		path := filepath.Join(dir, "extra.wav")
+		if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+			t.Fatal(err)
+		}
+		got, err := readWavFormat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.sampleRate != 48000 || got.channels != 1 || got.dataSize != int64(len(samples)*2) {
+			t.Errorf("format = %+v, want mono 48kHz with %d data bytes", got, len(samples)*2)
+		}
+		read, err := readWavSamples(path, got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fmt.Sprint(read) != fmt.Sprint(samples) {
+			t.Errorf("samples = %v, want %v", read, samples)
+		}
+	})
+}
+
+// TestReadWavFileRoundTrip checks that a WAV written by the recorder's writer
+// reads back with the same format and samples
+func TestReadWavFileRoundTrip(t *testing.T) {
+	const freqHz = 440
+	path := filepath.Join(t.TempDir(), "tone.wav")
+	if err := audio.GenerateTone(path, freqHz, time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	format, err := readWavFormat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if format.sampleRate != audio.SampleRate || format.channels != audio.Channels || format.bitsPerSample != 16 {
+		t.Errorf("format = %+v, want %dHz, %d channel(s), 16-bit", format, audio.SampleRate, audio.Channels)
+	}
+	if format.dataOffset != 44 || format.dataSize != audio.SampleRate*2 {
+		t.Errorf("data chunk at %d with %d bytes, want 44 with %d", format.dataOffset, format.dataSize, audio.SampleRate*2)
+	}
+
+	samples, err := readWavFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(samples) != audio.SampleRate {
+		t.Fatalf("read %d samples, want %d", len(samples), audio.SampleRate)
+	}
+	for i, got := range samples {
+		want := int16(0.3 * math.MaxInt16 * math.Sin(2*math.Pi*freqHz*float64(i)/audio.SampleRate))
+		if got != float32(want)/32768.0 {
+			t.Fatalf("sample %d = %v, want %v", i, got, float32(want)/32768.0)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"voxflow/internal/hotkey"
+)
+
+// lastRecordingName is the temp file the last recording is kept in until it
+// processes successfully
+const lastRecordingName = "voxflow_last_recording.wav"
+
+// keepLastRecording moves wavPath to the last-recording temp file, replacing
+// any previous one, and returns where the audio now is
+func (a *App) keepLastRecording(wavPath string, duration time.Duration) string {
+	kept := filepath.Join(os.TempDir(), lastRecordingName)
+	if wavPath != kept {
+		if err := os.Rename(wavPath, kept); err != nil {
+			fmt.Printf("[App] Failed to keep last recording: %v\n", err)
+			kept = wavPath
+		}
+	}
+
+	a.lastRecordingMu.Lock()
+	defer a.lastRecordingMu.Unlock()
+	if a.lastRecording != "" && a.lastRecording != kept {
+		os.Remove(a.lastRecording)
+	}
+	a.lastRecording = kept
+	a.lastRecordingDuration = duration
+	return kept
+}
+
+// clearLastRecording deletes the kept last recording, if any
+func (a *App) clearLastRecording() {
+	a.lastRecordingMu.Lock()
+	defer a.lastRecordingMu.Unlock()
+	if a.lastRecording == "" {
+		return
+	}
+	if err := os.Remove(a.lastRecording); err != nil && !os.IsNotExist(err) {
+		fmt.Printf("[App] Failed to remove last recording: %v\n", err)
+	}
+	a.lastRecording = ""
+	a.lastRecordingDuration = 0
+}
+
+// HasLastRecording reports whether there is a recording RetryLastRecording can re-run
+func (a *App) HasLastRecording() bool {
+	a.lastRecordingMu.Lock()
+	defer a.lastRecordingMu.Unlock()
+	if a.lastRecording == "" {
+		return false
+	}
+	_, err := os.Stat(a.lastRecording)
+	return err == nil
+}
+
+// RetryLastRecording re-runs transcription and refinement on the last
+// recording, e.g. after the network or Gemini failed
+func (a *App) RetryLastRecording() error {
+	if a.state != hotkey.StateIdle {
+		return fmt.Errorf("cannot retry while recording or processing")
+	}
+	a.lastRecordingMu.Lock()
+	wavPath, duration := a.lastRecording, a.lastRecordingDuration
+	a.lastRecordingMu.Unlock()
+	if wavPath == "" {
+		return fmt.Errorf("no recording to retry")
+	}
+	if _, err := os.Stat(wavPath); err != nil {
+		return fmt.Errorf("last recording is no longer available: %w", err)
+	}
+
+	a.state = hotkey.StateProcessing
+	a.hotkeyManager.SetState(hotkey.StateProcessing)
+	a.emitEvent("state-changed", "Processing")
+	fmt.Printf("[App] Retrying last recording (%.1fs)\n", duration.Seconds())
+	quickNote := a.quickNote.Load() // The last recording's mode
+	a.startProcessing(func(ctx context.Context) {
+		a.processAudio(ctx, wavPath, duration, quickNote, time.Now())
+	})
+	return nil
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"voxflow/internal/config"
+)
+
+// latencyWindow is how many recent runs the rolling average covers
+const latencyWindow = 50
+
+// LatencyBreakdown is the average time spent in each pipeline stage
+type LatencyBreakdown struct {
+	AudioMs   float64 `json:"audio_ms"`
+	WhisperMs float64 `json:"whisper_ms"`
+	GeminiMs  float64 `json:"gemini_ms"`
+	TotalMs   float64 `json:"total_ms"`
+	Samples   int     `json:"samples"` // Runs included in the average
+}
+
+// latencySample is the timing of a single processRecording run
+type latencySample struct {
+	AudioMs   int64 `json:"audio_ms"`
+	WhisperMs int64 `json:"whisper_ms"`
+	GeminiMs  int64 `json:"gemini_ms"`
+	TotalMs   int64 `json:"total_ms"`
+}
+
+// latencyTracker keeps a bounded window of recent timings, persisted to disk
+type latencyTracker struct {
+	samples []latencySample
+	path    string
+	mu      sync.Mutex
+}
+
+// newLatencyTracker creates a tracker and loads previously persisted timings
+func newLatencyTracker() *latencyTracker {
+	t := &latencyTracker{}
+	configDir, err := config.GetConfigDir()
+	if err != nil {
+		fmt.Printf("[Latency] Could not resolve config dir: %v\n", err)
+		return t
+	}
+	t.path = filepath.Join(configDir, "latency.json")
+
+	data, err := os.ReadFile(t.path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			fmt.Printf("[Latency] Failed to read %s: %v\n", t.path, err)
+		}
+		return t
+	}
+	if err := json.Unmarshal(data, &t.samples); err != nil {
+		fmt.Printf("[Latency] Ignoring corrupt %s: %v\n", t.path, err)
+		t.samples = nil
+	}
+	if len(t.samples) > latencyWindow {
+		t.samples = t.samples[len(t.samples)-latencyWindow:]
+	}
+	return t
+}
+
+// Record adds a run's timings and persists the window
+func (t *latencyTracker) Record(audio, whisper, gemini, total time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.samples = append(t.samples, latencySample{
+		AudioMs:   audio.Milliseconds(),
+		WhisperMs: whisper.Milliseconds(),
+		GeminiMs:  gemini.Milliseconds(),
+		TotalMs:   total.Milliseconds(),
+	})
+	if len(t.samples) > latencyWindow {
+		t.samples = t.samples[len(t.samples)-latencyWindow:]
+	}
+
+	if t.path == "" {
+		return
+	}
+	data, err := json.Marshal(t.samples)
+	if err != nil {
+		fmt.Printf("[Latency] Failed to encode timings: %v\n", err)
+		return
+	}
+	if err := os.WriteFile(t.path, data, 0644); err != nil {
+		fmt.Printf("[Latency] Failed to save timings: %v\n", err)
+	}
+}
+
+// Average returns the rolling average over the current window
+func (t *latencyTracker) Average() LatencyBreakdown {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var avg LatencyBreakdown
+	if len(t.samples) == 0 {
+		return avg
+	}
+	for _, s := range t.samples {
+		avg.AudioMs += float64(s.AudioMs)
+		avg.WhisperMs += float64(s.WhisperMs)
+		avg.GeminiMs += float64(s.GeminiMs)
+		avg.TotalMs += float64(s.TotalMs)
+	}
+	n := float64(len(t.samples))
+	avg.AudioMs /= n
+	avg.WhisperMs /= n
+	avg.GeminiMs /= n
+	avg.TotalMs /= n
+	avg.Samples = len(t.samples)
+	return avg
+}
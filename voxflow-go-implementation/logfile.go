@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"voxflow/internal/config"
+)
+
+const (
+	logFileName    = "voxflow.log"
+	maxLogFileSize = 5 << 20 // Rotate to voxflow.log.1 past this size at startup
+)
+
+// logFilePath returns where the log file lives
+func logFilePath() (string, error) {
+	configDir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, logFileName), nil
+}
+
+// startFileLog tees stdout into the log file so diagnostics can include the
+// log tail. Lines printed while privacy mode is on only go to stdout.
+func (a *App) startFileLog() {
+	logPath, err := logFilePath()
+	if err != nil {
+		fmt.Printf("[App] File logging disabled: %v\n", err)
+		return
+	}
+	if info, err := os.Stat(logPath); err == nil && info.Size() > maxLogFileSize {
+		os.Rename(logPath, logPath+".1")
+	}
+	file, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		fmt.Printf("[App] File logging disabled: %v\n", err)
+		return
+	}
+	r, w, err := os.Pipe()
+	if err != nil {
+		file.Close()
+		fmt.Printf("[App] File logging disabled: %v\n", err)
+		return
+	}
+
+	stdout := os.Stdout
+	os.Stdout = w
+	go func() {
+		defer file.Close()
+		reader := bufio.NewReader(r)
+		for {
+			line, err := reader.ReadString('\n')
+			if line != "" {
+				io.WriteString(stdout, line)
+				if !a.privacyMode.Load() {
+					fmt.Fprintf(file, "%s %s", time.Now().Format("2006-01-02 15:04:05"), line)
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+}
+
+// ClearLog empties the log file and deletes the rotated one
+func (a *App) ClearLog() error {
+	logPath, err := logFilePath()
+	if err != nil {
+		return err
+	}
+	if err := os.Truncate(logPath, 0); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to clear log: %w", err)
+	}
+	if err := os.Remove(logPath + ".1"); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to clear log: %w", err)
+	}
+	fmt.Println("[App] Log cleared")
+	return nil
+}
@@ -17,6 +17,7 @@ var assets embed.FS
 func main() {
 	// Create an instance of the app structure
 	app := NewApp()
+	app.startFileLog()
 
 	// Create application menu
 	appMenu := menu.NewMenu()
@@ -29,6 +30,12 @@ func main() {
 	fileMenu.AddText("Toggle Recording", keys.CmdOrCtrl("r"), func(cd *menu.CallbackData) {
 		app.ToggleRecording()
 	})
+	fileMenu.AddText("Pause/Resume Recording", keys.CmdOrCtrl("p"), func(cd *menu.CallbackData) {
+		app.TogglePause()
+	})
+	app.refinementMenuItem = fileMenu.AddCheckbox("AI Refinement", app.config.GetRefinementEnabled(), keys.CmdOrCtrl("e"), func(cd *menu.CallbackData) {
+		app.SetRefinementEnabled(cd.MenuItem.Checked)
+	})
 	fileMenu.AddSeparator()
 	fileMenu.AddText("Open Full App", keys.CmdOrCtrl("o"), func(cd *menu.CallbackData) {
 		app.HideMiniMode()
@@ -50,9 +57,9 @@ func main() {
 	// Create application with options - Start as floating indicator
 	err := wails.Run(&options.App{
 		Title:             "voxflow",
-		Width:             200,
+		Width:             240,
 		Height:            60,
-		MinWidth:          200,
+		MinWidth:          240,
 		MinHeight:         60,
 		DisableResize:     true,
 		Frameless:         true,
@@ -64,6 +71,7 @@ func main() {
 		},
 		BackgroundColour: &options.RGBA{R: 0, G: 0, B: 0, A: 0}, // Transparent
 		OnStartup:        app.startup,
+		OnBeforeClose:    app.beforeClose,
 		OnShutdown:       app.shutdown,
 		Bind: []interface{}{
 			app,
@@ -80,7 +88,7 @@ func main() {
 				Title:   "voxflow",
 				Message: "AI-Powered Dictation App\n\nVersion 1.0.0",
 			},
-			Appearance:           mac.NSAppearanceNameDarkAqua,
+			Appearance:           macAppearance(app.config.GetAppearance()),
 			WebviewIsTransparent: true,
 			WindowIsTranslucent:  false,
 		},
@@ -91,3 +99,15 @@ func main() {
 		println("Error:", err.Error())
 	}
 }
+
+// macAppearance maps the configured appearance to the Wails mac option
+func macAppearance(appearance string) mac.AppearanceType {
+	switch appearance {
+	case "light":
+		return mac.NSAppearanceNameAqua
+	case "dark":
+		return mac.NSAppearanceNameDarkAqua
+	default:
+		return mac.DefaultAppearance
+	}
+}
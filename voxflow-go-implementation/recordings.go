@@ -0,0 +1,173 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"voxflow/internal/config"
+)
+
+// recordingsDir returns the directory archived recordings are kept in
+func (a *App) recordingsDir() (string, error) {
+	if dir := a.config.GetRecordingsDir(); dir != "" {
+		return dir, nil
+	}
+	configDir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "recordings"), nil
+}
+
+// recordingPath returns where the archived audio for a transcript is kept
+func (a *App) recordingPath(transcriptID int64) (string, error) {
+	dir, err := a.recordingsDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, fmt.Sprintf("%d.wav", transcriptID)), nil
+}
+
+// archiveRecording moves a processed recording into the recordings directory
+// under the transcript's ID, then applies the retention policy
+func (a *App) archiveRecording(wavPath string, transcriptID int64) error {
+	dest, err := a.recordingPath(transcriptID)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return fmt.Errorf("failed to create recordings directory: %w", err)
+	}
+
+	// Rename fails across volumes; fall back to copying
+	if err := os.Rename(wavPath, dest); err != nil {
+		data, err := os.ReadFile(wavPath)
+		if err != nil {
+			return fmt.Errorf("failed to read recording: %w", err)
+		}
+		if err := os.WriteFile(dest, data, 0644); err != nil {
+			return fmt.Errorf("failed to save recording: %w", err)
+		}
+	}
+
+	a.pruneRecordings()
+	return nil
+}
+
+// pruneRecordings deletes archived recordings beyond the configured count or age
+func (a *App) pruneRecordings() {
+	maxCount, maxDays := a.config.GetRecordingRetention()
+	if maxCount <= 0 && maxDays <= 0 {
+		return
+	}
+
+	dir, err := a.recordingsDir()
+	if err != nil {
+		return
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+
+	type recording struct {
+		path    string
+		modTime time.Time
+	}
+	var recordings []recording
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".wav") {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		recordings = append(recordings, recording{filepath.Join(dir, e.Name()), info.ModTime()})
+	}
+
+	// Newest first, so everything past the limits can be removed
+	sort.Slice(recordings, func(i, j int) bool {
+		return recordings[i].modTime.After(recordings[j].modTime)
+	})
+	cutoff := time.Now().AddDate(0, 0, -maxDays)
+	removed := 0
+	for i, rec := range recordings {
+		tooMany := maxCount > 0 && i >= maxCount
+		tooOld := maxDays > 0 && rec.modTime.Before(cutoff)
+		if !tooMany && !tooOld {
+			continue
+		}
+		if err := os.Remove(rec.path); err == nil {
+			removed++
+		}
+	}
+	if removed > 0 {
+		fmt.Printf("[Recordings] Pruned %d old recordings\n", removed)
+	}
+}
+
+// deleteRecordings removes the archived audio for the given transcripts
+func (a *App) deleteRecordings(ids []int64) {
+	for _, id := range ids {
+		path, err := a.recordingPath(id)
+		if err != nil {
+			fmt.Printf("[Recordings] Failed to locate recording %d: %v\n", id, err)
+			continue
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			fmt.Printf("[Recordings] Failed to delete %s: %v\n", path, err)
+		}
+	}
+}
+
+// GetRecordingPath returns the archived audio file for a transcript, for playback
+func (a *App) GetRecordingPath(transcriptID int64) (string, error) {
+	path, err := a.recordingPath(transcriptID)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(path); err != nil {
+		return "", fmt.Errorf("no saved audio for transcript %d", transcriptID)
+	}
+	return path, nil
+}
+
+// SetKeepRecordings sets whether recordings are archived with their transcripts
+func (a *App) SetKeepRecordings(keep bool) error {
+	a.config.SetKeepRecordings(keep)
+	return a.config.Save()
+}
+
+// SetRecordingsDir sets where recordings are archived ("" = ~/.voxflow/recordings).
+// Existing recordings are not moved.
+func (a *App) SetRecordingsDir(dir string) error {
+	if dir != "" {
+		if !filepath.IsAbs(dir) {
+			return fmt.Errorf("recordings directory must be an absolute path")
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create recordings directory: %w", err)
+		}
+	}
+	a.config.SetRecordingsDir(dir)
+	return a.config.Save()
+}
+
+// SetRecordingRetention keeps at most count recordings and none older than
+// days. 0 disables either limit.
+func (a *App) SetRecordingRetention(count, days int) error {
+	if count < 0 || days < 0 {
+		return fmt.Errorf("retention limits must not be negative")
+	}
+	a.config.SetRecordingRetention(count, days)
+	if err := a.config.Save(); err != nil {
+		return err
+	}
+	a.pruneRecordings()
+	return nil
+}
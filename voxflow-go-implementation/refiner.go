@@ -0,0 +1,192 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"voxflow/internal/gemini"
+	"voxflow/internal/openai"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// Refiner turns raw transcriptions into polished text. Implemented by the
+// Gemini and OpenAI-compatible clients.
+type Refiner interface {
+	RefineText(rawText string, mode string) (string, error)
+	RefineTextWithTone(ctx context.Context, rawText string, mode string) (string, string, gemini.Usage, error)
+	RetryWithInstruction(text string, instruction string) (string, error)
+}
+
+// backendLocal is recorded for tokens billed by an OpenAI-compatible server
+// on this machine, which cost nothing
+const backendLocal = "local"
+
+// refinerModel returns the backend and model whose prices apply to refiner's
+// tokens, for recording with them
+func (a *App) refinerModel(refiner Refiner) (string, string) {
+	switch r := refiner.(type) {
+	case *gemini.Client:
+		return BackendGemini, a.config.GetGeminiModel()
+	case *openai.Client:
+		_, model, _ := a.config.GetOpenAIEndpoint()
+		if r.IsLocal() {
+			return backendLocal, model
+		}
+		return BackendOpenAI, model
+	}
+	return "", ""
+}
+
+// MonthlyUsage is the refinement token usage shown in settings
+type MonthlyUsage struct {
+	Requests         int     `json:"requests"`
+	PromptTokens     int     `json:"prompt_tokens"`
+	OutputTokens     int     `json:"output_tokens"`
+	TotalTokens      int     `json:"total_tokens"`
+	EstimatedCostUSD float64 `json:"estimated_cost_usd"`
+	CostKnown        bool    `json:"cost_known"` // False if any tokens came from a model with an unknown price
+}
+
+// GetUsageThisMonth returns the refinement tokens used by transcripts this
+// calendar month, with a cost estimate at the price of the model that billed
+// each one. Local servers are free; other OpenAI-compatible APIs have no known
+// price. Transcripts saved before the model was recorded are priced at the
+// configured Gemini model.
+func (a *App) GetUsageThisMonth() (*MonthlyUsage, error) {
+	if a.historyService == nil {
+		return nil, fmt.Errorf("history service not available")
+	}
+	now := time.Now()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	entries, err := a.historyService.GetTokenUsageSince(monthStart)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token usage: %w", err)
+	}
+
+	monthly := &MonthlyUsage{CostKnown: true}
+	for _, e := range entries {
+		usage := gemini.Usage{
+			PromptTokens: e.PromptTokens,
+			OutputTokens: e.OutputTokens,
+			TotalTokens:  e.PromptTokens + e.OutputTokens,
+		}
+		monthly.Requests += e.Requests
+		monthly.PromptTokens += usage.PromptTokens
+		monthly.OutputTokens += usage.OutputTokens
+		monthly.TotalTokens += usage.TotalTokens
+
+		switch e.Backend {
+		case backendLocal:
+		case BackendGemini, "":
+			model := e.Model
+			if model == "" {
+				model = a.config.GetGeminiModel()
+			}
+			cost, known := gemini.EstimateCost(model, usage)
+			monthly.EstimatedCostUSD += cost
+			monthly.CostKnown = monthly.CostKnown && known
+		default:
+			monthly.CostKnown = false
+		}
+	}
+	return monthly, nil
+}
+
+// GetSessionUsage returns the tokens used by the active backend since launch
+func (a *App) GetSessionUsage() gemini.Usage {
+	if a.config.GetRefinementBackend() == BackendOpenAI {
+		return a.openaiClient.GetUsage()
+	}
+	return a.geminiClient.GetUsage()
+}
+
+// refineErrorMessage describes a refinement failure for a toast
+func refineErrorMessage(err error) string {
+	switch {
+	case errors.Is(err, gemini.ErrTimeout):
+		return "Refinement timed out — try a longer timeout in settings (" + err.Error() + ")"
+	case errors.Is(err, gemini.ErrNetwork):
+		return "Network error: " + err.Error() + " — check your connection or proxy"
+	}
+	return "Refinement error: " + err.Error()
+}
+
+// Refinement backends selectable in settings
+const (
+	BackendGemini = "gemini"
+	BackendOpenAI = "openai" // Any OpenAI-compatible API, e.g. Ollama or LM Studio
+)
+
+// passthroughRefiner returns transcriptions unchanged, for raw mode and when
+// refinement is turned off
+type passthroughRefiner struct{}
+
+func (passthroughRefiner) RefineText(rawText string, mode string) (string, error) {
+	return rawText, nil
+}
+
+func (passthroughRefiner) RefineTextWithTone(ctx context.Context, rawText string, mode string) (string, string, gemini.Usage, error) {
+	return rawText, "", gemini.Usage{}, nil
+}
+
+func (passthroughRefiner) RetryWithInstruction(text string, instruction string) (string, error) {
+	return "", fmt.Errorf("refinement is turned off")
+}
+
+// refinerFor returns the refiner to use for mode: the configured backend, or
+// a passthrough in raw mode or with refinement turned off
+func (a *App) refinerFor(mode string) Refiner {
+	if mode == gemini.RawMode || !a.config.GetRefinementEnabled() {
+		return passthroughRefiner{}
+	}
+	return a.refiner()
+}
+
+// refiner returns the client for the configured refinement backend
+func (a *App) refiner() Refiner {
+	if a.config.GetRefinementBackend() == BackendOpenAI {
+		return a.openaiClient
+	}
+	return a.geminiClient
+}
+
+// SetRefinementBackend selects which service refines transcriptions (gemini, openai)
+func (a *App) SetRefinementBackend(backend string) error {
+	if backend != BackendGemini && backend != BackendOpenAI {
+		return fmt.Errorf("unknown refinement backend: %s", backend)
+	}
+	a.config.SetRefinementBackend(backend)
+	return a.config.Save()
+}
+
+// SetOpenAIEndpoint configures the OpenAI-compatible backend. baseURL is the
+// API root, e.g. http://localhost:11434/v1 for Ollama ("" = that default);
+// apiKey may be empty for local servers.
+func (a *App) SetOpenAIEndpoint(baseURL, model, apiKey string) error {
+	baseURL = strings.TrimSpace(baseURL)
+	model = strings.TrimSpace(model)
+	if baseURL != "" && !strings.HasPrefix(baseURL, "http://") && !strings.HasPrefix(baseURL, "https://") {
+		return fmt.Errorf("base URL must start with http:// or https://")
+	}
+	if model == "" {
+		return fmt.Errorf("model name must not be empty")
+	}
+	a.openaiClient.SetEndpoint(baseURL, model, apiKey)
+	a.config.SetOpenAIEndpoint(baseURL, model, apiKey)
+	return a.config.Save()
+}
+
+// SetRefinementEnabled turns refinement on or off. When off, the Whisper
+// transcription is used as is and nothing is sent to a refinement service.
+func (a *App) SetRefinementEnabled(enabled bool) error {
+	a.config.SetRefinementEnabled(enabled)
+	if a.refinementMenuItem != nil && a.refinementMenuItem.Checked != enabled {
+		a.refinementMenuItem.Checked = enabled
+		runtime.MenuUpdateApplicationMenu(a.ctx)
+	}
+	return a.config.Save()
+}
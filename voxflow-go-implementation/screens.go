@@ -0,0 +1,38 @@
+package main
+
+// screenRect is a rectangle in window-position coordinates: top-left origin,
+// relative to the visible area of the screen the window is on
+type screenRect struct {
+	X, Y, Width, Height int
+}
+
+// overlap returns the area r shares with s
+func (r screenRect) overlap(s screenRect) int {
+	w := min(r.X+r.Width, s.X+s.Width) - max(r.X, s.X)
+	h := min(r.Y+r.Height, s.Y+s.Height) - max(r.Y, s.Y)
+	if w <= 0 || h <= 0 {
+		return 0
+	}
+	return w * h
+}
+
+// clampToScreens returns r moved, and shrunk if needed, to lie on one of
+// screens: the one it overlaps most, or the first if it's off all of them.
+// r is returned unchanged if it already fits on a screen or none are known.
+func clampToScreens(r screenRect, screens []screenRect) screenRect {
+	if len(screens) == 0 {
+		return r
+	}
+	best, bestOverlap := screens[0], 0
+	for _, s := range screens {
+		if o := r.overlap(s); o > bestOverlap {
+			best, bestOverlap = s, o
+		}
+	}
+
+	r.Width = min(r.Width, best.Width)
+	r.Height = min(r.Height, best.Height)
+	r.X = max(best.X, min(r.X, best.X+best.Width-r.Width))
+	r.Y = max(best.Y, min(r.Y, best.Y+best.Height-r.Height))
+	return r
+}
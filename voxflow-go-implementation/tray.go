@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// startTray shows the menu bar status item if enabled and keeps it in step
+// with the recording state
+func (a *App) startTray() {
+	if a.config.GetShowTrayIcon() {
+		ShowTray()
+		SetTrayState(a.state.String())
+	}
+	runtime.EventsOn(a.ctx, "state-changed", func(data ...interface{}) {
+		if len(data) == 0 {
+			return
+		}
+		if state, ok := data[0].(string); ok {
+			SetTrayState(state)
+		}
+	})
+	go a.handleTrayActions()
+}
+
+// handleTrayActions runs the status item's menu choices
+func (a *App) handleTrayActions() {
+	for action := range TrayActions() {
+		switch action {
+		case trayToggle:
+			if result := a.ToggleRecording(); strings.HasPrefix(result, "Error") {
+				fmt.Printf("[Tray] %s\n", result)
+			}
+		case trayHistory:
+			if a.miniMode() {
+				a.HideMiniMode()
+			}
+			runtime.WindowShow(a.ctx)
+			a.OpenHistoryWindow()
+		case trayAbort:
+			if err := a.AbortProcessing(); err != nil {
+				fmt.Printf("[Tray] %v\n", err)
+			}
+		case trayQuit:
+			a.Quit()
+		}
+	}
+}
+
+// SetShowTrayIcon shows or hides the menu bar status item
+func (a *App) SetShowTrayIcon(enabled bool) error {
+	if enabled {
+		ShowTray()
+		SetTrayState(a.state.String())
+	} else {
+		HideTray()
+	}
+	a.config.SetShowTrayIcon(enabled)
+	return a.config.Save()
+}
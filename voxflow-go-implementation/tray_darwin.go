@@ -0,0 +1,60 @@
+//go:build darwin
+
+package main
+
+/*
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework Cocoa
+
+void showTray(void);
+void hideTray(void);
+void setTrayState(int state);
+*/
+import "C"
+
+// Tray menu actions, as sent by tray_darwin.m
+const (
+	trayToggle = iota
+	trayHistory
+	trayQuit
+	trayAbort
+)
+
+var trayActions = make(chan int, 1)
+
+//export goTrayAction
+func goTrayAction(action C.int) {
+	// Runs on the main thread: hand the action off so it can't block the menu
+	select {
+	case trayActions <- int(action):
+	default:
+	}
+}
+
+// ShowTray adds the voxflow status item to the menu bar
+func ShowTray() {
+	C.showTray()
+}
+
+// HideTray removes the voxflow status item from the menu bar
+func HideTray() {
+	C.hideTray()
+}
+
+// SetTrayState updates the status item for a state-changed state
+// ("Idle", "Recording" or "Processing")
+func SetTrayState(state string) {
+	switch state {
+	case "Recording":
+		C.setTrayState(1)
+	case "Processing":
+		C.setTrayState(2)
+	default:
+		C.setTrayState(0)
+	}
+}
+
+// TrayActions returns the channel that receives menu choices from the status item
+func TrayActions() <-chan int {
+	return trayActions
+}
@@ -0,0 +1,101 @@
+#import <Cocoa/Cocoa.h>
+
+extern void goTrayAction(int action);
+
+// Keep in sync with the tray* constants in tray_darwin.go
+enum { trayToggle = 0, trayHistory = 1, trayQuit = 2, trayAbort = 3 };
+
+@interface VoxflowTrayTarget : NSObject
+@end
+
+@implementation VoxflowTrayTarget
+- (void)itemClicked:(NSMenuItem *)item {
+    goTrayAction((int)[item tag]);
+}
+@end
+
+static NSStatusItem *statusItem;
+static NSMenuItem *toggleItem;
+static NSMenuItem *abortItem;
+static VoxflowTrayTarget *trayTarget;
+
+static NSMenuItem *trayMenuItem(NSString *title, int action, NSString *key) {
+    NSMenuItem *item = [[NSMenuItem alloc] initWithTitle:title action:@selector(itemClicked:) keyEquivalent:key];
+    [item setTarget:trayTarget];
+    [item setTag:action];
+    return [item autorelease];
+}
+
+// showTray adds the status item to the menu bar, if it isn't there yet
+void showTray(void) {
+    dispatch_async(dispatch_get_main_queue(), ^{
+        if (statusItem != nil) return;
+        if (trayTarget == nil) {
+            trayTarget = [[VoxflowTrayTarget alloc] init];
+        }
+        statusItem = [[[NSStatusBar systemStatusBar] statusItemWithLength:NSVariableStatusItemLength] retain];
+
+        NSMenu *menu = [[[NSMenu alloc] init] autorelease];
+        // setTrayState enables and disables items itself; with autoenabling
+        // on, AppKit would re-enable them since they all have a target
+        [menu setAutoenablesItems:NO];
+        toggleItem = trayMenuItem(@"Start Recording", trayToggle, @"");
+        [menu addItem:toggleItem];
+        abortItem = trayMenuItem(@"Cancel Processing", trayAbort, @"");
+        [abortItem setHidden:YES];
+        [menu addItem:abortItem];
+        [menu addItem:trayMenuItem(@"Open History", trayHistory, @"")];
+        [menu addItem:[NSMenuItem separatorItem]];
+        [menu addItem:trayMenuItem(@"Quit voxflow", trayQuit, @"q")];
+        [statusItem setMenu:menu];
+    });
+}
+
+// hideTray removes the status item from the menu bar
+void hideTray(void) {
+    dispatch_async(dispatch_get_main_queue(), ^{
+        if (statusItem == nil) return;
+        [[NSStatusBar systemStatusBar] removeStatusItem:statusItem];
+        [statusItem release];
+        statusItem = nil;
+        toggleItem = nil;
+        abortItem = nil;
+    });
+}
+
+// setTrayState updates the icon and toggle item: 0 = idle, 1 = recording, 2 = processing
+void setTrayState(int state) {
+    dispatch_async(dispatch_get_main_queue(), ^{
+        if (statusItem == nil) return;
+        NSString *symbol = @"mic";
+        NSString *fallback = @"◦";
+        NSString *toggleTitle = @"Start Recording";
+        if (state == 1) {
+            symbol = @"mic.fill";
+            fallback = @"●";
+            toggleTitle = @"Stop Recording";
+        } else if (state == 2) {
+            symbol = @"ellipsis.circle";
+            fallback = @"…";
+            toggleTitle = @"Processing…";
+        }
+
+        NSStatusBarButton *button = [statusItem button];
+        NSImage *image = nil;
+        if (@available(macOS 11.0, *)) {
+            image = [NSImage imageWithSystemSymbolName:symbol accessibilityDescription:@"voxflow"];
+        }
+        if (image != nil) {
+            [image setTemplate:YES];
+            [button setImage:image];
+            [button setContentTintColor:(state == 1 ? [NSColor systemRedColor] : nil)];
+            [button setTitle:@""];
+        } else {
+            [button setTitle:fallback];
+        }
+
+        [toggleItem setTitle:toggleTitle];
+        [toggleItem setEnabled:(state != 2)];
+        [abortItem setHidden:(state != 2)];
+    });
+}
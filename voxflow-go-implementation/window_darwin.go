@@ -32,6 +32,15 @@ void makeWindowFloatEverywhere() {
     });
 }
 
+void setWindowAlpha(double alpha) {
+    dispatch_async(dispatch_get_main_queue(), ^{
+        NSApplication *app = [NSApplication sharedApplication];
+        for (NSWindow *window in [app windows]) {
+            [window setAlphaValue:alpha];
+        }
+    });
+}
+
 void resetWindowBehavior() {
     dispatch_async(dispatch_get_main_queue(), ^{
         NSApplication *app = [NSApplication sharedApplication];
@@ -42,16 +51,159 @@ void resetWindowBehavior() {
         }
     });
 }
+
+// Keep the window on screen and above other apps without activating it
+void pinWindowVisible() {
+    dispatch_async(dispatch_get_main_queue(), ^{
+        NSApplication *app = [NSApplication sharedApplication];
+        for (NSWindow *window in [app windows]) {
+            [window setHidesOnDeactivate:NO];
+            [window setLevel:101];
+            [window orderFrontRegardless];
+        }
+    });
+}
+
+// Give focus back to the previously active application
+void deactivateApp() {
+    dispatch_async(dispatch_get_main_queue(), ^{
+        [[NSApplication sharedApplication] deactivate];
+    });
+}
+
+#define MAX_SCREENS 16
+static int screenRects[MAX_SCREENS][4];
+
+// Snapshot the visible frames of all screens, relative to the window's screen
+// with a top-left origin (the coordinates Wails uses for window positions),
+// as x, y, width, height. Returns the number of screens; read them with
+// screenRectValue.
+int loadScreenRects(void) {
+    __block int count = 0;
+    void (^read)(void) = ^{
+        NSApplication *app = [NSApplication sharedApplication];
+        NSWindow *window = [[app windows] firstObject];
+        NSScreen *current = (window && [window screen]) ? [window screen] : [NSScreen mainScreen];
+        NSRect base = [current visibleFrame];
+        for (NSScreen *screen in [NSScreen screens]) {
+            if (count >= MAX_SCREENS) break;
+            NSRect f = [screen visibleFrame];
+            screenRects[count][0] = (int)(f.origin.x - base.origin.x);
+            screenRects[count][1] = (int)((base.origin.y + base.size.height) - (f.origin.y + f.size.height));
+            screenRects[count][2] = (int)f.size.width;
+            screenRects[count][3] = (int)f.size.height;
+            count++;
+        }
+    };
+    if ([NSThread isMainThread]) {
+        read();
+    } else {
+        dispatch_sync(dispatch_get_main_queue(), read);
+    }
+    return count;
+}
+
+// field: 0 = x, 1 = y, 2 = width, 3 = height
+int screenRectValue(int i, int field) {
+    return screenRects[i][field];
+}
+
+// Whether voxflow is the active app with one of its windows focused
+int isWindowFocused(void) {
+    __block int focused = 0;
+    void (^read)(void) = ^{
+        NSApplication *app = [NSApplication sharedApplication];
+        focused = [app isActive] && [app keyWindow] != nil;
+    };
+    if ([NSThread isMainThread]) {
+        read();
+    } else {
+        dispatch_sync(dispatch_get_main_queue(), read);
+    }
+    return focused;
+}
+
+// appearance: 0 = follow system, 1 = light (Aqua), 2 = dark (DarkAqua)
+void setAppAppearance(int appearance) {
+    dispatch_async(dispatch_get_main_queue(), ^{
+        NSApplication *app = [NSApplication sharedApplication];
+        switch (appearance) {
+            case 1:
+                [app setAppearance:[NSAppearance appearanceNamed:NSAppearanceNameAqua]];
+                break;
+            case 2:
+                [app setAppearance:[NSAppearance appearanceNamed:NSAppearanceNameDarkAqua]];
+                break;
+            default:
+                [app setAppearance:nil];
+                break;
+        }
+    });
+}
 */
 import "C"
 
+import "sync"
+
 // MakeWindowFloatEverywhere makes the window visible on all desktops/spaces
 // and able to appear over fullscreen applications
 func MakeWindowFloatEverywhere() {
 	C.makeWindowFloatEverywhere()
 }
 
+// SetWindowAlpha sets the window opacity (0-1)
+func SetWindowAlpha(alpha float64) {
+	C.setWindowAlpha(C.double(alpha))
+}
+
 // ResetWindowBehavior resets the window to normal behavior
 func ResetWindowBehavior() {
 	C.resetWindowBehavior()
 }
+
+// SetAppAppearance sets the NSApplication appearance ("system", "light" or "dark")
+func SetAppAppearance(appearance string) {
+	switch appearance {
+	case "light":
+		C.setAppAppearance(1)
+	case "dark":
+		C.setAppAppearance(2)
+	default:
+		C.setAppAppearance(0)
+	}
+}
+
+// PinWindowVisible brings the window to the front without taking focus and
+// stops it hiding when another app is activated
+func PinWindowVisible() {
+	C.pinWindowVisible()
+}
+
+// screensMu guards the C-side snapshot read by VisibleScreens
+var screensMu sync.Mutex
+
+// VisibleScreens returns the usable area (excluding menu bar and Dock) of
+// every connected screen, in window-position coordinates
+func VisibleScreens() []screenRect {
+	screensMu.Lock()
+	defer screensMu.Unlock()
+
+	n := int(C.loadScreenRects())
+	screens := make([]screenRect, n)
+	for i := range screens {
+		value := func(field int) int { return int(C.screenRectValue(C.int(i), C.int(field))) }
+		screens[i] = screenRect{X: value(0), Y: value(1), Width: value(2), Height: value(3)}
+	}
+	return screens
+}
+
+// DeactivateApp hands keyboard focus back to the previously active application,
+// so a simulated paste lands there instead of in voxflow's own window
+func DeactivateApp() {
+	C.deactivateApp()
+}
+
+// IsWindowFocused reports whether the user is looking at voxflow's window
+func IsWindowFocused() bool {
+	return C.isWindowFocused() != 0
+}
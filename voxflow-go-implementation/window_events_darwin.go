@@ -0,0 +1,34 @@
+//go:build darwin
+
+package main
+
+/*
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework Cocoa
+
+void observeWindowMoves(void);
+*/
+import "C"
+
+import "sync"
+
+var (
+	windowMoved     = make(chan struct{}, 1)
+	observeMovesOne sync.Once
+)
+
+//export goWindowMoved
+func goWindowMoved() {
+	// Runs on the main thread: just flag the move, the reader does the work
+	select {
+	case windowMoved <- struct{}{}:
+	default:
+	}
+}
+
+// WindowMoves returns a channel that receives after the window is moved or
+// resized. Moves in quick succession may be coalesced into one receive.
+func WindowMoves() <-chan struct{} {
+	observeMovesOne.Do(func() { C.observeWindowMoves() })
+	return windowMoved
+}
@@ -0,0 +1,17 @@
+#import <Cocoa/Cocoa.h>
+
+extern void goWindowMoved(void);
+
+// observeWindowMoves calls goWindowMoved whenever a window is moved or resized
+void observeWindowMoves(void) {
+    dispatch_async(dispatch_get_main_queue(), ^{
+        NSNotificationCenter *center = [NSNotificationCenter defaultCenter];
+        for (NSWindow *window in [[NSApplication sharedApplication] windows]) {
+            for (NSNotificationName name in @[NSWindowDidMoveNotification, NSWindowDidResizeNotification]) {
+                [center addObserverForName:name object:window queue:nil usingBlock:^(NSNotification *note) {
+                    goWindowMoved();
+                }];
+            }
+        }
+    });
+}